@@ -17,3 +17,73 @@ const SecondaryClick = 1
 
 // Click of both the primary and secondary button
 const BothClick = 2
+
+// Mines are placed when the minefield is generated and a random patch is revealed
+const SafeStartNone = 0
+
+// Mines are placed on the first reveal, keeping the revealed tile free of mines
+const SafeStartTile = 1
+
+// Mines are placed on the first reveal, keeping the revealed tile and its
+// adjacent tiles free of mines
+const SafeStartArea = 2
+
+// Tiles are squares, each adjacent to the 8 tiles around it
+const TopologySquare = 0
+
+// Tiles are hexagons, each adjacent to 6 tiles, with the odd rows shifted right
+// by half a tile
+const TopologyHex = 1
+
+// Tiles are squares and the edges of the board wrap around, so every tile is
+// adjacent to the 8 tiles around it
+const TopologyTorus = 2
+
+// Tiles are squares, each adjacent to the tiles a knight's move away from it
+const TopologyKnight = 3
+
+// Tiles are squares, each adjacent to the 24 tiles up to two rows and two cols
+// away from it
+const TopologyRadiusTwo = 4
+
+// A tile reveal made by the player
+const ActionReveal = 0
+
+// A flag added to or removed from a tile by the player
+const ActionFlag = 1
+
+// A reveal of the tiles adjacent to a revealed tile made by the player
+const ActionProcessAdjacent = 2
+
+// An undo of the last action made by the player
+const ActionUndo = 3
+
+// A redo of the last action undone by the player
+const ActionRedo = 4
+
+// A hint given to the player
+const ActionHint = 5
+
+// Tiles of a game were revealed
+const EventTileRevealed = 0
+
+// Tiles of a game were hidden again, by an undo
+const EventTileHidden = 1
+
+// A flag was added to or removed from a tile
+const EventFlagToggled = 2
+
+// Tiles with a mine were revealed, each taking one of the game's lives
+const EventLifeLost = 3
+
+// The game ended on a win
+const EventGameWon = 4
+
+// The game ended on a loss
+const EventGameLost = 5
+
+// The game started, on the first tile reveal
+const EventTimerStarted = 6
+
+// The game is on going again, after an undo of the action that ended it
+const EventGameResumed = 7
@@ -0,0 +1,331 @@
+/*
+Package coop lets several players act on the same game concurrently, with each
+action attributed to the player that made it
+*/
+package coop
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+)
+
+// Error: The player has not joined the game
+type unknownPlayerError struct {
+	Player string
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e unknownPlayerError) Error() string {
+	return fmt.Sprintf("The player '%v' has not joined the game", e.Player)
+}
+
+// Error: A player with the same ID has already joined the game
+type duplicatePlayerError struct {
+	Player string
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e duplicatePlayerError) Error() string {
+	return fmt.Sprintf("The player '%v' has already joined the game", e.Player)
+}
+
+// Error: The flag was placed by another player, who is the only one that can
+// remove it
+type flagOwnedError struct {
+	Player   string
+	Owner    string
+	RowIndex int
+	ColIndex int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e flagOwnedError) Error() string {
+	return fmt.Sprintf(
+		"The flag on row '%v' and column '%v' was placed by '%v' and can not be removed by '%v'",
+		e.RowIndex, e.ColIndex, e.Owner, e.Player)
+}
+
+// Action describes a change made to the game by a player
+type action struct {
+	Player string
+	// One of the configs.ActionReveal, configs.ActionFlag or
+	// configs.ActionProcessAdjacent constants
+	Kind     int
+	RowIndex int
+	ColIndex int
+	// The indexes of the tiles that were revealed or had their flag toggled
+	TileIndexes []int
+	Timestamp   time.Time
+}
+
+// PlayerStats contains what a player contributed to the game
+type playerStats struct {
+	// The number of tiles the player revealed, including mines
+	TilesRevealed int
+	// The number of flags placed by the player that are still on the board
+	Flags int
+	// The number of lives of the shared pool the player lost
+	MinesHit int
+}
+
+// Coop holds a game shared by several players
+type coop struct {
+	// Held during each action, so the game and the attribution of the action
+	// change together
+	mutex   sync.Mutex
+	game    game.IGame
+	players map[string]*playerStats
+	// The players that placed each flag, keyed by the flagged tile's index
+	flagOwners map[int]string
+	actions    []action
+	now        func() time.Time
+}
+
+/*
+New creates a game shared by the players with the provided IDs.
+Every player draws from the same pool of lives, the configuration's Lives.
+*/
+func New(gameConfig game.GameConfig, players []string) (ICoop, error) {
+	gameInstance, error := game.Generate(gameConfig)
+	if error != nil {
+		return nil, error
+	}
+
+	coopGame := &coop{
+		game:       gameInstance,
+		players:    map[string]*playerStats{},
+		flagOwners: map[int]string{},
+		now:        time.Now,
+	}
+	for _, player := range players {
+		error = coopGame.Join(player)
+		if error != nil {
+			return nil, error
+		}
+	}
+
+	return coopGame, nil
+}
+
+/*
+Join adds a player to the game.
+*/
+func (coop *coop) Join(player string) error {
+	coop.mutex.Lock()
+	defer coop.mutex.Unlock()
+
+	if _, ok := coop.players[player]; ok {
+		return duplicatePlayerError{
+			Player: player,
+		}
+	}
+
+	coop.players[player] = &playerStats{}
+	return nil
+}
+
+/*
+RevealTile reveals the requested tile on behalf of the player.
+If the tile is empty the patch it belongs to will be revealed.
+*/
+func (coop *coop) RevealTile(player string, rowIndex int, colIndex int) ([]int, error) {
+	coop.mutex.Lock()
+	defer coop.mutex.Unlock()
+
+	error := coop.checkPlayer(player)
+	if error != nil {
+		return nil, error
+	}
+
+	tileIndexes, error := coop.game.RevealTile(rowIndex, colIndex)
+	if error != nil {
+		return tileIndexes, error
+	}
+
+	coop.recordReveal(player, configs.ActionReveal, rowIndex, colIndex, tileIndexes)
+	return tileIndexes, nil
+}
+
+/*
+ToggleFlag flips the flag state for the requested tile on behalf of the player.
+A flag can only be removed by the player that placed it.
+*/
+func (coop *coop) ToggleFlag(player string, rowIndex int, colIndex int) error {
+	coop.mutex.Lock()
+	defer coop.mutex.Unlock()
+
+	error := coop.checkPlayer(player)
+	if error != nil {
+		return error
+	}
+
+	tile, error := coop.game.Tile(rowIndex, colIndex)
+	if error != nil {
+		return error
+	}
+
+	tileIndex := rowIndex*coop.game.Config().NumCols + colIndex
+	owner, owned := coop.flagOwners[tileIndex]
+	if tile.HasFlag() && owned && owner != player {
+		return flagOwnedError{
+			Player:   player,
+			Owner:    owner,
+			RowIndex: rowIndex,
+			ColIndex: colIndex,
+		}
+	}
+
+	hadFlag := tile.HasFlag()
+	error = coop.game.ToggleFlag(rowIndex, colIndex)
+	if error != nil {
+		return error
+	}
+	tile, _ = coop.game.Tile(rowIndex, colIndex)
+	// Flags can not be toggled on revealed tiles or once the game has ended
+	if tile.HasFlag() == hadFlag {
+		return nil
+	}
+
+	if tile.HasFlag() {
+		coop.flagOwners[tileIndex] = player
+		coop.players[player].Flags++
+	} else {
+		delete(coop.flagOwners, tileIndex)
+		coop.players[player].Flags--
+	}
+
+	coop.actions = append(coop.actions, action{
+		Player:      player,
+		Kind:        configs.ActionFlag,
+		RowIndex:    rowIndex,
+		ColIndex:    colIndex,
+		TileIndexes: []int{tileIndex},
+		Timestamp:   coop.now(),
+	})
+
+	return nil
+}
+
+/*
+ProcessAdjacentTiles reveals, on behalf of the player, the tiles adjacent to a
+revealed tile that do not have a flag, if there are enough adjacent flags.
+The flags of every player are counted.
+*/
+func (coop *coop) ProcessAdjacentTiles(player string, rowIndex int, colIndex int) ([]int, error) {
+	coop.mutex.Lock()
+	defer coop.mutex.Unlock()
+
+	error := coop.checkPlayer(player)
+	if error != nil {
+		return nil, error
+	}
+
+	tileIndexes, error := coop.game.ProcessAdjacentTiles(rowIndex, colIndex)
+	if error != nil {
+		return tileIndexes, error
+	}
+
+	coop.recordReveal(player, configs.ActionProcessAdjacent, rowIndex, colIndex, tileIndexes)
+	return tileIndexes, nil
+}
+
+/*
+FlagOwner returns the player that placed the flag on the requested tile.
+Returns false if the tile does not have a flag.
+*/
+func (coop *coop) FlagOwner(rowIndex int, colIndex int) (string, bool) {
+	coop.mutex.Lock()
+	defer coop.mutex.Unlock()
+
+	owner, ok := coop.flagOwners[rowIndex*coop.game.Config().NumCols+colIndex]
+	return owner, ok
+}
+
+/*
+Actions returns the actions made by the players, oldest first.
+*/
+func (coop *coop) Actions() []action {
+	coop.mutex.Lock()
+	defer coop.mutex.Unlock()
+
+	return append([]action{}, coop.actions...)
+}
+
+/*
+PlayerStats returns what each player contributed to the game, keyed by player.
+*/
+func (coop *coop) PlayerStats() map[string]playerStats {
+	coop.mutex.Lock()
+	defer coop.mutex.Unlock()
+
+	output := make(map[string]playerStats, len(coop.players))
+	for player, stats := range coop.players {
+		output[player] = *stats
+	}
+
+	return output
+}
+
+/*
+View calls the function with a read only view of the shared game, while no
+player can act on it.
+*/
+func (coop *coop) View(callback func(gameView game.IGameView)) {
+	coop.mutex.Lock()
+	defer coop.mutex.Unlock()
+
+	callback(coop.game)
+}
+
+/*
+checkPlayer returns an error if the player has not joined the game.
+The mutex must be held.
+*/
+func (coop *coop) checkPlayer(player string) error {
+	if _, ok := coop.players[player]; !ok {
+		return unknownPlayerError{
+			Player: player,
+		}
+	}
+
+	return nil
+}
+
+/*
+recordReveal attributes the revealed tiles, and the mines hit, to the player.
+The mutex must be held.
+*/
+func (coop *coop) recordReveal(player string, kind int, rowIndex int, colIndex int, tileIndexes []int) {
+	if len(tileIndexes) == 0 {
+		return
+	}
+
+	numCols := coop.game.Config().NumCols
+	stats := coop.players[player]
+	stats.TilesRevealed += len(tileIndexes)
+	for _, tileIndex := range tileIndexes {
+		tile, _ := coop.game.Tile(tileIndex/numCols, tileIndex%numCols)
+		if tile.HasMine() {
+			stats.MinesHit++
+		}
+	}
+
+	coop.actions = append(coop.actions, action{
+		Player:      player,
+		Kind:        kind,
+		RowIndex:    rowIndex,
+		ColIndex:    colIndex,
+		TileIndexes: tileIndexes,
+		Timestamp:   coop.now(),
+	})
+}
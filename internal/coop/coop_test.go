@@ -0,0 +1,163 @@
+package coop_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/coop"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type coopTestSuite struct {
+	suite.Suite
+	sutArgs game.GameConfig
+}
+
+func (suite *coopTestSuite) SetupTest() {
+	// The mines are on the first and last tiles of the first row
+	suite.sutArgs = game.GameConfig{
+		NumRows:      4,
+		NumCols:      4,
+		NumMines:     2,
+		FlagsEnabled: true,
+		Lives:        2,
+		MineTiles:    []int{0, 3},
+	}
+}
+
+func (suite *coopTestSuite) TestNewReturnsAnErrorIfAPlayerIsRepeated() {
+	_, err := coop.New(suite.sutArgs, []string{"ana", "ana"})
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *coopTestSuite) TestActionsOfPlayersThatHaveNotJoinedReturnAnError() {
+	sut, _ := coop.New(suite.sutArgs, []string{"ana"})
+
+	_, err := sut.RevealTile("bob", 3, 3)
+	require.NotNil(suite.T(), err)
+
+	err = sut.ToggleFlag("bob", 0, 0)
+	require.NotNil(suite.T(), err)
+
+	_, err = sut.ProcessAdjacentTiles("bob", 3, 3)
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *coopTestSuite) TestJoinAddsAPlayerThatCanAct() {
+	sut, _ := coop.New(suite.sutArgs, []string{"ana"})
+
+	err := sut.Join("bob")
+	require.Nil(suite.T(), err)
+
+	_, err = sut.RevealTile("bob", 3, 3)
+	require.Nil(suite.T(), err)
+}
+
+func (suite *coopTestSuite) TestActionsAreAttributedToThePlayerThatMadeThem() {
+	sut, _ := coop.New(suite.sutArgs, []string{"ana", "bob"})
+	sut.RevealTile("ana", 3, 3)
+	sut.ToggleFlag("bob", 0, 0)
+
+	actual := sut.Actions()
+
+	require.Equal(suite.T(), 2, len(actual))
+	require.Equal(suite.T(), "ana", actual[0].Player)
+	require.Equal(suite.T(), configs.ActionReveal, actual[0].Kind)
+	require.Equal(suite.T(), "bob", actual[1].Player)
+	require.Equal(suite.T(), configs.ActionFlag, actual[1].Kind)
+	require.Equal(suite.T(), []int{0}, actual[1].TileIndexes)
+}
+
+func (suite *coopTestSuite) TestAFlagCanOnlyBeRemovedByThePlayerThatPlacedIt() {
+	sut, _ := coop.New(suite.sutArgs, []string{"ana", "bob"})
+	sut.ToggleFlag("ana", 0, 0)
+
+	err := sut.ToggleFlag("bob", 0, 0)
+	require.NotNil(suite.T(), err)
+	owner, ok := sut.FlagOwner(0, 0)
+	require.Equal(suite.T(), true, ok)
+	require.Equal(suite.T(), "ana", owner)
+
+	err = sut.ToggleFlag("ana", 0, 0)
+	require.Nil(suite.T(), err)
+	_, ok = sut.FlagOwner(0, 0)
+	require.Equal(suite.T(), false, ok)
+}
+
+func (suite *coopTestSuite) TestTheFlagsOfEveryPlayerAreCountedToRevealAdjacentTiles() {
+	sut, _ := coop.New(suite.sutArgs, []string{"ana", "bob"})
+	sut.RevealTile("ana", 1, 1)
+	sut.ToggleFlag("bob", 0, 0)
+
+	actual, err := sut.ProcessAdjacentTiles("ana", 1, 1)
+
+	require.Nil(suite.T(), err)
+	require.NotEmpty(suite.T(), actual)
+}
+
+func (suite *coopTestSuite) TestThePlayersShareThePoolOfLives() {
+	sut, _ := coop.New(suite.sutArgs, []string{"ana", "bob"})
+	sut.RevealTile("ana", 0, 0)
+
+	sut.View(func(gameView game.IGameView) {
+		require.Equal(suite.T(), 1, gameView.Stats().RemainingLives)
+		require.Equal(suite.T(), configs.StateOnGoing, gameView.State())
+	})
+
+	sut.RevealTile("bob", 0, 3)
+
+	sut.View(func(gameView game.IGameView) {
+		require.Equal(suite.T(), 0, gameView.Stats().RemainingLives)
+		require.Equal(suite.T(), configs.StateLoss, gameView.State())
+	})
+	stats := sut.PlayerStats()
+	require.Equal(suite.T(), 1, stats["ana"].MinesHit)
+	require.Equal(suite.T(), 1, stats["bob"].MinesHit)
+}
+
+func (suite *coopTestSuite) TestPlayerStatsCountTheTilesRevealedAndTheFlagsOfEachPlayer() {
+	sut, _ := coop.New(suite.sutArgs, []string{"ana", "bob"})
+	sut.RevealTile("ana", 3, 3)
+	sut.ToggleFlag("bob", 0, 0)
+	sut.ToggleFlag("bob", 0, 3)
+
+	actual := sut.PlayerStats()
+
+	require.Equal(suite.T(), 12, actual["ana"].TilesRevealed)
+	require.Equal(suite.T(), 0, actual["ana"].Flags)
+	require.Equal(suite.T(), 2, actual["bob"].Flags)
+	require.Equal(suite.T(), 0, actual["bob"].TilesRevealed)
+}
+
+func (suite *coopTestSuite) TestConcurrentActionsOfSeveralPlayersAreAllApplied() {
+	suite.sutArgs.NumRows = 10
+	suite.sutArgs.NumCols = 10
+	sut, _ := coop.New(suite.sutArgs, []string{"ana", "bob", "eve", "joe"})
+
+	waitGroup := sync.WaitGroup{}
+	for playerIndex, player := range []string{"ana", "bob", "eve", "joe"} {
+		waitGroup.Add(1)
+		go func(playerIndex int, player string) {
+			defer waitGroup.Done()
+			for rowIndex := 1 + playerIndex*2; rowIndex < 3+playerIndex*2; rowIndex++ {
+				for colIndex := 0; colIndex < 10; colIndex++ {
+					sut.ToggleFlag(player, rowIndex, colIndex)
+				}
+			}
+		}(playerIndex, player)
+	}
+	waitGroup.Wait()
+
+	require.Equal(suite.T(), 80, len(sut.Actions()))
+	for _, stats := range sut.PlayerStats() {
+		require.Equal(suite.T(), 20, stats.Flags)
+	}
+}
+
+func TestCoopSuite(t *testing.T) {
+	suite.Run(t, new(coopTestSuite))
+}
@@ -0,0 +1,49 @@
+package coop
+
+import (
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+)
+
+type ICoop interface {
+	/*
+		Join adds a player to the game.
+	*/
+	Join(player string) error
+	/*
+		RevealTile reveals the requested tile on behalf of the player.
+		If the tile is empty the patch it belongs to will be revealed.
+	*/
+	RevealTile(player string, rowIndex int, colIndex int) ([]int, error)
+	/*
+		ToggleFlag flips the flag state for the requested tile on behalf of the
+		player.
+		A flag can only be removed by the player that placed it.
+	*/
+	ToggleFlag(player string, rowIndex int, colIndex int) error
+	/*
+		ProcessAdjacentTiles reveals, on behalf of the player, the tiles adjacent
+		to a revealed tile that do not have a flag, if there are enough adjacent
+		flags.
+		The flags of every player are counted.
+	*/
+	ProcessAdjacentTiles(player string, rowIndex int, colIndex int) ([]int, error)
+	/*
+		FlagOwner returns the player that placed the flag on the requested tile.
+		Returns false if the tile does not have a flag.
+	*/
+	FlagOwner(rowIndex int, colIndex int) (string, bool)
+	/*
+		Actions returns the actions made by the players, oldest first.
+	*/
+	Actions() []action
+	/*
+		PlayerStats returns what each player contributed to the game, keyed by
+		player.
+	*/
+	PlayerStats() map[string]playerStats
+	/*
+		View calls the function with a read only view of the shared game, while
+		no player can act on it.
+	*/
+	View(callback func(gameView game.IGameView))
+}
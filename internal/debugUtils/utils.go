@@ -16,9 +16,11 @@ func PrintMinefield(minefield minefield.IMinefield, revealAll bool) {
 
 	for rIndex := 0; rIndex < minefield.Rows(); rIndex++ {
 		for cIndex := 0; cIndex < minefield.Cols(); cIndex++ {
-			tile, _ := minefield.Tile(rIndex, cIndex)
+			tile, error := minefield.Tile(rIndex, cIndex)
 
-			if tile.Revealed() || revealAll {
+			if error != nil {
+				fmt.Printf("   ")
+			} else if tile.Revealed() || revealAll {
 				if tile.HasMine() {
 					fmt.Printf(" %v ", "X")
 				} else {
@@ -45,9 +47,11 @@ func PrintMinefieldFromGame(game game.IGame, revealAll bool) {
 
 	for rIndex := 0; rIndex < game.Config().NumRows; rIndex++ {
 		for cIndex := 0; cIndex < game.Config().NumCols; cIndex++ {
-			tile, _ := game.Tile(rIndex, cIndex)
+			tile, error := game.Tile(rIndex, cIndex)
 
-			if tile.Revealed() || revealAll {
+			if error != nil {
+				fmt.Printf("   ")
+			} else if tile.Revealed() || revealAll {
 				if tile.HasMine() {
 					fmt.Printf(" %v ", "X")
 				} else {
@@ -0,0 +1,221 @@
+/*
+Package deduction finds which tiles of a board are certainly safe or certainly
+mines, and the probability of each tile having a mine, from the numbers a
+player can see.
+It only knows the tiles, the tiles adjacent to each of them and the number of
+mines, so the generator of the minefields and the solver share the same rules.
+*/
+package deduction
+
+import "fmt"
+
+// Error: The visible numbers and flags can not be satisfied by any mine layout
+type inconsistentBoardError struct {
+	RowIndex int
+	ColIndex int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e inconsistentBoardError) Error() string {
+	return fmt.Sprintf(
+		"The number on the tile with row index '%v' and col index '%v' can not be satisfied by the visible board",
+		e.RowIndex, e.ColIndex)
+}
+
+// Error: The mines left can not be spread among the unknown tiles
+type inconsistentMineCountError struct {
+	MinesLeft int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e inconsistentMineCountError) Error() string {
+	return fmt.Sprintf(
+		"The '%v' mines left can not be spread among the unknown tiles of the visible board",
+		e.MinesLeft)
+}
+
+// Board holds the information a player has about a minefield
+type board struct {
+	cols     int
+	numMines int
+	// True for revealed tiles without a mine
+	revealed []bool
+	// The number on each revealed tile
+	numbers []int
+	// True for the tiles known to be mines and the tiles deduced to be mines
+	mines []bool
+	// True for hidden tiles deduced to be safe
+	safe []bool
+	// True for the tiles that are not revealed or known to be mines, whatever
+	// was deduced about them
+	hidden []bool
+	// True for the cells that are not tiles of the board
+	masked []bool
+	// The indexes of the tiles adjacent to each tile
+	adjacent [][]int
+	// The most search nodes visited while enumerating a frontier component, in
+	// the last enumeration
+	enumerationNodes int
+}
+
+// Constraint describes a revealed number and the unknown tiles around it
+type constraint struct {
+	tileIndex   int
+	tileIndexes []int
+	numMines    int
+}
+
+/*
+NewBoard creates a board of hidden tiles, laid out in rows of cols cells, with
+the provided number of mines and the indexes of the tiles adjacent to each tile.
+The cells that are not tiles of the board must be marked with Mask, and the
+tiles a player knows about with Reveal and MarkMine.
+*/
+func NewBoard(cols int, numMines int, adjacent [][]int) IBoard {
+	numTiles := len(adjacent)
+	board := &board{
+		cols:     cols,
+		numMines: numMines,
+		revealed: make([]bool, numTiles),
+		numbers:  make([]int, numTiles),
+		mines:    make([]bool, numTiles),
+		safe:     make([]bool, numTiles),
+		hidden:   make([]bool, numTiles),
+		masked:   make([]bool, numTiles),
+		adjacent: adjacent,
+	}
+	for tileIndex := range board.hidden {
+		board.hidden[tileIndex] = true
+	}
+
+	return board
+}
+
+/*
+Mask removes the cell with the provided index from the board.
+*/
+func (board *board) Mask(tileIndex int) {
+	board.masked[tileIndex] = true
+	board.hidden[tileIndex] = false
+}
+
+/*
+Reveal records the number of a revealed tile without a mine.
+*/
+func (board *board) Reveal(tileIndex int, adjacentMines int) {
+	board.revealed[tileIndex] = true
+	board.numbers[tileIndex] = adjacentMines
+	board.hidden[tileIndex] = false
+}
+
+/*
+MarkMine records a tile known to have a mine, such as a revealed mine or a
+flag that is trusted.
+*/
+func (board *board) MarkMine(tileIndex int) {
+	board.mines[tileIndex] = true
+	board.hidden[tileIndex] = false
+}
+
+/*
+unknown returns true if nothing is known about the tile with the provided index.
+The cells removed by a mask are never unknown.
+*/
+func (board *board) unknown(tileIndex int) bool {
+	return !board.revealed[tileIndex] && !board.mines[tileIndex] && !board.safe[tileIndex] &&
+		!board.masked[tileIndex]
+}
+
+/*
+constraints creates one constraint for each revealed number that still has
+unknown adjacent tiles.
+Returns an error if a number can not be satisfied.
+*/
+func (board *board) constraints() ([]constraint, error) {
+	constraints := []constraint{}
+
+	for tileIndex, revealed := range board.revealed {
+		if !revealed {
+			continue
+		}
+
+		unknownIndexes := []int{}
+		numMines := board.numbers[tileIndex]
+		for _, adjacentIndex := range board.adjacent[tileIndex] {
+			if board.mines[adjacentIndex] {
+				numMines--
+			} else if board.unknown(adjacentIndex) {
+				unknownIndexes = append(unknownIndexes, adjacentIndex)
+			}
+		}
+
+		if numMines < 0 || numMines > len(unknownIndexes) {
+			return nil, inconsistentBoardError{
+				RowIndex: tileIndex / board.cols,
+				ColIndex: tileIndex % board.cols,
+			}
+		}
+		if len(unknownIndexes) == 0 {
+			continue
+		}
+
+		constraints = append(constraints, constraint{
+			tileIndex:   tileIndex,
+			tileIndexes: unknownIndexes,
+			numMines:    numMines,
+		})
+	}
+
+	return constraints, nil
+}
+
+/*
+minesLeft returns the number of mines that are not known or deduced.
+*/
+func (board *board) minesLeft() int {
+	numMines := board.numMines
+	for _, isMine := range board.mines {
+		if isMine {
+			numMines--
+		}
+	}
+
+	return numMines
+}
+
+/*
+unknownTileIndexes returns the indexes of all the tiles nothing is known about.
+*/
+func (board *board) unknownTileIndexes() []int {
+	tileIndexes := []int{}
+	for tileIndex := range board.revealed {
+		if board.unknown(tileIndex) {
+			tileIndexes = append(tileIndexes, tileIndex)
+		}
+	}
+
+	return tileIndexes
+}
+
+/*
+enumerateComponents splits the constraints into independent frontier components
+and enumerates the mine layouts of each of them.
+*/
+func (board *board) enumerateComponents(constraints []constraint) []*component {
+	minesLeft := board.minesLeft()
+	components := splitComponents(constraints)
+
+	board.enumerationNodes = 0
+	for _, component := range components {
+		component.enumerate(minesLeft)
+		if component.numNodes > board.enumerationNodes {
+			board.enumerationNodes = component.numNodes
+		}
+	}
+
+	return components
+}
@@ -0,0 +1,139 @@
+package deduction_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/deduction"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+/*
+newBoard builds a board of square tiles where each row is a string with one
+character per cell.
+0-8 = revealed number | ? = hidden | F = known mine | . = masked
+*/
+func newBoard(numMines int, rows ...string) deduction.IBoard {
+	numRows := len(rows)
+	numCols := len(rows[0])
+
+	adjacent := make([][]int, numRows*numCols)
+	for rowIndex := 0; rowIndex < numRows; rowIndex++ {
+		for colIndex := 0; colIndex < numCols; colIndex++ {
+			tileIndexes := []int{}
+			for rIndex := rowIndex - 1; rIndex <= rowIndex+1; rIndex++ {
+				for cIndex := colIndex - 1; cIndex <= colIndex+1; cIndex++ {
+					if rIndex < 0 || rIndex >= numRows || cIndex < 0 || cIndex >= numCols {
+						continue
+					}
+					if rIndex == rowIndex && cIndex == colIndex || rows[rIndex][cIndex] == '.' {
+						continue
+					}
+					tileIndexes = append(tileIndexes, rIndex*numCols+cIndex)
+				}
+			}
+			adjacent[rowIndex*numCols+colIndex] = tileIndexes
+		}
+	}
+
+	board := deduction.NewBoard(numCols, numMines, adjacent)
+	for rowIndex, row := range rows {
+		for colIndex, char := range row {
+			tileIndex := rowIndex*numCols + colIndex
+			switch {
+			case char == '.':
+				board.Mask(tileIndex)
+			case char == 'F':
+				board.MarkMine(tileIndex)
+			case strings.ContainsRune("012345678", char):
+				board.Reveal(tileIndex, int(char-'0'))
+			}
+		}
+	}
+
+	return board
+}
+
+type boardTestSuite struct {
+	suite.Suite
+}
+
+func (suite *boardTestSuite) TestSolveReturnsTheSafeTilesAndTheMinesOfTheHiddenTiles() {
+	sut := newBoard(1, "1??")
+
+	result, err := sut.Solve()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{2}, result.SafeTiles)
+	require.Equal(suite.T(), []int{1}, result.MineTiles)
+}
+
+func (suite *boardTestSuite) TestSolveKeepsTheDeductionsAfterTheSafeTilesAreRevealed() {
+	sut := newBoard(1, "1??")
+	sut.Solve()
+
+	sut.Reveal(2, 1)
+	result, err := sut.Solve()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{}, result.SafeTiles)
+	require.Equal(suite.T(), []int{1}, result.MineTiles)
+}
+
+func (suite *boardTestSuite) TestSolveTrustsTheKnownMinesAndSkipsTheMaskedCells() {
+	sut := newBoard(1, "F1?.")
+
+	result, err := sut.Solve()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{2}, result.SafeTiles)
+	require.Equal(suite.T(), []int{}, result.MineTiles)
+}
+
+func (suite *boardTestSuite) TestSolveReturnsAnErrorIfANumberCanNotBeSatisfied() {
+	_, err := newBoard(1, "?0", "?1").Solve()
+
+	require.EqualError(suite.T(), err, "The number on the tile with row index '1' and col index '1' can not be satisfied by the visible board")
+}
+
+func (suite *boardTestSuite) TestProbabilitiesAreIndexedByTileIndex() {
+	actual, err := newBoard(1, "?1?").Probabilities()
+
+	require.Nil(suite.T(), err)
+	require.InDeltaSlice(suite.T(), []float64{0.5, 0, 0.5}, actual, 1e-9)
+}
+
+func (suite *boardTestSuite) TestHintReturnsACertainlySafeTile() {
+	actualIndex, actualProbability, err := newBoard(1, "1??").Hint()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), 2, actualIndex)
+	require.Equal(suite.T(), 0.0, actualProbability)
+}
+
+func (suite *boardTestSuite) TestHintReturnsTheTileLeastLikelyToHaveAMineIfNoneIsSafe() {
+	actualIndex, actualProbability, err := newBoard(2, "?1????").Hint()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), 3, actualIndex)
+	require.InDelta(suite.T(), 1.0/3, actualProbability, 1e-9)
+}
+
+func (suite *boardTestSuite) TestHintSpreadsTheMinesEvenlyIfAFrontierComponentIsTooLarge() {
+	row := ""
+	for index := 0; index < 45; index++ {
+		row += "?1"
+	}
+
+	actualIndex, actualProbability, err := newBoard(45, row+"?", row+"?").Hint()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), 0, actualIndex)
+	require.InDelta(suite.T(), 45.0/92, actualProbability, 1e-9)
+}
+
+func TestBoardSuite(t *testing.T) {
+	suite.Run(t, new(boardTestSuite))
+}
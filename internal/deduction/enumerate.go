@@ -0,0 +1,256 @@
+package deduction
+
+/*
+The maximum number of search nodes visited while enumerating the mine layouts
+of a single frontier component, before giving up on that component
+*/
+const maxEnumerationNodes int = 1 << 22
+
+// Component is a group of frontier tiles linked to each other by constraints
+type component struct {
+	tileIndexes []int
+	constraints []constraint
+	// Number of mine layouts found, keyed by the number of mines in the layout
+	numLayouts map[int]float64
+	// Number of layouts where each tile has a mine, keyed by the number of mines
+	// in the layout, in the same order as tileIndexes
+	tileMineCounts map[int][]float64
+	// False if the enumeration ran out of budget
+	complete bool
+	// The number of search nodes visited by the enumeration
+	numNodes int
+}
+
+/*
+splitComponents groups the constraints, and the tiles they cover, into
+independent frontier components.
+Two constraints belong to the same component if they share a tile.
+*/
+func splitComponents(constraints []constraint) []*component {
+	parents := make([]int, len(constraints))
+	for cIndex := range parents {
+		parents[cIndex] = cIndex
+	}
+	var find func(int) int
+	find = func(cIndex int) int {
+		if parents[cIndex] != cIndex {
+			parents[cIndex] = find(parents[cIndex])
+		}
+		return parents[cIndex]
+	}
+
+	firstConstraintByTile := map[int]int{}
+	for cIndex, constraint := range constraints {
+		for _, tileIndex := range constraint.tileIndexes {
+			if other, ok := firstConstraintByTile[tileIndex]; ok {
+				parents[find(cIndex)] = find(other)
+			} else {
+				firstConstraintByTile[tileIndex] = cIndex
+			}
+		}
+	}
+
+	componentsByRoot := map[int]*component{}
+	components := []*component{}
+	for cIndex, constraint := range constraints {
+		root := find(cIndex)
+		if _, ok := componentsByRoot[root]; !ok {
+			componentsByRoot[root] = &component{}
+			components = append(components, componentsByRoot[root])
+		}
+		componentsByRoot[root].constraints = append(componentsByRoot[root].constraints, constraint)
+	}
+
+	for _, component := range components {
+		seen := map[int]bool{}
+		for _, constraint := range component.constraints {
+			for _, tileIndex := range constraint.tileIndexes {
+				if !seen[tileIndex] {
+					seen[tileIndex] = true
+					component.tileIndexes = append(component.tileIndexes, tileIndex)
+				}
+			}
+		}
+	}
+
+	return components
+}
+
+/*
+enumerate finds every mine layout of the component's tiles that satisfies all
+its constraints and counts them by number of mines.
+Layouts with more than maxMines mines are not counted.
+*/
+func (component *component) enumerate(maxMines int) {
+	numTiles := len(component.tileIndexes)
+	positions := make(map[int]int, numTiles)
+	for position, tileIndex := range component.tileIndexes {
+		positions[tileIndex] = position
+	}
+
+	// For each tile, the constraints it belongs to
+	tileConstraints := make([][]int, numTiles)
+	// For each constraint, the mines still needed and the tiles still unassigned
+	minesNeeded := make([]int, len(component.constraints))
+	tilesLeft := make([]int, len(component.constraints))
+	for cIndex, constraint := range component.constraints {
+		minesNeeded[cIndex] = constraint.numMines
+		tilesLeft[cIndex] = len(constraint.tileIndexes)
+		for _, tileIndex := range constraint.tileIndexes {
+			tileConstraints[positions[tileIndex]] = append(tileConstraints[positions[tileIndex]], cIndex)
+		}
+	}
+
+	component.numLayouts = map[int]float64{}
+	component.tileMineCounts = map[int][]float64{}
+	component.complete = true
+
+	layout := make([]bool, numTiles)
+	numMines := 0
+	numNodes := 0
+
+	var search func(position int)
+	search = func(position int) {
+		numNodes++
+		if numNodes > maxEnumerationNodes {
+			component.complete = false
+			return
+		}
+
+		if position == numTiles {
+			if _, ok := component.tileMineCounts[numMines]; !ok {
+				component.tileMineCounts[numMines] = make([]float64, numTiles)
+			}
+			component.numLayouts[numMines]++
+			for tilePosition, hasMine := range layout {
+				if hasMine {
+					component.tileMineCounts[numMines][tilePosition]++
+				}
+			}
+			return
+		}
+
+		for _, hasMine := range []bool{false, true} {
+			if hasMine && numMines >= maxMines {
+				continue
+			}
+
+			valid := true
+			for _, cIndex := range tileConstraints[position] {
+				needed := minesNeeded[cIndex]
+				if hasMine {
+					needed--
+				}
+				if needed < 0 || needed > tilesLeft[cIndex]-1 {
+					valid = false
+					break
+				}
+			}
+			if !valid {
+				continue
+			}
+
+			for _, cIndex := range tileConstraints[position] {
+				tilesLeft[cIndex]--
+				if hasMine {
+					minesNeeded[cIndex]--
+				}
+			}
+			layout[position] = hasMine
+			if hasMine {
+				numMines++
+			}
+
+			search(position + 1)
+
+			for _, cIndex := range tileConstraints[position] {
+				tilesLeft[cIndex]++
+				if hasMine {
+					minesNeeded[cIndex]++
+				}
+			}
+			layout[position] = false
+			if hasMine {
+				numMines--
+			}
+
+			if !component.complete {
+				return
+			}
+		}
+	}
+
+	search(0)
+	component.numNodes = numNodes
+
+	if !component.complete {
+		component.numLayouts = nil
+		component.tileMineCounts = nil
+	}
+}
+
+/*
+possibleMineCounts returns, for each possible number of mines in the
+component, true.
+Incomplete components can hold any number of mines up to their number of tiles.
+*/
+func (component *component) possibleMineCounts() map[int]bool {
+	counts := map[int]bool{}
+	if !component.complete {
+		for numMines := 0; numMines <= len(component.tileIndexes); numMines++ {
+			counts[numMines] = true
+		}
+		return counts
+	}
+
+	for numMines := range component.numLayouts {
+		counts[numMines] = true
+	}
+
+	return counts
+}
+
+/*
+reachableMineCounts returns every total number of mines that can be obtained by
+picking one possible number of mines from each of the provided components.
+*/
+func reachableMineCounts(components []*component) map[int]bool {
+	totals := map[int]bool{0: true}
+
+	for _, component := range components {
+		nextTotals := map[int]bool{}
+		for total := range totals {
+			for numMines := range component.possibleMineCounts() {
+				nextTotals[total+numMines] = true
+			}
+		}
+		totals = nextTotals
+	}
+
+	return totals
+}
+
+/*
+feasibleMineCounts returns the numbers of mines the component at the provided
+position can hold, such that the remaining mines can be spread among the other
+components and the tiles outside the frontier.
+*/
+func feasibleMineCounts(components []*component, position int, minesLeft int, numInteriorTiles int) map[int]bool {
+	others := make([]*component, 0, len(components)-1)
+	others = append(others, components[:position]...)
+	others = append(others, components[position+1:]...)
+	otherTotals := reachableMineCounts(others)
+
+	feasible := map[int]bool{}
+	for numMines := range components[position].possibleMineCounts() {
+		for total := range otherTotals {
+			interiorMines := minesLeft - numMines - total
+			if interiorMines >= 0 && interiorMines <= numInteriorTiles {
+				feasible[numMines] = true
+				break
+			}
+		}
+	}
+
+	return feasible
+}
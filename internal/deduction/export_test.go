@@ -0,0 +1,12 @@
+package deduction
+
+// The budget of search nodes of a frontier component, for the tests
+const MaxEnumerationNodes = maxEnumerationNodes
+
+/*
+EnumerationNodes returns the most search nodes visited while enumerating a
+frontier component of the board, in its last enumeration.
+*/
+func EnumerationNodes(instance IBoard) int {
+	return instance.(*board).enumerationNodes
+}
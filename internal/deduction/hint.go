@@ -0,0 +1,63 @@
+package deduction
+
+/*
+Hint returns a hidden tile that is certainly safe or, if there are none, the
+hidden tile with the lowest probability of having a mine, with that probability.
+If a frontier component has too many mine layouts to enumerate, the mines left
+are taken to be spread evenly among the unknown tiles.
+The returned tile index is -1 if every hidden tile is certainly a mine.
+Returns an error if the board can not be satisfied by any mine layout.
+*/
+func (board *board) Hint() (int, float64, error) {
+	result, error := board.Solve()
+	if error != nil {
+		return -1, 0, error
+	}
+	if len(result.SafeTiles) > 0 {
+		return result.SafeTiles[0], 0, nil
+	}
+
+	tileProbabilities, error := board.Probabilities()
+	if _, ok := error.(frontierTooLargeError); ok {
+		tileProbabilities = board.densityProbabilities()
+	} else if error != nil {
+		return -1, 0, error
+	}
+
+	hintIndex := -1
+	for tileIndex, hidden := range board.hidden {
+		if !hidden || board.mines[tileIndex] {
+			continue
+		}
+		if hintIndex == -1 || tileProbabilities[tileIndex] < tileProbabilities[hintIndex] {
+			hintIndex = tileIndex
+		}
+	}
+	if hintIndex == -1 {
+		return -1, 0, nil
+	}
+
+	return hintIndex, tileProbabilities[hintIndex], nil
+}
+
+/*
+densityProbabilities gives every unknown tile the same probability of having a
+mine, the mines left divided by the number of unknown tiles.
+Known and deduced mines have a probability of 1, every other tile 0.
+*/
+func (board *board) densityProbabilities() []float64 {
+	unknownIndexes := board.unknownTileIndexes()
+	density := float64(board.minesLeft()) / float64(len(unknownIndexes))
+
+	tileProbabilities := make([]float64, len(board.revealed))
+	for tileIndex := range tileProbabilities {
+		if board.mines[tileIndex] {
+			tileProbabilities[tileIndex] = 1
+		}
+	}
+	for _, tileIndex := range unknownIndexes {
+		tileProbabilities[tileIndex] = density
+	}
+
+	return tileProbabilities
+}
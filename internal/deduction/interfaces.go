@@ -0,0 +1,43 @@
+package deduction
+
+type IBoard interface {
+	/*
+		Mask removes the cell with the provided index from the board.
+	*/
+	Mask(tileIndex int)
+	/*
+		Reveal records the number of a revealed tile without a mine.
+	*/
+	Reveal(tileIndex int, adjacentMines int)
+	/*
+		MarkMine records a tile known to have a mine, such as a revealed mine or a
+		flag that is trusted.
+	*/
+	MarkMine(tileIndex int)
+	/*
+		Solve deduces the state of as many tiles as possible and returns the tiles
+		that are not revealed or known to be mines and are certainly safe or
+		certainly mines.
+		The deductions are kept, so the board can be solved again after revealing
+		the safe tiles.
+		Returns an error if the board can not be satisfied by any mine layout.
+	*/
+	Solve() (result, error)
+	/*
+		Probabilities calculates, for each tile, the probability of it having a
+		mine.
+		Returns an error if the board can not be satisfied by any mine layout or a
+		frontier component has too many mine layouts to enumerate.
+	*/
+	Probabilities() ([]float64, error)
+	/*
+		Hint returns a hidden tile that is certainly safe or, if there are none,
+		the hidden tile with the lowest probability of having a mine, with that
+		probability.
+		If a frontier component has too many mine layouts to enumerate, the mines
+		left are taken to be spread evenly among the unknown tiles.
+		The returned tile index is -1 if every hidden tile is certainly a mine.
+		Returns an error if the board can not be satisfied by any mine layout.
+	*/
+	Hint() (int, float64, error)
+}
@@ -0,0 +1,170 @@
+package deduction
+
+import (
+	"fmt"
+	"math"
+)
+
+// Error: A frontier component has too many mine layouts to enumerate
+type frontierTooLargeError struct {
+	NumTiles int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e frontierTooLargeError) Error() string {
+	return fmt.Sprintf(
+		"A frontier component with '%v' tiles has too many mine layouts to enumerate",
+		e.NumTiles)
+}
+
+/*
+Probabilities calculates, for each tile, the probability of it having a mine
+given the visible numbers, the known mines and the total number of mines.
+Revealed tiles have a probability of 0, while known mines have a probability of
+1.
+Every mine layout of the frontier is enumerated, one independent component at a
+time, and weighted by the number of ways the remaining mines can be placed in
+the tiles outside the frontier.
+Returns an error if the board can not be satisfied by any mine layout or a
+frontier component has too many mine layouts to enumerate.
+*/
+func (board *board) Probabilities() ([]float64, error) {
+	for {
+		progress, error := applyLocalRules(board)
+		if error != nil {
+			return nil, error
+		}
+		if !progress {
+			break
+		}
+	}
+
+	constraints, error := board.constraints()
+	if error != nil {
+		return nil, error
+	}
+
+	minesLeft := board.minesLeft()
+	components := board.enumerateComponents(constraints)
+	for _, component := range components {
+		if !component.complete {
+			return nil, frontierTooLargeError{
+				NumTiles: len(component.tileIndexes),
+			}
+		}
+	}
+	interiorIndexes := interiorTileIndexes(board, components)
+
+	tileProbabilities := make([]float64, len(board.revealed))
+	for tileIndex := range tileProbabilities {
+		if board.mines[tileIndex] {
+			tileProbabilities[tileIndex] = 1
+		}
+	}
+
+	interiorWeights := interiorLayoutWeights(len(interiorIndexes), minesLeft)
+	totalWeight := 0.0
+	allDistribution := combineDistributions(components, -1)
+	for numMines, numLayouts := range allDistribution {
+		totalWeight += numLayouts * interiorWeights(minesLeft-numMines)
+	}
+	if totalWeight == 0 {
+		return nil, inconsistentMineCountError{
+			MinesLeft: minesLeft,
+		}
+	}
+
+	for position, component := range components {
+		othersDistribution := combineDistributions(components, position)
+
+		for numMines, tileMineCounts := range component.tileMineCounts {
+			weight := 0.0
+			for otherMines, numLayouts := range othersDistribution {
+				weight += numLayouts * interiorWeights(minesLeft-numMines-otherMines)
+			}
+
+			for tilePosition, tileIndex := range component.tileIndexes {
+				tileProbabilities[tileIndex] += tileMineCounts[tilePosition] * weight / totalWeight
+			}
+		}
+	}
+
+	if len(interiorIndexes) > 0 {
+		interiorProbability := 0.0
+		for numMines, numLayouts := range allDistribution {
+			interiorMines := minesLeft - numMines
+			interiorProbability += numLayouts * interiorWeights(interiorMines) * float64(interiorMines) / float64(len(interiorIndexes))
+		}
+
+		for _, tileIndex := range interiorIndexes {
+			tileProbabilities[tileIndex] = interiorProbability / totalWeight
+		}
+	}
+
+	return tileProbabilities, nil
+}
+
+/*
+combineDistributions combines the number of layouts, by number of mines, of all
+the components except the one at the provided position.
+The result is indexed by the total number of mines.
+*/
+func combineDistributions(components []*component, excludedPosition int) []float64 {
+	distribution := []float64{1}
+
+	for position, component := range components {
+		if position == excludedPosition {
+			continue
+		}
+
+		maxMines := 0
+		for numMines := range component.numLayouts {
+			if numMines > maxMines {
+				maxMines = numMines
+			}
+		}
+
+		combined := make([]float64, len(distribution)+maxMines)
+		for total, totalLayouts := range distribution {
+			if totalLayouts == 0 {
+				continue
+			}
+			for numMines, numLayouts := range component.numLayouts {
+				combined[total+numMines] += totalLayouts * numLayouts
+			}
+		}
+		distribution = combined
+	}
+
+	return distribution
+}
+
+/*
+interiorLayoutWeights returns a function that calculates the number of ways a
+number of mines can be placed in the tiles outside the frontier.
+The values are scaled by a common factor, to stay within the float64 range.
+*/
+func interiorLayoutWeights(numInteriorTiles int, maxMines int) func(numMines int) float64 {
+	logBinomial := func(numMines int) float64 {
+		n := float64(numInteriorTiles)
+		k := float64(numMines)
+		lgN, _ := math.Lgamma(n + 1)
+		lgK, _ := math.Lgamma(k + 1)
+		lgNK, _ := math.Lgamma(n - k + 1)
+		return lgN - lgK - lgNK
+	}
+
+	maxLog := math.Inf(-1)
+	for numMines := 0; numMines <= maxMines && numMines <= numInteriorTiles; numMines++ {
+		maxLog = math.Max(maxLog, logBinomial(numMines))
+	}
+
+	return func(numMines int) float64 {
+		if numMines < 0 || numMines > numInteriorTiles {
+			return 0
+		}
+		return math.Exp(logBinomial(numMines) - maxLog)
+	}
+}
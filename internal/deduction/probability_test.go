@@ -0,0 +1,86 @@
+package deduction_test
+
+import (
+	"testing"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/deduction"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// A mid-game Expert board, reached by revealing the certainly safe tiles and
+// guessing when there are none, whose largest frontier component takes most of
+// the enumeration budget
+var expertFrontier = []string{
+	"???1????1001?22?112?2110011111",
+	"???3?2??221212?212??4?2111?11?",
+	"?????212?2?1123213???23?212221",
+	"?????3?212123?3?12?5433?212?21",
+	"???????20001??422222??3211?3?1",
+	"????2??2000123?11?1123?1122333",
+	"????2??201221111111112111?11??",
+	"???????211??10000002?311112232",
+	"????3?4?222210000014?4?1001?21",
+	"???????4?2011100012??3221012?2",
+	"?????????312?10001?5421?10012?",
+	"????????33?21211123??221222111",
+	"???????3?22322?22?334?102??321",
+	"????????222??22?212?32213?4??1",
+	"?????1??????423220223?11?22221",
+	"????????2???2?2?101?2111110000",
+}
+
+type probabilityTestSuite struct {
+	suite.Suite
+}
+
+func (suite *probabilityTestSuite) TestProbabilitiesEnumerateAWideMidGameExpertFrontierWithinTheBudget() {
+	sut := newBoard(99, expertFrontier...)
+
+	actual, err := sut.Probabilities()
+
+	require.Nil(suite.T(), err)
+	require.LessOrEqual(suite.T(), deduction.EnumerationNodes(sut), deduction.MaxEnumerationNodes)
+	require.Greater(suite.T(), deduction.EnumerationNodes(sut), deduction.MaxEnumerationNodes/2)
+
+	total := 0.0
+	for _, probability := range actual {
+		total += probability
+	}
+	require.InDelta(suite.T(), 99.0, total, 1e-6)
+
+	result, err := newBoard(99, expertFrontier...).Solve()
+	require.Nil(suite.T(), err)
+	for _, tileIndex := range result.SafeTiles {
+		require.InDeltaf(suite.T(), 0.0, actual[tileIndex], 1e-9, "tile index: %v", tileIndex)
+	}
+	for _, tileIndex := range result.MineTiles {
+		require.InDeltaf(suite.T(), 1.0, actual[tileIndex], 1e-9, "tile index: %v", tileIndex)
+	}
+}
+
+func (suite *probabilityTestSuite) TestProbabilitiesReturnAnErrorIfAFrontierComponentIsTooLarge() {
+	// Every other tile of the row is revealed, with a mine on one of its two
+	// adjacent hidden tiles, so the layouts double with each number
+	row := ""
+	for index := 0; index < 45; index++ {
+		row += "?1"
+	}
+	sut := newBoard(45, row+"?", row+"?")
+
+	_, err := sut.Probabilities()
+
+	require.EqualError(suite.T(), err, "A frontier component with '92' tiles has too many mine layouts to enumerate")
+	require.Greater(suite.T(), deduction.EnumerationNodes(sut), deduction.MaxEnumerationNodes)
+}
+
+func BenchmarkProbabilities(b *testing.B) {
+	for index := 0; index < b.N; index++ {
+		newBoard(99, expertFrontier...).Probabilities()
+	}
+}
+
+func TestProbabilitySuite(t *testing.T) {
+	suite.Run(t, new(probabilityTestSuite))
+}
@@ -0,0 +1,144 @@
+package deduction
+
+/*
+applySinglePointRules checks each constraint on its own.
+If a constraint needs no more mines all its tiles are safe and if it needs as
+many mines as it has tiles all its tiles are mines.
+Returns true if anything new was deduced.
+*/
+func applySinglePointRules(board *board, constraints []constraint) bool {
+	progress := false
+
+	for _, constraint := range constraints {
+		if constraint.numMines == 0 {
+			progress = markTiles(board.safe, constraint.tileIndexes) || progress
+		} else if constraint.numMines == len(constraint.tileIndexes) {
+			progress = markTiles(board.mines, constraint.tileIndexes) || progress
+		}
+	}
+
+	return progress
+}
+
+/*
+applyPairwiseRules checks each pair of overlapping constraints.
+The number of mines in the shared tiles is bounded by both constraints, which
+bounds the number of mines in the tiles only one of the constraints has.
+This covers the subset rule, where one constraint's tiles are all shared.
+Returns true if anything new was deduced.
+*/
+func applyPairwiseRules(board *board, constraints []constraint) bool {
+	progress := false
+	constraintsByTile := map[int][]int{}
+	for cIndex, constraint := range constraints {
+		for _, tileIndex := range constraint.tileIndexes {
+			constraintsByTile[tileIndex] = append(constraintsByTile[tileIndex], cIndex)
+		}
+	}
+
+	for aIndex, a := range constraints {
+		checked := map[int]bool{aIndex: true}
+
+		for _, tileIndex := range a.tileIndexes {
+			for _, bIndex := range constraintsByTile[tileIndex] {
+				if checked[bIndex] {
+					continue
+				}
+				checked[bIndex] = true
+				b := constraints[bIndex]
+
+				onlyA, shared := splitTileIndexes(a.tileIndexes, b.tileIndexes)
+				onlyB := len(b.tileIndexes) - len(shared)
+
+				maxShared := minInt(len(shared), minInt(a.numMines, b.numMines))
+				minShared := maxInt(0, maxInt(a.numMines-len(onlyA), b.numMines-onlyB))
+
+				if len(onlyA) == 0 {
+					continue
+				}
+				if a.numMines-minShared == 0 {
+					progress = markTiles(board.safe, onlyA) || progress
+				} else if a.numMines-maxShared == len(onlyA) {
+					progress = markTiles(board.mines, onlyA) || progress
+				}
+			}
+		}
+	}
+
+	return progress
+}
+
+/*
+applyMineCountRule uses the number of mines left to check if all the unknown
+tiles are either safe or mines.
+Returns true if anything new was deduced.
+*/
+func applyMineCountRule(board *board) bool {
+	unknownIndexes := board.unknownTileIndexes()
+	minesLeft := board.minesLeft()
+
+	if len(unknownIndexes) == 0 {
+		return false
+	}
+	if minesLeft == 0 {
+		return markTiles(board.safe, unknownIndexes)
+	}
+	if minesLeft == len(unknownIndexes) {
+		return markTiles(board.mines, unknownIndexes)
+	}
+
+	return false
+}
+
+/*
+markTiles sets the provided tile indexes to true.
+Returns true if any of them was false.
+*/
+func markTiles(target []bool, tileIndexes []int) bool {
+	changed := false
+	for _, tileIndex := range tileIndexes {
+		if !target[tileIndex] {
+			target[tileIndex] = true
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+/*
+splitTileIndexes splits the tile indexes in source into the ones that do not
+exist in other and the ones that do.
+*/
+func splitTileIndexes(source []int, other []int) ([]int, []int) {
+	inOther := make(map[int]bool, len(other))
+	for _, tileIndex := range other {
+		inOther[tileIndex] = true
+	}
+
+	onlySource := []int{}
+	shared := []int{}
+	for _, tileIndex := range source {
+		if inOther[tileIndex] {
+			shared = append(shared, tileIndex)
+		} else {
+			onlySource = append(onlySource, tileIndex)
+		}
+	}
+
+	return onlySource, shared
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
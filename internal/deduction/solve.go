@@ -0,0 +1,182 @@
+package deduction
+
+/*
+Solve deduces the state of as many tiles as possible and returns the tiles that
+are not revealed or known to be mines and are certainly safe or certainly mines.
+The simple rules are applied first, checking each number on its own and each
+pair of overlapping numbers, and only when they can not deduce anything else are
+all the mine layouts of the frontier enumerated.
+The deductions are kept, so the board can be solved again after revealing the
+safe tiles.
+Returns an error if the board can not be satisfied by any mine layout.
+*/
+func (board *board) Solve() (result, error) {
+	for {
+		progress, error := applyLocalRules(board)
+		if error != nil {
+			return result{}, error
+		}
+		if progress {
+			continue
+		}
+
+		constraints, error := board.constraints()
+		if error != nil {
+			return result{}, error
+		}
+
+		progress, error = applyEnumeration(board, constraints)
+		if error != nil {
+			return result{}, error
+		}
+		if !progress {
+			break
+		}
+	}
+
+	output := result{
+		SafeTiles: []int{},
+		MineTiles: []int{},
+	}
+	for tileIndex, hidden := range board.hidden {
+		if !hidden {
+			continue
+		}
+		if board.safe[tileIndex] {
+			output.SafeTiles = append(output.SafeTiles, tileIndex)
+		} else if board.mines[tileIndex] {
+			output.MineTiles = append(output.MineTiles, tileIndex)
+		}
+	}
+
+	return output, nil
+}
+
+/*
+applyLocalRules applies the rules that only look at each number, each pair of
+overlapping numbers and the mines left.
+Returns true if anything new was deduced.
+*/
+func applyLocalRules(board *board) (bool, error) {
+	constraints, error := board.constraints()
+	if error != nil {
+		return false, error
+	}
+
+	if applySinglePointRules(board, constraints) {
+		return true, nil
+	}
+	if applyPairwiseRules(board, constraints) {
+		return true, nil
+	}
+
+	return applyMineCountRule(board), nil
+}
+
+/*
+applyEnumeration enumerates the mine layouts of each frontier component and
+marks the tiles that have the same state in every layout that can be combined
+with the mines left.
+The tiles outside the frontier are marked if the mines left force them all to be
+safe or all to be mines.
+Returns true if anything new was deduced.
+*/
+func applyEnumeration(board *board, constraints []constraint) (bool, error) {
+	minesLeft := board.minesLeft()
+	components := board.enumerateComponents(constraints)
+	interiorIndexes := interiorTileIndexes(board, components)
+	progress := false
+
+	for position, component := range components {
+		if !component.complete {
+			continue
+		}
+
+		feasible := feasibleMineCounts(components, position, minesLeft, len(interiorIndexes))
+		if len(feasible) == 0 {
+			return false, inconsistentBoardError{
+				RowIndex: component.constraints[0].tileIndex / board.cols,
+				ColIndex: component.constraints[0].tileIndex % board.cols,
+			}
+		}
+
+		for tilePosition, tileIndex := range component.tileIndexes {
+			alwaysMine := true
+			neverMine := true
+			for numMines := range feasible {
+				count := component.tileMineCounts[numMines][tilePosition]
+				if count != component.numLayouts[numMines] {
+					alwaysMine = false
+				}
+				if count != 0 {
+					neverMine = false
+				}
+			}
+
+			if alwaysMine {
+				progress = markTiles(board.mines, []int{tileIndex}) || progress
+			} else if neverMine {
+				progress = markTiles(board.safe, []int{tileIndex}) || progress
+			}
+		}
+	}
+
+	if len(interiorIndexes) > 0 {
+		possible := false
+		alwaysSafe := true
+		alwaysMines := true
+		for total := range reachableMineCounts(components) {
+			interiorMines := minesLeft - total
+			if interiorMines < 0 || interiorMines > len(interiorIndexes) {
+				continue
+			}
+			possible = true
+			if interiorMines != 0 {
+				alwaysSafe = false
+			}
+			if interiorMines != len(interiorIndexes) {
+				alwaysMines = false
+			}
+		}
+
+		if !possible {
+			return false, inconsistentMineCountError{
+				MinesLeft: minesLeft,
+			}
+		} else if alwaysSafe {
+			progress = markTiles(board.safe, interiorIndexes) || progress
+		} else if alwaysMines {
+			progress = markTiles(board.mines, interiorIndexes) || progress
+		}
+	}
+
+	return progress, nil
+}
+
+/*
+interiorTileIndexes returns the unknown tiles that are not in any frontier
+component.
+*/
+func interiorTileIndexes(board *board, components []*component) []int {
+	inFrontier := map[int]bool{}
+	for _, component := range components {
+		for _, tileIndex := range component.tileIndexes {
+			inFrontier[tileIndex] = true
+		}
+	}
+
+	tileIndexes := []int{}
+	for _, tileIndex := range board.unknownTileIndexes() {
+		if !inFrontier[tileIndex] {
+			tileIndexes = append(tileIndexes, tileIndex)
+		}
+	}
+
+	return tileIndexes
+}
+
+// Result contains the tile indexes that are certainly safe or mines
+type result struct {
+	SafeTiles []int
+	MineTiles []int
+}
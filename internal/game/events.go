@@ -0,0 +1,135 @@
+package game
+
+import (
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+)
+
+// The number of events that can be waiting to be received by a subscriber
+const subscriberBufferSize int = 256
+
+// Event describes a change to a game, published to the game's subscribers
+type event struct {
+	// One of the configs.Event* constants
+	Kind int
+	// The indexes of the tiles that changed, empty for events about the whole
+	// game
+	TileIndexes []int
+	Timestamp   time.Time
+}
+
+// Subscribers holds the channels of the subscribers to a game's events.
+// It is not safe for concurrent use, the game's mutex must be held
+type subscribers map[chan event]bool
+
+/*
+Subscribe returns a channel that receives every event of the game, in the order
+they happened, and the function that cancels the subscription and closes the
+channel.
+A subscriber that has too many events waiting is removed, and its channel
+closed.
+*/
+func (game *game) Subscribe() (<-chan event, func()) {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+
+	events := game.subscribers.add()
+
+	return events, func() {
+		game.mutex.Lock()
+		defer game.mutex.Unlock()
+
+		game.subscribers.remove(events)
+	}
+}
+
+/*
+publishEvent sends an event to every subscriber.
+*/
+func (game *game) publishEvent(kind int, tileIndexes []int, timestamp time.Time) {
+	game.subscribers.publish(kind, tileIndexes, timestamp)
+}
+
+/*
+add creates the channel of a new subscriber.
+*/
+func (subscribers subscribers) add() chan event {
+	events := make(chan event, subscriberBufferSize)
+	subscribers[events] = true
+
+	return events
+}
+
+/*
+remove removes a subscriber from the game's events, closing its channel.
+*/
+func (subscribers subscribers) remove(events chan event) {
+	if !subscribers[events] {
+		return
+	}
+
+	delete(subscribers, events)
+	close(events)
+}
+
+/*
+publish sends an event to every subscriber.
+*/
+func (subscribers subscribers) publish(kind int, tileIndexes []int, timestamp time.Time) {
+	newEvent := event{
+		Kind:        kind,
+		TileIndexes: tileIndexes,
+		Timestamp:   timestamp,
+	}
+
+	for events := range subscribers {
+		select {
+		case events <- newEvent:
+		default:
+			subscribers.remove(events)
+		}
+	}
+}
+
+/*
+publishRevealEvents sends the events for the tiles revealed by an action, and for
+the lives lost on the revealed mines.
+*/
+func (game *game) publishRevealEvents(tileIndexes []int, timestamp time.Time) {
+	if len(tileIndexes) == 0 {
+		return
+	}
+	game.publishEvent(configs.EventTileRevealed, tileIndexes, timestamp)
+
+	mineTileIndexes := []int{}
+	for _, tileIndex := range tileIndexes {
+		tile, _ := game.minefield.Tile(tileIndex/game.numCols, tileIndex%game.numCols)
+		if tile.HasMine() {
+			mineTileIndexes = append(mineTileIndexes, tileIndex)
+		}
+	}
+	if len(mineTileIndexes) > 0 {
+		game.publishEvent(configs.EventLifeLost, mineTileIndexes, timestamp)
+	}
+}
+
+/*
+publishStateEvent sends the event for the change of the game's state by an
+action, if it changed.
+*/
+func (game *game) publishStateEvent(previousState int, timestamp time.Time) {
+	state := game.state()
+	if state == previousState {
+		return
+	}
+
+	switch state {
+	case configs.StateWin:
+		game.publishEvent(configs.EventGameWon, []int{}, timestamp)
+	case configs.StateLoss:
+		game.publishEvent(configs.EventGameLost, []int{}, timestamp)
+	default:
+		game.publishEvent(configs.EventGameResumed, []int{}, timestamp)
+	}
+}
@@ -0,0 +1,165 @@
+package game_test
+
+import (
+	"testing"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type eventsTestSuite struct {
+	suite.Suite
+	sutArgs game.GameConfig
+}
+
+func (suite *eventsTestSuite) SetupTest() {
+	// The only mine is on the first tile
+	suite.sutArgs = game.GameConfig{
+		NumRows:      3,
+		NumCols:      3,
+		NumMines:     1,
+		FlagsEnabled: true,
+		Lives:        1,
+		MineTiles:    []int{0},
+	}
+}
+
+func (suite *eventsTestSuite) TestTheFirstRevealPublishesTheTimerStartAndTheRevealedTiles() {
+	sut, _ := game.Generate(suite.sutArgs)
+	events, _ := sut.Subscribe()
+
+	tileIndexes, _ := sut.RevealTile(0, 1)
+
+	require.Equal(suite.T(), 2, len(events))
+	event := <-events
+	require.Equal(suite.T(), configs.EventTimerStarted, event.Kind)
+	require.Equal(suite.T(), sut.StartTime(), event.Timestamp)
+	event = <-events
+	require.Equal(suite.T(), configs.EventTileRevealed, event.Kind)
+	require.Equal(suite.T(), tileIndexes, event.TileIndexes)
+}
+
+func (suite *eventsTestSuite) TestToggleFlagPublishesTheFlaggedTile() {
+	sut, _ := game.Generate(suite.sutArgs)
+	events, _ := sut.Subscribe()
+
+	sut.ToggleFlag(0, 0)
+
+	require.Equal(suite.T(), 1, len(events))
+	event := <-events
+	require.Equal(suite.T(), configs.EventFlagToggled, event.Kind)
+	require.Equal(suite.T(), []int{0}, event.TileIndexes)
+}
+
+func (suite *eventsTestSuite) TestRevealingAMinePublishesTheLifeLost() {
+	suite.sutArgs.Lives = 2
+	sut, _ := game.Generate(suite.sutArgs)
+	sut.RevealTile(0, 1)
+	events, _ := sut.Subscribe()
+
+	sut.RevealTile(0, 0)
+
+	require.Equal(suite.T(), 2, len(events))
+	require.Equal(suite.T(), configs.EventTileRevealed, (<-events).Kind)
+	event := <-events
+	require.Equal(suite.T(), configs.EventLifeLost, event.Kind)
+	require.Equal(suite.T(), []int{0}, event.TileIndexes)
+}
+
+func (suite *eventsTestSuite) TestRevealingTheLastLifeMinePublishesTheLoss() {
+	sut, _ := game.Generate(suite.sutArgs)
+	sut.RevealTile(0, 1)
+	events, _ := sut.Subscribe()
+
+	sut.RevealTile(0, 0)
+
+	require.Equal(suite.T(), 3, len(events))
+	<-events
+	<-events
+	require.Equal(suite.T(), configs.EventGameLost, (<-events).Kind)
+}
+
+func (suite *eventsTestSuite) TestRevealingEverySafeTilePublishesTheWin() {
+	sut, _ := game.Generate(suite.sutArgs)
+	events, _ := sut.Subscribe()
+
+	sut.RevealTile(2, 2)
+
+	require.Equal(suite.T(), 3, len(events))
+	<-events
+	<-events
+	require.Equal(suite.T(), configs.EventGameWon, (<-events).Kind)
+}
+
+func (suite *eventsTestSuite) TestUndoOfTheLosingRevealPublishesTheHiddenTilesAndTheResume() {
+	sut, _ := game.Generate(suite.sutArgs)
+	sut.RevealTile(0, 1)
+	sut.RevealTile(0, 0)
+	events, _ := sut.Subscribe()
+
+	sut.Undo()
+
+	require.Equal(suite.T(), 2, len(events))
+	event := <-events
+	require.Equal(suite.T(), configs.EventTileHidden, event.Kind)
+	require.Equal(suite.T(), []int{0}, event.TileIndexes)
+	require.Equal(suite.T(), configs.EventGameResumed, (<-events).Kind)
+}
+
+func (suite *eventsTestSuite) TestRedoOfTheLosingRevealPublishesTheLoss() {
+	sut, _ := game.Generate(suite.sutArgs)
+	sut.RevealTile(0, 1)
+	sut.RevealTile(0, 0)
+	sut.Undo()
+	events, _ := sut.Subscribe()
+
+	sut.Redo()
+
+	require.Equal(suite.T(), 3, len(events))
+	require.Equal(suite.T(), configs.EventTileRevealed, (<-events).Kind)
+	require.Equal(suite.T(), configs.EventLifeLost, (<-events).Kind)
+	require.Equal(suite.T(), configs.EventGameLost, (<-events).Kind)
+}
+
+func (suite *eventsTestSuite) TestEverySubscriberReceivesTheEvents() {
+	sut, _ := game.Generate(suite.sutArgs)
+	firstEvents, _ := sut.Subscribe()
+	secondEvents, _ := sut.Subscribe()
+
+	sut.ToggleFlag(0, 0)
+
+	require.Equal(suite.T(), configs.EventFlagToggled, (<-firstEvents).Kind)
+	require.Equal(suite.T(), configs.EventFlagToggled, (<-secondEvents).Kind)
+}
+
+func (suite *eventsTestSuite) TestCancellingTheSubscriptionClosesTheEvents() {
+	sut, _ := game.Generate(suite.sutArgs)
+	events, cancel := sut.Subscribe()
+
+	cancel()
+	sut.ToggleFlag(0, 0)
+
+	_, ok := <-events
+	require.Equal(suite.T(), false, ok)
+}
+
+func (suite *eventsTestSuite) TestASubscriberWithTooManyEventsWaitingIsRemoved() {
+	sut, _ := game.Generate(suite.sutArgs)
+	events, _ := sut.Subscribe()
+
+	for index := 0; index <= cap(events); index++ {
+		sut.ToggleFlag(0, 0)
+	}
+
+	received := 0
+	for range events {
+		received++
+	}
+	require.Equal(suite.T(), cap(events), received)
+}
+
+func TestEventsSuite(t *testing.T) {
+	suite.Run(t, new(eventsTestSuite))
+}
@@ -1,39 +1,84 @@
 package game
 
 import (
+	"sync"
 	"time"
 
 	"github.com/pedrohenriques/go-minesweeper/internal/configs"
 	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
+	"github.com/pedrohenriques/go-minesweeper/internal/solver"
 )
 
-// Game contains all the information about a game
+// Game contains all the information about a game.
+// Every exported method holds the mutex, so a game can be used by several
+// goroutines, and the unexported methods expect it to be held.
 type game struct {
+	mutex        sync.RWMutex
+	gameConfig   GameConfig
 	startTs      time.Time
 	endTs        time.Time
 	numMines     int
+	numLayers    int
 	numRows      int
 	numCols      int
 	flagsEnabled bool
 	lives        int
 	minefield    minefield.IMinefield
+	hintsUsed    int
+	// The actions undone by the player
+	undosUsed int
+	// The reveals, flags and reveals of adjacent tiles that changed tiles, and
+	// the ones that did not
+	effectiveClicks int
+	wastedClicks    int
+	// The actions that can be undone, oldest first
+	history []action
+	// The actions that were undone and can be redone, most recently undone last
+	undone []action
+	// Every action processed, to replay the game
+	replayActions []replayAction
+	// Returns the current time, replaced when replaying a game.
+	// Only the wall clock is kept, like in the saves and the replays, so the
+	// stats of a replay are the same as the recorded game's
+	now func() time.Time
+	// The channels of the subscribers to the game's events
+	subscribers subscribers
 }
 
 /*
 Config returns the configuration data for a game.
 */
 func (game *game) Config() *config {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+
 	return &config{
-		NumMines: game.numMines,
-		NumRows:  game.numRows,
-		NumCols:  game.numCols,
+		NumMines:  game.numMines,
+		NumRows:   game.numRows,
+		NumCols:   game.numCols,
+		NumLayers: game.numLayers,
 	}
 }
 
+/*
+GameConfig returns the configuration the game was generated with, including the
+seed that was used.
+*/
+func (game *game) GameConfig() GameConfig {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+
+	return game.gameConfig
+}
+
 /*
 StartTime returns the Time object of when the game started.
+The game starts on the first tile reveal, before that the zero Time is returned.
 */
 func (game *game) StartTime() time.Time {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+
 	return game.startTs
 }
 
@@ -41,6 +86,16 @@ func (game *game) StartTime() time.Time {
 State returns information about the game's state.
 */
 func (game *game) State() int {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+
+	return game.state()
+}
+
+/*
+state returns one of the configs.State* constants for the game.
+*/
+func (game *game) state() int {
 	stats := game.minefield.Stats()
 
 	if stats.NumMinesRevealed >= game.lives {
@@ -48,7 +103,7 @@ func (game *game) State() int {
 	}
 
 	numNonMineTilesRevealed := stats.NumTilesRevealed - stats.NumMinesRevealed
-	if numNonMineTilesRevealed == game.numRows*game.numCols-game.numMines {
+	if numNonMineTilesRevealed == game.minefield.NumTiles()-game.numMines {
 		return configs.StateWin
 	}
 
@@ -60,6 +115,9 @@ Tile searches for the tile in the requested row and column.
 The row and column coordinates are zero-indexed.
 */
 func (game *game) Tile(rowIndex int, colIndex int) (minefield.ITile, error) {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+
 	return game.minefield.Tile(rowIndex, colIndex)
 }
 
@@ -68,14 +126,32 @@ RevealTile reveals the requested tile.
 If the tile is empty the patch it belongs to will be revealed.
 */
 func (game *game) RevealTile(rowIndex int, colIndex int) ([]int, error) {
-	if game.State() != configs.StateOnGoing {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+
+	if game.state() != configs.StateOnGoing {
 		return nil, nil
 	}
 
+	now := game.now()
+	endTs := game.endTs
 	tileIndexes, error := game.minefield.RevealTile(rowIndex, colIndex)
 
-	if game.State() != configs.StateOnGoing {
-		game.endTs = time.Now()
+	if error == nil && game.startTs.IsZero() {
+		game.startTs = now
+		game.publishEvent(configs.EventTimerStarted, []int{}, now)
+	}
+
+	if game.state() != configs.StateOnGoing {
+		game.endTs = now
+	}
+
+	if error == nil {
+		game.countClick(len(tileIndexes) > 0)
+		game.recordAction(configs.ActionReveal, rowIndex, colIndex, tileIndexes, endTs)
+		game.logReplayAction(configs.ActionReveal, rowIndex, colIndex, now)
+		game.publishRevealEvents(tileIndexes, now)
+		game.publishStateEvent(configs.StateOnGoing, now)
 	}
 
 	return tileIndexes, error
@@ -85,11 +161,29 @@ func (game *game) RevealTile(rowIndex int, colIndex int) ([]int, error) {
 ToggleFlag flips the flag state for the requested tile.
 */
 func (game *game) ToggleFlag(rowIndex int, colIndex int) error {
-	if !game.flagsEnabled || game.State() != configs.StateOnGoing {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+
+	if !game.flagsEnabled || game.state() != configs.StateOnGoing {
 		return nil
 	}
 
-	return game.minefield.ToggleFlag(rowIndex, colIndex)
+	error := game.minefield.ToggleFlag(rowIndex, colIndex)
+	if error != nil {
+		return error
+	}
+
+	tile, _ := game.minefield.Tile(rowIndex, colIndex)
+	game.countClick(!tile.Revealed())
+	if !tile.Revealed() {
+		now := game.now()
+		tileIndexes := []int{rowIndex*game.numCols + colIndex}
+		game.recordAction(configs.ActionFlag, rowIndex, colIndex, tileIndexes, game.endTs)
+		game.logReplayAction(configs.ActionFlag, rowIndex, colIndex, now)
+		game.publishEvent(configs.EventFlagToggled, tileIndexes, now)
+	}
+
+	return nil
 }
 
 /*
@@ -99,38 +193,131 @@ If the number of adjacent flags is >= the number on the tile it will reveal
 all adjacent tiles without a flag.
 */
 func (game *game) ProcessAdjacentTiles(rowIndex int, colIndex int) ([]int, error) {
-	if game.State() != configs.StateOnGoing {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+
+	if game.state() != configs.StateOnGoing {
 		return nil, nil
 	}
 
+	now := game.now()
+	endTs := game.endTs
 	tileIndexes, error := game.minefield.ProcessAdjacentTiles(rowIndex, colIndex)
 
-	if game.State() != configs.StateOnGoing {
-		game.endTs = time.Now()
+	if game.state() != configs.StateOnGoing {
+		game.endTs = now
+	}
+
+	if error == nil {
+		game.countClick(len(tileIndexes) > 0)
+		game.recordAction(configs.ActionProcessAdjacent, rowIndex, colIndex, tileIndexes, endTs)
+		game.logReplayAction(configs.ActionProcessAdjacent, rowIndex, colIndex, now)
+		game.publishRevealEvents(tileIndexes, now)
+		game.publishStateEvent(configs.StateOnGoing, now)
 	}
 
 	return tileIndexes, error
 }
 
+/*
+Hint analyses the visible state of the game and returns a tile that is
+certainly safe or, if there are none, the tile with the lowest probability of
+having a mine.
+The flags are ignored, so a wrong flag does not mislead the hint.
+Each hint is counted in the game's stats.
+If the game is not on going no hint is given and the returned tile index is -1.
+*/
+func (game *game) Hint() (hint, error) {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+
+	if game.state() != configs.StateOnGoing {
+		return hint{TileIndex: -1}, nil
+	}
+
+	tileIndex, probability, error := solver.Hint(game.minefield)
+	if error != nil {
+		return hint{TileIndex: -1}, error
+	}
+
+	output := hint{
+		TileIndex:       tileIndex,
+		MineProbability: probability,
+	}
+
+	if output.TileIndex != -1 {
+		game.hintsUsed++
+		game.logReplayAction(configs.ActionHint, -1, -1, game.now())
+	}
+
+	return output, nil
+}
+
 /*
 Stats returns information about the current game.
 */
 func (game *game) Stats() stats {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+
+	return game.stats()
+}
+
+/*
+stats returns information about the current game.
+*/
+func (game *game) stats() stats {
 	minefieldStats := game.minefield.Stats()
 
-	return stats{
-		StartTime:      game.startTs,
-		EndTime:        game.endTs,
-		RemainingMines: game.numMines - minefieldStats.NumFlags,
-		RemainingLives: game.lives - minefieldStats.NumMinesRevealed,
+	output := stats{
+		StartTime:       game.startTs,
+		EndTime:         game.endTs,
+		RemainingMines:  game.numMines - minefieldStats.NumFlags,
+		RemainingLives:  game.lives - minefieldStats.NumMinesRevealed,
+		HintsUsed:       game.hintsUsed,
+		HintAssisted:    game.hintsUsed > 0,
+		UndosUsed:       game.undosUsed,
+		UndoAssisted:    game.undosUsed > 0,
+		EffectiveClicks: game.effectiveClicks,
+		WastedClicks:    game.wastedClicks,
+	}
+
+	// The 3BV would tell the player how much of the board is left to clear
+	if game.state() != configs.StateOnGoing {
+		output.ThreeBV = game.minefield.ThreeBV()
+		seconds := game.endTs.Sub(game.startTs).Seconds()
+		if seconds > 0 {
+			output.ThreeBVPerSecond = float64(output.ThreeBV) / seconds
+		}
+		if game.effectiveClicks > 0 {
+			output.IOE = float64(output.ThreeBV) / float64(game.effectiveClicks+game.wastedClicks)
+			output.Throughput = float64(output.ThreeBV) / float64(game.effectiveClicks)
+		}
+	}
+
+	return output
+}
+
+/*
+countClick counts a click of the player as effective, if it changed any tile,
+or as wasted otherwise.
+*/
+func (game *game) countClick(effective bool) {
+	if effective {
+		game.effectiveClicks++
+	} else {
+		game.wastedClicks++
 	}
 }
 
 // Config contains the setup of a game
 type config struct {
 	NumMines int
-	NumRows  int
-	NumCols  int
+	// The rows of every layer, one layer after the other
+	NumRows int
+	NumCols int
+	// The number of layers the rows are split into, 1 for a flat board
+	NumLayers int
 }
 
 // Stats contains statistics about a game
@@ -139,4 +326,30 @@ type stats struct {
 	EndTime        time.Time
 	RemainingMines int
 	RemainingLives int
+	HintsUsed      int
+	// True if any hint was used, in which case the game should not count for records
+	HintAssisted bool
+	UndosUsed    int
+	// True if any action was undone, in which case the game should not count for
+	// records
+	UndoAssisted bool
+	// The minimum number of clicks needed to clear the board, only set once the
+	// game has ended
+	ThreeBV int
+	// The reveals, flags and reveals of adjacent tiles that changed tiles, and
+	// the ones that did not
+	EffectiveClicks int
+	WastedClicks    int
+	// The 3BV per second, the 3BV per click and the 3BV per effective click,
+	// only set once the game has ended
+	ThreeBVPerSecond float64
+	IOE              float64
+	Throughput       float64
+}
+
+// Hint contains the tile suggested to the player
+type hint struct {
+	TileIndex int
+	// The probability of the tile having a mine, 0 if the tile is certainly safe
+	MineProbability float64
 }
@@ -2,9 +2,11 @@ package game_test
 
 import (
 	"sort"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
 	"github.com/pedrohenriques/go-minesweeper/internal/game"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -179,7 +181,7 @@ func (suite *gameTestSuite) SetupTest() {
 		{adjacentMines: 0, hasMine: true},
 	}
 
-	suite.sut = game.Generate(*suite.sutArgs)
+	suite.sut, _ = game.Generate(*suite.sutArgs)
 }
 
 /*
@@ -261,14 +263,29 @@ func (suite *gameTestSuite) TestConfigReturnsTheExpectedObject() {
 	require.Equal(suite.T(), expected.NumRows, actual.NumRows)
 }
 
-func (suite *gameTestSuite) TestStartTimeReturnsAPlausibleTime() {
+func (suite *gameTestSuite) TestStartTimeReturnsTheZeroTimeBeforeTheFirstReveal() {
+	require.Equal(suite.T(), true, suite.sut.StartTime().IsZero())
+}
+
+func (suite *gameTestSuite) TestStartTimeReturnsAPlausibleTimeAfterTheFirstReveal() {
 	beforeTime := time.Now()
 
+	suite.sut.RevealTile(5, 5)
+
 	actual := suite.sut.StartTime().Unix()
 	require.GreaterOrEqual(suite.T(), actual, beforeTime.Unix())
 	require.LessOrEqual(suite.T(), actual, time.Now().Unix())
 }
 
+func (suite *gameTestSuite) TestStartTimeDoesNotChangeOnLaterReveals() {
+	suite.sut.RevealTile(5, 5)
+	expected := suite.sut.StartTime()
+
+	suite.sut.RevealTile(5, 6)
+
+	require.Equal(suite.T(), expected, suite.sut.StartTime())
+}
+
 func (suite *gameTestSuite) TestStateReturnsZero() {
 	require.Equal(suite.T(), 0, suite.sut.State())
 }
@@ -491,7 +508,7 @@ func (suite *gameTestSuite) TestToggleFlagDoesNotChangeTheRequestedTileFlagCondi
 		Lives:        2,
 		Seed:         "hello",
 	}
-	suite.sut = game.Generate(*suite.sutArgs)
+	suite.sut, _ = game.Generate(*suite.sutArgs)
 
 	suite.solveGame()
 	tile, _ := suite.sut.Tile(2, 0)
@@ -536,7 +553,7 @@ func (suite *gameTestSuite) TestProcessAdjacentTilesRevealsTheAdjacentTilesWitho
 		Lives:        1,
 		Seed:         "pedrohenriques",
 	}
-	suite.sut = game.Generate(*suite.sutArgs)
+	suite.sut, _ = game.Generate(*suite.sutArgs)
 
 	suite.sut.ToggleFlag(7, 8)
 	suite.sut.RevealTile(6, 8)
@@ -617,6 +634,57 @@ func (suite *gameTestSuite) TestProcessAdjacentTilesDoesNotChangeTheGameEndTsIfT
 	require.Equal(suite.T(), expected, suite.sut.Stats().EndTime)
 }
 
+func (suite *gameTestSuite) TestHintReturnsATileThatIsCertainlySafe() {
+	actual, err := suite.sut.Hint()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), 0.0, actual.MineProbability)
+	require.Equal(suite.T(), false, suite.expectedMinefield[actual.TileIndex].hasMine)
+	require.Equal(suite.T(), false, suite.expectedMinefield[actual.TileIndex].revealed)
+}
+
+func (suite *gameTestSuite) TestHintReturnsTheTileWithTheLowestMineProbabilityIfNoTileIsCertainlySafe() {
+	suite.sutArgs.SafeStart = configs.SafeStartTile
+	suite.sut, _ = game.Generate(*suite.sutArgs)
+
+	actual, err := suite.sut.Hint()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), 0, actual.TileIndex)
+	require.InDelta(suite.T(), 20.0/110, actual.MineProbability, 1e-9)
+}
+
+func (suite *gameTestSuite) TestHintIgnoresTheFlags() {
+	// The tile is safe, but a flag on it contradicts the 2 on the tile (1, 4)
+	suite.sut.ToggleFlag(2, 5)
+
+	actual, err := suite.sut.Hint()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), 0.0, actual.MineProbability)
+	require.Equal(suite.T(), false, suite.expectedMinefield[actual.TileIndex].hasMine)
+	require.Equal(suite.T(), false, suite.expectedMinefield[actual.TileIndex].revealed)
+}
+
+func (suite *gameTestSuite) TestHintCountsTheHintsUsedInTheStats() {
+	suite.sut.Hint()
+	suite.sut.Hint()
+
+	require.Equal(suite.T(), 2, suite.sut.Stats().HintsUsed)
+	require.Equal(suite.T(), true, suite.sut.Stats().HintAssisted)
+}
+
+func (suite *gameTestSuite) TestHintDoesNotGiveAHintIfTheGameIsInAnEndState() {
+	suite.solveGame()
+
+	actual, err := suite.sut.Hint()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), -1, actual.TileIndex)
+	require.Equal(suite.T(), 0, suite.sut.Stats().HintsUsed)
+	require.Equal(suite.T(), false, suite.sut.Stats().HintAssisted)
+}
+
 func (suite *gameTestSuite) TestStatsReturnsTheExpectedObject() {
 	type stats struct {
 		StartTime      time.Time
@@ -684,6 +752,150 @@ func (suite *gameTestSuite) TestStatsReturnsTheExpectedObjectIfMinesHaveBeenReve
 	require.Equal(suite.T(), expected.RemainingLives, actual.RemainingLives)
 }
 
+func (suite *gameTestSuite) TestTileReturnsACopyThatIsNotUpdatedByLaterActions() {
+	tile, _ := suite.sut.Tile(9, 10)
+
+	suite.sut.ToggleFlag(9, 10)
+
+	require.Equal(suite.T(), false, tile.HasFlag())
+	tile, _ = suite.sut.Tile(9, 10)
+	require.Equal(suite.T(), true, tile.HasFlag())
+}
+
+func (suite *gameTestSuite) TestConcurrentReadsAndWritesDoNotCorruptTheGame() {
+	waitGroup := sync.WaitGroup{}
+	for rIndex := 0; rIndex < suite.sutArgs.NumRows; rIndex++ {
+		waitGroup.Add(2)
+		go func(rIndex int) {
+			defer waitGroup.Done()
+			for cIndex := 0; cIndex < suite.sutArgs.NumCols; cIndex++ {
+				suite.sut.ToggleFlag(rIndex, cIndex)
+				suite.sut.ToggleFlag(rIndex, cIndex)
+			}
+		}(rIndex)
+		go func(rIndex int) {
+			defer waitGroup.Done()
+			for cIndex := 0; cIndex < suite.sutArgs.NumCols; cIndex++ {
+				suite.sut.Tile(rIndex, cIndex)
+				suite.sut.State()
+				suite.sut.Stats()
+			}
+		}(rIndex)
+	}
+	waitGroup.Wait()
+
+	require.Equal(suite.T(), configs.StateOnGoing, suite.sut.State())
+	require.Equal(suite.T(), 20, suite.sut.Stats().RemainingMines)
+	_, err := suite.sut.Save()
+	require.Nil(suite.T(), err)
+}
+
+func (suite *gameTestSuite) TestStatsCountTheEffectiveAndWastedClicks() {
+	// The only mine is on the center tile, so every tile has adjacent mines
+	sut, _ := game.Generate(game.GameConfig{
+		NumRows:      3,
+		NumCols:      3,
+		NumMines:     1,
+		FlagsEnabled: true,
+		Lives:        1,
+		MineTiles:    []int{4},
+	})
+
+	sut.RevealTile(0, 0)
+	sut.RevealTile(0, 0)
+	sut.ToggleFlag(1, 1)
+	sut.ToggleFlag(0, 0)
+	sut.ProcessAdjacentTiles(0, 0)
+
+	actual := sut.Stats()
+	require.Equal(suite.T(), 0, actual.ThreeBV)
+	require.Equal(suite.T(), 3, actual.EffectiveClicks)
+	require.Equal(suite.T(), 2, actual.WastedClicks)
+	require.Equal(suite.T(), 0.0, actual.IOE)
+}
+
+func (suite *gameTestSuite) TestStatsHaveTheThreeBVOnceTheGameIsLost() {
+	sut, _ := game.Generate(game.GameConfig{
+		NumRows:   3,
+		NumCols:   3,
+		NumMines:  1,
+		Lives:     1,
+		MineTiles: []int{4},
+	})
+
+	sut.RevealTile(0, 0)
+	sut.RevealTile(1, 1)
+
+	actual := sut.Stats()
+	require.Equal(suite.T(), configs.StateLoss, sut.State())
+	require.Equal(suite.T(), 8, actual.ThreeBV)
+	require.Equal(suite.T(), 4.0, actual.IOE)
+	require.Equal(suite.T(), 4.0, actual.Throughput)
+}
+
+func (suite *gameTestSuite) TestStatsHaveTheEfficiencyMetricsOnceTheGameIsWon() {
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	sut, _ := game.GenerateWithClock(game.GameConfig{
+		NumRows:   3,
+		NumCols:   3,
+		NumMines:  1,
+		Lives:     1,
+		MineTiles: []int{4},
+	}, func() time.Time {
+		return now
+	})
+
+	sut.RevealTile(0, 0)
+	sut.RevealTile(0, 0)
+	now = now.Add(4 * time.Second)
+	for _, tileIndex := range []int{1, 2, 3, 5, 6, 7, 8} {
+		sut.RevealTile(tileIndex/3, tileIndex%3)
+	}
+
+	actual := sut.Stats()
+	require.Equal(suite.T(), configs.StateWin, sut.State())
+	require.Equal(suite.T(), 8, actual.EffectiveClicks)
+	require.Equal(suite.T(), 1, actual.WastedClicks)
+	require.Equal(suite.T(), 8.0/9.0, actual.IOE)
+	require.Equal(suite.T(), 1.0, actual.Throughput)
+	require.Equal(suite.T(), 2.0, actual.ThreeBVPerSecond)
+}
+
+func (suite *gameTestSuite) TestStateIsWinOnceTheTilesOfAMaskedBoardWithoutAMineAreRevealed() {
+	sut, _ := game.Generate(game.GameConfig{
+		NumRows:     1,
+		NumCols:     4,
+		NumMines:    1,
+		Lives:       1,
+		MineTiles:   []int{0},
+		MaskedTiles: []int{1},
+	})
+
+	sut.RevealTile(0, 2)
+
+	require.Equal(suite.T(), configs.StateWin, sut.State())
+	_, err := sut.Tile(0, 1)
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *gameTestSuite) TestStateIsWinOnceTheTilesOfEveryLayerWithoutAMineAreRevealed() {
+	sut, _ := game.Generate(game.GameConfig{
+		NumLayers: 2,
+		NumRows:   1,
+		NumCols:   2,
+		NumMines:  1,
+		Lives:     1,
+		MineTiles: []int{0},
+	})
+
+	sut.RevealTile(0, 1)
+	require.Equal(suite.T(), configs.StateOnGoing, sut.State())
+	sut.RevealTile(1, 0)
+	sut.RevealTile(1, 1)
+
+	require.Equal(suite.T(), configs.StateWin, sut.State())
+}
+
 func TestGameFunctionalitySuite(t *testing.T) {
 	suite.Run(t, new(gameTestSuite))
 }
@@ -16,25 +16,90 @@ type GameConfig struct {
 	FlagsEnabled bool
 	Lives        int
 	Seed         string
+	// One of the configs.SafeStart* constants
+	SafeStart int
+	// One of the configs.Topology* constants
+	Topology int
+	// If true, only boards that can be cleared without guessing are generated
+	NoGuess bool
+	// If not nil, the indexes of the tiles that have a mine, instead of a board
+	// generated from the seed
+	MineTiles []int
+	// If not nil, the indexes of the cells of the grid that are not tiles of the
+	// board, as row-major indexes
+	MaskedTiles []int
+	// If more than 1, the board has this many layers of NumRows x NumCols square
+	// tiles, stacked on top of each other, where each tile is adjacent to the up
+	// to 26 tiles around it. The game's rows are the rows of every layer, one
+	// layer after the other
+	NumLayers int
 }
 
 /*
 Generate creates a new game with the provided configuration and returns the
 a game instance
+If a seed is not provided one is created, so the game can be reproduced.
+Returns an error if the configuration is not valid.
 */
-func Generate(args GameConfig) IGame {
+func Generate(args GameConfig) (IGame, error) {
+	return GenerateWithClock(args, wallClock)
+}
+
+/*
+GenerateWithClock creates a new game like Generate, but the times of the game
+are read from the provided function instead of the system clock, so they can be
+chosen, such as in tests.
+*/
+func GenerateWithClock(args GameConfig, now func() time.Time) (IGame, error) {
+	error := Validate(args)
+	if error != nil {
+		return nil, error
+	}
+
+	if args.Seed == "" {
+		args.Seed = minefield.NewSeed()
+	}
+
+	minefieldArgs := minefield.MinefieldConfig{
+		NumCols:     args.NumCols,
+		NumRows:     args.NumRows,
+		NumMines:    args.NumMines,
+		Seed:        args.Seed,
+		SafeStart:   args.SafeStart,
+		Topology:    args.Topology,
+		NoGuess:     args.NoGuess,
+		MineTiles:   args.MineTiles,
+		MaskedTiles: args.MaskedTiles,
+		NumLayers:   numLayers(args),
+	}
+
+	var gameMinefield minefield.IMinefield
+	if minefieldArgs.NumLayers > 1 {
+		gameMinefield, error = minefield.GenerateLayered(minefieldArgs)
+	} else {
+		gameMinefield, error = minefield.Generate(minefieldArgs)
+	}
+	if error != nil {
+		return nil, error
+	}
+
 	return &game{
-		startTs:      time.Now(),
+		gameConfig:   args,
 		numMines:     args.NumMines,
-		numRows:      args.NumRows,
+		numLayers:    minefieldArgs.NumLayers,
+		numRows:      minefieldArgs.NumLayers * args.NumRows,
 		numCols:      args.NumCols,
 		flagsEnabled: args.FlagsEnabled,
 		lives:        args.Lives,
-		minefield: minefield.Generate(minefield.MinefieldConfig{
-			NumCols:  args.NumCols,
-			NumRows:  args.NumRows,
-			NumMines: args.NumMines,
-			Seed:     args.Seed,
-		}),
-	}
+		minefield:    gameMinefield,
+		now:          now,
+		subscribers:  subscribers{},
+	}, nil
+}
+
+/*
+wallClock returns the current time without its monotonic clock reading.
+*/
+func wallClock() time.Time {
+	return time.Now().Round(0)
 }
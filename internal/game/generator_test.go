@@ -1,8 +1,10 @@
 package game_test
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
 	"github.com/pedrohenriques/go-minesweeper/internal/game"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -17,27 +19,39 @@ func (suite *generatorTestSuite) TestItReturnsAGameWithTheCorrectNumberOfMines()
 		NumMines: 3,
 		NumRows:  10,
 		NumCols:  10,
+		Lives:    1,
 	}
 
-	require.Equal(suite.T(), 3, game.Generate(config).Config().NumMines)
+	sut, err := game.Generate(config)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), 3, sut.Config().NumMines)
 }
 
 func (suite *generatorTestSuite) TestItReturnsAGameWithTheCorrectNumberOfRows() {
 	config := game.GameConfig{
 		NumRows: 1,
 		NumCols: 4,
+		Lives:   1,
 	}
 
-	require.Equal(suite.T(), 1, game.Generate(config).Config().NumRows)
+	sut, err := game.Generate(config)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), 1, sut.Config().NumRows)
 }
 
 func (suite *generatorTestSuite) TestItReturnsAGameWithTheCorrectNumberOfCols() {
 	config := game.GameConfig{
 		NumCols: 7,
 		NumRows: 1,
+		Lives:   1,
 	}
 
-	require.Equal(suite.T(), 7, game.Generate(config).Config().NumCols)
+	sut, err := game.Generate(config)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), 7, sut.Config().NumCols)
 }
 
 func (suite *generatorTestSuite) TestItReturnsAGameWithAStateOfZero() {
@@ -48,7 +62,349 @@ func (suite *generatorTestSuite) TestItReturnsAGameWithAStateOfZero() {
 		Lives:    1,
 	}
 
-	require.Equal(suite.T(), 0, game.Generate(config).State())
+	sut, err := game.Generate(config)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), 0, sut.State())
+}
+
+func (suite *generatorTestSuite) TestItReturnsAGameWithASafeFirstRevealIfASafeStartIsRequested() {
+	config := game.GameConfig{
+		NumCols:   9,
+		NumRows:   9,
+		NumMines:  70,
+		Lives:     1,
+		Seed:      "hello",
+		SafeStart: configs.SafeStartArea,
+	}
+
+	sut, _ := game.Generate(config)
+	_, err := sut.RevealTile(4, 4)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), configs.StateOnGoing, sut.State())
+	for rIndex := 3; rIndex <= 5; rIndex++ {
+		for cIndex := 3; cIndex <= 5; cIndex++ {
+			tile, _ := sut.Tile(rIndex, cIndex)
+			require.Equalf(suite.T(), false, tile.HasMine(), "row index: %v | col index: %v", rIndex, cIndex)
+		}
+	}
+}
+
+func (suite *generatorTestSuite) TestItReturnsANoGuessGameIfRequested() {
+	config := game.GameConfig{
+		NumCols:   30,
+		NumRows:   16,
+		NumMines:  99,
+		Lives:     1,
+		Seed:      "hello",
+		SafeStart: configs.SafeStartArea,
+		NoGuess:   true,
+	}
+
+	sut, err := game.Generate(config)
+	require.Nil(suite.T(), err)
+
+	_, err = sut.RevealTile(8, 15)
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), configs.StateOnGoing, sut.State())
+}
+
+func (suite *generatorTestSuite) TestItCreatesASeedIfNoneIsProvided() {
+	config := game.GameConfig{
+		NumCols:  10,
+		NumRows:  10,
+		NumMines: 10,
+		Lives:    1,
+	}
+
+	sut, err := game.Generate(config)
+
+	require.Nil(suite.T(), err)
+	require.NotEqual(suite.T(), "", sut.GameConfig().Seed)
+}
+
+func (suite *generatorTestSuite) TestItKeepsTheProvidedConfig() {
+	config := game.GameConfig{
+		NumCols:      10,
+		NumRows:      10,
+		NumMines:     10,
+		FlagsEnabled: true,
+		Lives:        3,
+		Seed:         "hello",
+		SafeStart:    configs.SafeStartTile,
+	}
+
+	sut, err := game.Generate(config)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), config, sut.GameConfig())
+}
+
+func (suite *generatorTestSuite) TestItReturnsAnErrorIfTheBoardHasNoTiles() {
+	config := game.GameConfig{
+		NumCols:  0,
+		NumRows:  10,
+		NumMines: 1,
+	}
+
+	sut, err := game.Generate(config)
+
+	require.Nil(suite.T(), sut)
+	require.EqualError(suite.T(), err, "The board with '10' rows and '0' columns is not valid, it must have at least one tile and at most '10000' tiles")
+}
+
+func (suite *generatorTestSuite) TestItReturnsAnErrorIfTheBoardHasTooManyTiles() {
+	config := game.GameConfig{
+		NumCols:  101,
+		NumRows:  100,
+		NumMines: 1,
+	}
+
+	_, err := game.Generate(config)
+
+	require.EqualError(suite.T(), err, "The board with '100' rows and '101' columns is not valid, it must have at least one tile and at most '10000' tiles")
+}
+
+func (suite *generatorTestSuite) TestItReturnsAnErrorIfTheGameHasNoLives() {
+	for _, lives := range []int{0, -1} {
+		config := game.GameConfig{
+			NumCols:  3,
+			NumRows:  3,
+			NumMines: 1,
+			Lives:    lives,
+		}
+
+		sut, err := game.Generate(config)
+
+		require.Nil(suite.T(), sut)
+		require.EqualErrorf(suite.T(), err, fmt.Sprintf("The number of lives '%v' is not valid, it must be at least '1'", lives), "lives: %v", lives)
+	}
+}
+
+func (suite *generatorTestSuite) TestItReturnsAnErrorIfEveryTileHasAMine() {
+	config := game.GameConfig{
+		NumCols:   3,
+		NumRows:   3,
+		NumMines:  9,
+		SafeStart: configs.SafeStartTile,
+	}
+
+	sut, err := game.Generate(config)
+
+	require.Nil(suite.T(), sut)
+	require.EqualError(suite.T(), err, "The number of mines '9' is not valid, it must be between '0' and '8'")
+}
+
+func (suite *generatorTestSuite) TestItReturnsAnErrorIfTheNumberOfMinesIsNegative() {
+	config := game.GameConfig{
+		NumCols:  3,
+		NumRows:  3,
+		NumMines: -1,
+	}
+
+	_, err := game.Generate(config)
+
+	require.EqualError(suite.T(), err, "The number of mines '-1' is not valid, it must be between '0' and '5'")
+}
+
+func (suite *generatorTestSuite) TestItReturnsAnErrorIfTheSafeAreaDoesNotFitWithoutMines() {
+	config := game.GameConfig{
+		NumCols:   9,
+		NumRows:   9,
+		NumMines:  73,
+		SafeStart: configs.SafeStartArea,
+	}
+
+	_, err := game.Generate(config)
+
+	require.EqualError(suite.T(), err, "The number of mines '73' is not valid, it must be between '0' and '72'")
+}
+
+func (suite *generatorTestSuite) TestItAcceptsTheLargestNumberOfMinesThatLeavesTheSafeAreaFree() {
+	config := game.GameConfig{
+		NumCols:   9,
+		NumRows:   9,
+		NumMines:  72,
+		Lives:     1,
+		SafeStart: configs.SafeStartArea,
+	}
+
+	sut, err := game.Generate(config)
+	require.Nil(suite.T(), err)
+
+	_, err = sut.RevealTile(4, 4)
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), configs.StateWin, sut.State())
+}
+
+func (suite *generatorTestSuite) TestMaxMinesOnlyKeepsTheTilesOfTheBoardInTheSafeArea() {
+	config := game.GameConfig{
+		NumCols:   10,
+		NumRows:   2,
+		SafeStart: configs.SafeStartArea,
+	}
+
+	require.Equal(suite.T(), 14, game.MaxMines(config))
+}
+
+func (suite *generatorTestSuite) TestMaxMinesKeepsOneTileWithoutAMineWithASafeTile() {
+	config := game.GameConfig{
+		NumCols:   10,
+		NumRows:   10,
+		SafeStart: configs.SafeStartTile,
+	}
+
+	require.Equal(suite.T(), 99, game.MaxMines(config))
+}
+
+func (suite *generatorTestSuite) TestMaxMinesKeepsRoomForTheSmallestInitialPatchWithoutASafeStart() {
+	config := game.GameConfig{
+		NumCols:   10,
+		NumRows:   10,
+		SafeStart: configs.SafeStartNone,
+	}
+
+	require.Equal(suite.T(), 96, game.MaxMines(config))
+}
+
+func (suite *generatorTestSuite) TestItReturnsAnErrorIfTheTopologyIsNotKnown() {
+	config := game.GameConfig{
+		NumCols:  10,
+		NumRows:  10,
+		NumMines: 10,
+		Topology: 7,
+	}
+
+	_, err := game.Generate(config)
+
+	require.EqualError(suite.T(), err, "The topology '7' is not known")
+}
+
+func (suite *generatorTestSuite) TestItReturnsAGameWithHexagonalTilesIfRequested() {
+	config := game.GameConfig{
+		NumCols:   3,
+		NumRows:   3,
+		NumMines:  1,
+		Lives:     1,
+		Topology:  configs.TopologyHex,
+		MineTiles: []int{4},
+	}
+
+	sut, err := game.Generate(config)
+	require.Nil(suite.T(), err)
+
+	tile, _ := sut.Tile(0, 0)
+	require.Equal(suite.T(), 0, tile.AdjacentMines())
+}
+
+func (suite *generatorTestSuite) TestMaxMinesKeepsRoomForTheLargestSafeAreaOfTheTopology() {
+	config := game.GameConfig{
+		NumCols:   10,
+		NumRows:   10,
+		SafeStart: configs.SafeStartArea,
+		Topology:  configs.TopologyRadiusTwo,
+	}
+
+	require.Equal(suite.T(), 75, game.MaxMines(config))
+}
+
+func (suite *generatorTestSuite) TestItReturnsAnErrorIfAMaskedTileIsNotOnTheBoard() {
+	config := game.GameConfig{
+		NumCols:     3,
+		NumRows:     3,
+		NumMines:    1,
+		MaskedTiles: []int{9},
+	}
+
+	_, err := game.Generate(config)
+
+	require.EqualError(suite.T(), err, "The mask is not valid for the board with '3' rows and '3' columns, its cells must be on the board and leave at least one tile")
+}
+
+func (suite *generatorTestSuite) TestItReturnsAnErrorIfTheMaskRemovesEveryTile() {
+	config := game.GameConfig{
+		NumCols:     2,
+		NumRows:     1,
+		MaskedTiles: []int{0, 1},
+	}
+
+	_, err := game.Generate(config)
+
+	require.EqualError(suite.T(), err, "The mask is not valid for the board with '1' rows and '2' columns, its cells must be on the board and leave at least one tile")
+}
+
+func (suite *generatorTestSuite) TestMaxMinesDoesNotCountTheMaskedTiles() {
+	config := game.GameConfig{
+		NumCols:     3,
+		NumRows:     3,
+		SafeStart:   configs.SafeStartArea,
+		MaskedTiles: []int{0, 2, 6, 8},
+	}
+
+	require.Equal(suite.T(), 0, game.MaxMines(config))
+
+	config.SafeStart = configs.SafeStartTile
+	require.Equal(suite.T(), 4, game.MaxMines(config))
+}
+
+func (suite *generatorTestSuite) TestItReturnsAGameWithTheRowsOfEveryLayerIfLayersAreRequested() {
+	config := game.GameConfig{
+		NumLayers: 3,
+		NumCols:   3,
+		NumRows:   3,
+		NumMines:  1,
+		Lives:     1,
+		MineTiles: []int{13},
+	}
+
+	sut, err := game.Generate(config)
+	require.Nil(suite.T(), err)
+
+	require.Equal(suite.T(), 9, sut.Config().NumRows)
+	require.Equal(suite.T(), 3, sut.Config().NumLayers)
+	for _, position := range [][2]int{{0, 0}, {4, 2}, {8, 2}} {
+		tile, _ := sut.Tile(position[0], position[1])
+		require.Equalf(suite.T(), 1, tile.AdjacentMines(), "position: %v", position)
+	}
+}
+
+func (suite *generatorTestSuite) TestItReturnsAnErrorIfALayeredBoardDoesNotHaveSquareTiles() {
+	config := game.GameConfig{
+		NumLayers: 2,
+		NumCols:   3,
+		NumRows:   3,
+		NumMines:  1,
+		Topology:  configs.TopologyHex,
+	}
+
+	_, err := game.Generate(config)
+
+	require.EqualError(suite.T(), err, "The boards with more than one layer must have square tiles, not the topology '1'")
+}
+
+func (suite *generatorTestSuite) TestItReturnsAnErrorIfTheNumberOfLayersIsNegative() {
+	config := game.GameConfig{
+		NumLayers: -1,
+		NumCols:   3,
+		NumRows:   3,
+		NumMines:  1,
+	}
+
+	_, err := game.Generate(config)
+
+	require.EqualError(suite.T(), err, "The number of layers '-1' is not valid, it can not be negative")
+}
+
+func (suite *generatorTestSuite) TestMaxMinesCountsTheTilesAndTheSafeAreaOfEveryLayer() {
+	config := game.GameConfig{
+		NumLayers: 3,
+		NumCols:   4,
+		NumRows:   4,
+		SafeStart: configs.SafeStartArea,
+	}
+
+	require.Equal(suite.T(), 21, game.MaxMines(config))
 }
 
 func TestGeneratorSuite(t *testing.T) {
@@ -0,0 +1,168 @@
+package game
+
+import (
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+)
+
+// Action describes a change made to the minefield by the player
+type action struct {
+	// One of the configs.Action* constants
+	Kind     int
+	RowIndex int
+	ColIndex int
+	// The indexes of the tiles that were revealed or had their flag toggled
+	TileIndexes []int
+	// The game's end time before and after the action
+	endTsBefore time.Time
+	endTsAfter  time.Time
+}
+
+/*
+recordAction adds an action to the game's history, if it changed any tile, and
+discards the actions that were undone.
+*/
+func (game *game) recordAction(kind int, rowIndex int, colIndex int, tileIndexes []int, endTsBefore time.Time) {
+	if len(tileIndexes) == 0 {
+		return
+	}
+
+	game.history = append(game.history, action{
+		Kind:        kind,
+		RowIndex:    rowIndex,
+		ColIndex:    colIndex,
+		TileIndexes: tileIndexes,
+		endTsBefore: endTsBefore,
+		endTsAfter:  game.endTs,
+	})
+	game.undone = nil
+}
+
+/*
+Undo reverts the last action made by the player, including the game's end time.
+Each undo is counted in the game's stats.
+Returns the indexes of the tiles that changed, which is empty if there is
+nothing to undo.
+*/
+func (game *game) Undo() ([]int, error) {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+
+	if len(game.history) == 0 {
+		return []int{}, nil
+	}
+
+	previousState := game.state()
+	lastAction := game.history[len(game.history)-1]
+	error := game.applyAction(lastAction, false)
+	if error != nil {
+		return nil, error
+	}
+
+	now := game.now()
+	game.history = game.history[:len(game.history)-1]
+	game.undone = append(game.undone, lastAction)
+	game.endTs = lastAction.endTsBefore
+	game.undosUsed++
+	game.logReplayAction(configs.ActionUndo, -1, -1, now)
+
+	if lastAction.Kind == configs.ActionFlag {
+		game.publishEvent(configs.EventFlagToggled, lastAction.TileIndexes, now)
+	} else {
+		game.publishEvent(configs.EventTileHidden, lastAction.TileIndexes, now)
+	}
+	game.publishStateEvent(previousState, now)
+
+	return lastAction.TileIndexes, nil
+}
+
+/*
+Redo applies again the last action that was undone, including the game's end
+time.
+Returns the indexes of the tiles that changed, which is empty if there is
+nothing to redo.
+*/
+func (game *game) Redo() ([]int, error) {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+
+	if len(game.undone) == 0 {
+		return []int{}, nil
+	}
+
+	previousState := game.state()
+	lastAction := game.undone[len(game.undone)-1]
+	error := game.applyAction(lastAction, true)
+	if error != nil {
+		return nil, error
+	}
+
+	now := game.now()
+	game.undone = game.undone[:len(game.undone)-1]
+	game.history = append(game.history, lastAction)
+	game.endTs = lastAction.endTsAfter
+	game.logReplayAction(configs.ActionRedo, -1, -1, now)
+
+	if lastAction.Kind == configs.ActionFlag {
+		game.publishEvent(configs.EventFlagToggled, lastAction.TileIndexes, now)
+	} else {
+		game.publishRevealEvents(lastAction.TileIndexes, now)
+	}
+	game.publishStateEvent(previousState, now)
+
+	return lastAction.TileIndexes, nil
+}
+
+/*
+applyAction sets the tiles changed by the action to their state after the
+action, if forward is true, or before the action otherwise.
+*/
+func (game *game) applyAction(action action, forward bool) error {
+	snapshot := game.minefield.Snapshot()
+	changed := map[int]bool{}
+	for _, tileIndex := range action.TileIndexes {
+		changed[tileIndex] = true
+	}
+
+	if action.Kind == configs.ActionFlag {
+		flaggedTiles := []int{}
+		for _, tileIndex := range snapshot.FlaggedTiles {
+			if !changed[tileIndex] {
+				flaggedTiles = append(flaggedTiles, tileIndex)
+			}
+		}
+		for _, tileIndex := range action.TileIndexes {
+			if !containsTileIndex(snapshot.FlaggedTiles, tileIndex) {
+				flaggedTiles = append(flaggedTiles, tileIndex)
+			}
+		}
+		snapshot.FlaggedTiles = flaggedTiles
+	} else {
+		revealedTiles := []int{}
+		for _, tileIndex := range snapshot.RevealedTiles {
+			if !changed[tileIndex] {
+				revealedTiles = append(revealedTiles, tileIndex)
+			}
+		}
+		if forward {
+			revealedTiles = append(revealedTiles, action.TileIndexes...)
+		}
+		snapshot.RevealedTiles = revealedTiles
+	}
+
+	return game.minefield.Restore(snapshot)
+}
+
+/*
+containsTileIndex returns true if the tile index is in the provided slice.
+*/
+func containsTileIndex(tileIndexes []int, tileIndex int) bool {
+	for _, index := range tileIndexes {
+		if index == tileIndex {
+			return true
+		}
+	}
+
+	return false
+}
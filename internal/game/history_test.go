@@ -0,0 +1,185 @@
+package game_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type historyTestSuite struct {
+	suite.Suite
+	sut game.IGame
+}
+
+func (suite *historyTestSuite) SetupTest() {
+	suite.sut, _ = game.Generate(game.GameConfig{
+		NumRows:      10,
+		NumCols:      11,
+		NumMines:     20,
+		FlagsEnabled: true,
+		Lives:        2,
+		Seed:         "hello",
+	})
+}
+
+/*
+tileStates returns the revealed and flag state of every tile in the SUT.
+*/
+func (suite *historyTestSuite) tileStates() []tile {
+	config := suite.sut.Config()
+	tiles := []tile{}
+	for rIndex := 0; rIndex < config.NumRows; rIndex++ {
+		for cIndex := 0; cIndex < config.NumCols; cIndex++ {
+			sutTile, _ := suite.sut.Tile(rIndex, cIndex)
+			tiles = append(tiles, tile{
+				revealed: sutTile.Revealed(),
+				hasFlag:  sutTile.HasFlag(),
+			})
+		}
+	}
+
+	return tiles
+}
+
+func (suite *historyTestSuite) TestUndoHidesTheTilesRevealedByTheLastReveal() {
+	expected := suite.tileStates()
+	revealed, _ := suite.sut.RevealTile(4, 6)
+
+	actual, err := suite.sut.Undo()
+
+	require.Nil(suite.T(), err)
+	sort.Ints(revealed)
+	sort.Ints(actual)
+	require.Equal(suite.T(), revealed, actual)
+	require.Equal(suite.T(), expected, suite.tileStates())
+}
+
+func (suite *historyTestSuite) TestUndoRemovesTheFlagAddedByTheLastToggle() {
+	expected := suite.tileStates()
+	suite.sut.ToggleFlag(3, 4)
+
+	actual, err := suite.sut.Undo()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{3*11 + 4}, actual)
+	require.Equal(suite.T(), expected, suite.tileStates())
+}
+
+func (suite *historyTestSuite) TestUndoHidesTheTilesRevealedByTheLastProcessAdjacentTiles() {
+	suite.sut.ToggleFlag(3, 4)
+	expected := suite.tileStates()
+	suite.sut.ProcessAdjacentTiles(3, 3)
+
+	actual, err := suite.sut.Undo()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{4*11 + 4}, actual)
+	require.Equal(suite.T(), expected, suite.tileStates())
+}
+
+func (suite *historyTestSuite) TestUndoRestoresTheGameToOnGoingAfterALoss() {
+	suite.sut.RevealTile(2, 0)
+	suite.sut.RevealTile(0, 5)
+	require.Equal(suite.T(), configs.StateLoss, suite.sut.State())
+
+	_, err := suite.sut.Undo()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), configs.StateOnGoing, suite.sut.State())
+	require.Equal(suite.T(), true, suite.sut.Stats().EndTime.IsZero())
+	require.Equal(suite.T(), 1, suite.sut.Stats().RemainingLives)
+}
+
+func (suite *historyTestSuite) TestUndoCountsTheUndosUsedInTheStats() {
+	suite.sut.RevealTile(2, 0)
+	suite.sut.Undo()
+	suite.sut.Redo()
+	suite.sut.Undo()
+
+	require.Equal(suite.T(), 2, suite.sut.Stats().UndosUsed)
+	require.Equal(suite.T(), true, suite.sut.Stats().UndoAssisted)
+}
+
+func (suite *historyTestSuite) TestUndoDoesNotCountAnUndoIfThereIsNothingToUndo() {
+	suite.sut.Undo()
+
+	require.Equal(suite.T(), 0, suite.sut.Stats().UndosUsed)
+	require.Equal(suite.T(), false, suite.sut.Stats().UndoAssisted)
+}
+
+func (suite *historyTestSuite) TestUndoRevertsTheActionsInReverseOrder() {
+	expected := suite.tileStates()
+	suite.sut.RevealTile(4, 6)
+	suite.sut.ToggleFlag(3, 4)
+	suite.sut.RevealTile(2, 0)
+
+	suite.sut.Undo()
+	suite.sut.Undo()
+	suite.sut.Undo()
+
+	require.Equal(suite.T(), expected, suite.tileStates())
+}
+
+func (suite *historyTestSuite) TestUndoReturnsNoTilesIfThereIsNothingToUndo() {
+	actual, err := suite.sut.Undo()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{}, actual)
+}
+
+func (suite *historyTestSuite) TestUndoDoesNotRecordActionsThatChangedNothing() {
+	suite.sut.RevealTile(4, 6)
+	expected := suite.tileStates()
+	suite.sut.RevealTile(4, 6)
+	suite.sut.ToggleFlag(4, 6)
+
+	suite.sut.Undo()
+
+	require.NotEqual(suite.T(), expected, suite.tileStates())
+}
+
+func (suite *historyTestSuite) TestRedoAppliesTheLastUndoneAction() {
+	suite.sut.RevealTile(4, 6)
+	suite.sut.ToggleFlag(3, 4)
+	expected := suite.tileStates()
+	suite.sut.Undo()
+	suite.sut.Undo()
+
+	suite.sut.Redo()
+	actual, err := suite.sut.Redo()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{3*11 + 4}, actual)
+	require.Equal(suite.T(), expected, suite.tileStates())
+}
+
+func (suite *historyTestSuite) TestRedoRestoresTheEndTimeOfTheRedoneAction() {
+	suite.sut.RevealTile(2, 0)
+	suite.sut.RevealTile(0, 5)
+	expected := suite.sut.Stats().EndTime
+	suite.sut.Undo()
+
+	suite.sut.Redo()
+
+	require.Equal(suite.T(), configs.StateLoss, suite.sut.State())
+	require.Equal(suite.T(), expected, suite.sut.Stats().EndTime)
+}
+
+func (suite *historyTestSuite) TestRedoReturnsNoTilesAfterANewAction() {
+	suite.sut.RevealTile(4, 6)
+	suite.sut.Undo()
+	suite.sut.ToggleFlag(3, 4)
+
+	actual, err := suite.sut.Redo()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{}, actual)
+}
+
+func TestHistorySuite(t *testing.T) {
+	suite.Run(t, new(historyTestSuite))
+}
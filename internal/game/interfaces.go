@@ -6,13 +6,20 @@ import (
 	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
 )
 
-type IGame interface {
+type IGameView interface {
 	/*
 		Config returns the configuration data for a game.
 	*/
 	Config() *config
+	/*
+		GameConfig returns the configuration the game was generated with,
+		including the seed that was used.
+	*/
+	GameConfig() GameConfig
 	/*
 		StartTime returns the Time object of when the game started.
+		The game starts on the first tile reveal, before that the zero Time is
+		returned.
 	*/
 	StartTime() time.Time
 	/*
@@ -25,8 +32,17 @@ type IGame interface {
 	/*
 		Tile searches for the tile in the requested row and column.
 		The row and column coordinates are zero-indexed.
+		The returned tile is a copy, which is not updated by later actions.
 	*/
 	Tile(rowIndex int, colIndex int) (minefield.ITile, error)
+	/*
+		Stats returns information about the current game.
+	*/
+	Stats() stats
+}
+
+type IGame interface {
+	IGameView
 	/*
 		RevealTile reveals the requested tile.
 		If the tile is empty the patch it belongs to will be revealed.
@@ -44,7 +60,92 @@ type IGame interface {
 	*/
 	ProcessAdjacentTiles(rowIndex int, colIndex int) ([]int, error)
 	/*
-		Stats returns information about the current game.
+		Undo reverts the last action made by the player, including the game's end
+		time.
+		Each undo is counted in the game's stats.
+		Returns the indexes of the tiles that changed, which is empty if there is
+		nothing to undo.
 	*/
-	Stats() stats
+	Undo() ([]int, error)
+	/*
+		Redo applies again the last action that was undone, including the game's
+		end time.
+		Returns the indexes of the tiles that changed, which is empty if there is
+		nothing to redo.
+	*/
+	Redo() ([]int, error)
+	/*
+		Hint analyses the visible state of the game and returns a tile that is
+		certainly safe or, if there are none, the tile with the lowest probability
+		of having a mine.
+		Each hint is counted in the game's stats.
+		If the game is not on going no hint is given and the returned tile index
+		is -1.
+	*/
+	Hint() (hint, error)
+	/*
+		Subscribe returns a channel that receives every event of the game, in the
+		order they happened, and the function that cancels the subscription and
+		closes the channel.
+		A subscriber that has too many events waiting is removed, and its channel
+		closed.
+	*/
+	Subscribe() (<-chan event, func())
+	/*
+		Save serializes the game into a versioned JSON document that can be given
+		to Load to rebuild the game.
+	*/
+	Save() ([]byte, error)
+	/*
+		SaveReplay serializes every action processed by the game, with the game's
+		configuration and seed, into a versioned JSON document that can be given
+		to NewReplayer.
+	*/
+	SaveReplay() ([]byte, error)
+	/*
+		ExportRAWVF writes the board and the actions of the game as a RAWVF video.
+		Each reveal, flag and reveal of adjacent tiles is written as the mouse
+		events of the matching click, timed from the first action.
+		Hints are not written, nor the tiles revealed when the board was
+		generated, and games with undone or redone actions can not be exported.
+	*/
+	ExportRAWVF() ([]byte, error)
+}
+
+type IRemoteGame interface {
+	IGame
+	/*
+		Update sets the visible state of the tiles changed on the server, and the
+		game's state, and publishes the events of the changes.
+		Tiles that are not on the board are ignored.
+	*/
+	Update(tiles []RemoteTile, state int)
+}
+
+type IReplayer interface {
+	/*
+		Game returns the game the actions are applied to.
+	*/
+	Game() IGame
+	/*
+		Done returns true if every action was applied.
+	*/
+	Done() bool
+	/*
+		NextDelay returns the time between the previous action and the next
+		action, as it happened in the recorded game.
+	*/
+	NextDelay() time.Duration
+	/*
+		Now returns the time, in the recorded game, of the last action applied.
+		Before the first action the zero Time is returned.
+	*/
+	Now() time.Time
+	/*
+		Step applies the next action and returns the indexes of the tiles that
+		changed.
+		After the last action the game is checked against the recorded game and
+		an error is returned if they do not match.
+	*/
+	Step() ([]int, error)
 }
@@ -0,0 +1,198 @@
+package game
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/rawvf"
+)
+
+// The time the imported RAWVF videos are considered to start at
+var rawvfStartTime = time.Unix(0, 0).UTC()
+
+// Error: The mines are only placed on the first reveal, which has not happened
+type minesNotPlacedError struct{}
+
+/*
+Error prints the message for this error.
+*/
+func (e minesNotPlacedError) Error() string {
+	return "The mines are not placed until the first tile is revealed"
+}
+
+// Error: The RAWVF format only describes boards of square tiles with the usual
+// adjacent tiles
+type rawvfUnsupportedTopologyError struct {
+	Topology int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e rawvfUnsupportedTopologyError) Error() string {
+	return fmt.Sprintf("The games with the topology '%v' can not be exported to RAWVF", e.Topology)
+}
+
+// Error: The RAWVF format only describes rectangular boards
+type rawvfUnsupportedMaskError struct{}
+
+/*
+Error prints the message for this error.
+*/
+func (e rawvfUnsupportedMaskError) Error() string {
+	return "The games with masked tiles can not be exported to RAWVF"
+}
+
+// Error: The RAWVF format only describes boards with a single layer
+type rawvfUnsupportedLayersError struct {
+	NumLayers int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e rawvfUnsupportedLayersError) Error() string {
+	return fmt.Sprintf("The games with '%v' layers can not be exported to RAWVF", e.NumLayers)
+}
+
+// Error: The action can not be represented in the RAWVF format
+type rawvfUnsupportedActionError struct {
+	Kind int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e rawvfUnsupportedActionError) Error() string {
+	return fmt.Sprintf("The action kind '%v' can not be exported to RAWVF", e.Kind)
+}
+
+/*
+ExportRAWVF writes the board and the actions of the game as a RAWVF video.
+Each reveal, flag and reveal of adjacent tiles is written as the mouse events of
+the matching click, timed from the first action.
+Hints are not written, nor the tiles revealed when the board was generated,
+and games with undone or redone actions, with another topology, with masked
+tiles or with more than one layer can not be exported.
+*/
+func (game *game) ExportRAWVF() ([]byte, error) {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+
+	if game.gameConfig.Topology != configs.TopologySquare {
+		return nil, rawvfUnsupportedTopologyError{
+			Topology: game.gameConfig.Topology,
+		}
+	}
+	if game.gameConfig.MaskedTiles != nil {
+		return nil, rawvfUnsupportedMaskError{}
+	}
+	if game.numLayers > 1 {
+		return nil, rawvfUnsupportedLayersError{
+			NumLayers: game.numLayers,
+		}
+	}
+
+	video := rawvf.Video{
+		Width:  game.numCols,
+		Height: game.numRows,
+		Mines:  game.numMines,
+		Properties: map[string]string{
+			"Program": "go-minesweeper",
+			"Mode":    "Classic",
+			"Marks":   "Off",
+		},
+		MineTiles: []int{},
+		Events:    []rawvf.Event{},
+	}
+
+	for rowIndex := 0; rowIndex < game.numRows; rowIndex++ {
+		for colIndex := 0; colIndex < game.numCols; colIndex++ {
+			tile, _ := game.minefield.Tile(rowIndex, colIndex)
+			if tile.HasMine() {
+				video.MineTiles = append(video.MineTiles, rowIndex*game.numCols+colIndex)
+			}
+		}
+	}
+	if len(video.MineTiles) != game.numMines {
+		return nil, minesNotPlacedError{}
+	}
+
+	if !game.startTs.IsZero() && !game.endTs.IsZero() {
+		video.Properties["Time"] = fmt.Sprintf("%.3f", game.endTs.Sub(game.startTs).Seconds())
+	}
+
+	for _, action := range game.replayActions {
+		click := rawvf.Click{
+			Time:     action.Timestamp.Sub(game.replayActions[0].Timestamp),
+			RowIndex: action.RowIndex,
+			ColIndex: action.ColIndex,
+		}
+
+		switch action.Kind {
+		case configs.ActionReveal:
+			click.Type = configs.PrimaryClick
+		case configs.ActionFlag:
+			click.Type = configs.SecondaryClick
+		case configs.ActionProcessAdjacent:
+			click.Type = configs.BothClick
+		case configs.ActionHint:
+			continue
+		default:
+			return nil, rawvfUnsupportedActionError{
+				Kind: action.Kind,
+			}
+		}
+
+		video.Events = append(video.Events, rawvf.ClickEvents(click)...)
+	}
+
+	return rawvf.Format(video), nil
+}
+
+/*
+ImportRAWVF plays the clicks of a RAWVF video on its board and returns the
+replay of the game, which can be given to NewReplayer.
+The game has flags enabled and a single life.
+*/
+func ImportRAWVF(data []byte) ([]byte, error) {
+	video, error := rawvf.Parse(data)
+	if error != nil {
+		return nil, error
+	}
+
+	gameInterface, error := Generate(GameConfig{
+		NumMines:     video.Mines,
+		NumRows:      video.Height,
+		NumCols:      video.Width,
+		FlagsEnabled: true,
+		Lives:        1,
+		MineTiles:    video.MineTiles,
+	})
+	if error != nil {
+		return nil, error
+	}
+	game := gameInterface.(*game)
+
+	for _, click := range rawvf.Clicks(video) {
+		clickTime := rawvfStartTime.Add(click.Time)
+		game.now = func() time.Time {
+			return clickTime
+		}
+
+		switch click.Type {
+		case configs.PrimaryClick:
+			_, error = game.RevealTile(click.RowIndex, click.ColIndex)
+		case configs.SecondaryClick:
+			error = game.ToggleFlag(click.RowIndex, click.ColIndex)
+		case configs.BothClick:
+			_, error = game.ProcessAdjacentTiles(click.RowIndex, click.ColIndex)
+		}
+		if error != nil {
+			return nil, error
+		}
+	}
+
+	return game.SaveReplay()
+}
@@ -0,0 +1,174 @@
+package game_test
+
+import (
+	"testing"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type rawvfTestSuite struct {
+	suite.Suite
+	sut game.IGame
+}
+
+func (suite *rawvfTestSuite) SetupTest() {
+	suite.sut, _ = game.Generate(game.GameConfig{
+		NumRows:      10,
+		NumCols:      11,
+		NumMines:     20,
+		FlagsEnabled: true,
+		Lives:        1,
+		Seed:         "hello",
+		SafeStart:    configs.SafeStartTile,
+	})
+}
+
+/*
+replayAll applies every action of a replay and returns the replayed game.
+*/
+func (suite *rawvfTestSuite) replayAll(data []byte) game.IGame {
+	replayer, err := game.NewReplayer(data)
+	require.Nil(suite.T(), err)
+
+	for !replayer.Done() {
+		_, err = replayer.Step()
+		require.Nil(suite.T(), err)
+	}
+
+	return replayer.Game()
+}
+
+func (suite *rawvfTestSuite) TestImportRAWVFReproducesAnExportedGame() {
+	suite.sut.RevealTile(0, 0)
+	for tileIndex := 0; tileIndex < 110; tileIndex++ {
+		tile, _ := suite.sut.Tile(tileIndex/11, tileIndex%11)
+		if tile.Revealed() && tile.AdjacentMines() > 0 {
+			continue
+		}
+		if !tile.Revealed() && tile.HasMine() {
+			suite.sut.ToggleFlag(tileIndex/11, tileIndex%11)
+		}
+	}
+	suite.sut.ProcessAdjacentTiles(0, 0)
+	suite.sut.Hint()
+
+	data, err := suite.sut.ExportRAWVF()
+	require.Nil(suite.T(), err)
+	replay, err := game.ImportRAWVF(data)
+	require.Nil(suite.T(), err)
+	actual := suite.replayAll(replay)
+
+	require.Equal(suite.T(), suite.sut.State(), actual.State())
+	for rIndex := 0; rIndex < 10; rIndex++ {
+		for cIndex := 0; cIndex < 11; cIndex++ {
+			expectedTile, _ := suite.sut.Tile(rIndex, cIndex)
+			actualTile, _ := actual.Tile(rIndex, cIndex)
+
+			require.Equalf(suite.T(), expectedTile.HasMine(), actualTile.HasMine(), "row index: %v | col index: %v", rIndex, cIndex)
+			require.Equalf(suite.T(), expectedTile.Revealed(), actualTile.Revealed(), "row index: %v | col index: %v", rIndex, cIndex)
+			require.Equalf(suite.T(), expectedTile.HasFlag(), actualTile.HasFlag(), "row index: %v | col index: %v", rIndex, cIndex)
+		}
+	}
+}
+
+func (suite *rawvfTestSuite) TestImportRAWVFPlaysTheClicksOfTheVideo() {
+	video := `Width: 3
+Height: 2
+Mines: 1
+Board:
+00*
+000
+Events:
+0.00 lc 1 2 (8 24)
+0.10 lr 1 2 (8 24)
+0.50 rc 3 1 (40 8)
+0.60 rr 3 1 (40 8)
+1.00 lc 3 2 (40 24)
+1.10 lr 3 2 (40 24)
+`
+
+	replay, err := game.ImportRAWVF([]byte(video))
+	require.Nil(suite.T(), err)
+	actual := suite.replayAll(replay)
+
+	require.Equal(suite.T(), configs.StateWin, actual.State())
+	flaggedTile, _ := actual.Tile(0, 2)
+	require.Equal(suite.T(), true, flaggedTile.HasFlag())
+	require.InDelta(suite.T(), 1.0, actual.Stats().EndTime.Sub(actual.StartTime()).Seconds(), 1e-9)
+}
+
+func (suite *rawvfTestSuite) TestImportRAWVFReturnsAnErrorIfTheBoardDoesNotHaveTheNumberOfMines() {
+	_, err := game.ImportRAWVF([]byte("Width: 2\nHeight: 1\nMines: 2\nBoard:\n*0\nEvents:\n"))
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *rawvfTestSuite) TestExportRAWVFReturnsAnErrorIfTheMinesWereNotPlaced() {
+	_, err := suite.sut.ExportRAWVF()
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *rawvfTestSuite) TestExportRAWVFReturnsAnErrorIfAnActionWasUndone() {
+	suite.sut.RevealTile(0, 0)
+	suite.sut.Undo()
+
+	_, err := suite.sut.ExportRAWVF()
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *rawvfTestSuite) TestExportRAWVFReturnsAnErrorForAGameWithHexagonalTiles() {
+	sut, _ := game.Generate(game.GameConfig{
+		NumRows:   3,
+		NumCols:   3,
+		NumMines:  1,
+		Lives:     1,
+		Topology:  configs.TopologyHex,
+		MineTiles: []int{4},
+	})
+	sut.RevealTile(0, 0)
+
+	_, err := sut.ExportRAWVF()
+
+	require.EqualError(suite.T(), err, "The games with the topology '1' can not be exported to RAWVF")
+}
+
+func (suite *rawvfTestSuite) TestExportRAWVFReturnsAnErrorForABoardWithMaskedTiles() {
+	sut, _ := game.Generate(game.GameConfig{
+		NumRows:     3,
+		NumCols:     3,
+		NumMines:    1,
+		Lives:       1,
+		MineTiles:   []int{4},
+		MaskedTiles: []int{8},
+	})
+	sut.RevealTile(0, 0)
+
+	_, err := sut.ExportRAWVF()
+
+	require.EqualError(suite.T(), err, "The games with masked tiles can not be exported to RAWVF")
+}
+
+func (suite *rawvfTestSuite) TestExportRAWVFReturnsAnErrorForABoardWithLayers() {
+	sut, _ := game.Generate(game.GameConfig{
+		NumLayers: 2,
+		NumRows:   3,
+		NumCols:   3,
+		NumMines:  1,
+		Lives:     1,
+		MineTiles: []int{4},
+	})
+	sut.RevealTile(0, 0)
+
+	_, err := sut.ExportRAWVF()
+
+	require.EqualError(suite.T(), err, "The games with '2' layers can not be exported to RAWVF")
+}
+
+func TestRawvfSuite(t *testing.T) {
+	suite.Run(t, new(rawvfTestSuite))
+}
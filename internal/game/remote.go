@@ -0,0 +1,470 @@
+package game
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
+)
+
+// Error: The requested tile of a remote game does not exist
+type tileNotFoundError struct {
+	RowIndex int
+	ColIndex int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e tileNotFoundError) Error() string {
+	return fmt.Sprintf(
+		"Tile not found for row index '%v' and col index '%v'",
+		e.RowIndex, e.ColIndex)
+}
+
+// Error: The action needs the board, which is kept by the server
+type remoteActionError struct {
+	Action string
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e remoteActionError) Error() string {
+	return fmt.Sprintf("The action '%v' is not available in a game played on a server", e.Action)
+}
+
+// RemoteTile is the visible state of a tile of a game played on a server.
+// The mine and the number of adjacent mines are only set once it is revealed
+type RemoteTile struct {
+	TileIndex     int
+	Revealed      bool
+	HasFlag       bool
+	HasMine       bool
+	AdjacentMines int
+}
+
+// RemoteTileState is the copy of a remote game's tile returned by Tile
+type remoteTileState struct {
+	tile RemoteTile
+}
+
+/*
+Revealed returns true if the tile is revealed and false otherwise.
+*/
+func (t remoteTileState) Revealed() bool {
+	return t.tile.Revealed
+}
+
+/*
+HasMine returns true if the tile is revealed and has a mine, and false
+otherwise.
+*/
+func (t remoteTileState) HasMine() bool {
+	return t.tile.HasMine
+}
+
+/*
+HasFlag returns true if the tile has a flag and false otherwise.
+*/
+func (t remoteTileState) HasFlag() bool {
+	return t.tile.HasFlag
+}
+
+/*
+AdjacentMines returns the number of mines in adjacent tiles, or 0 if the tile
+is not revealed.
+*/
+func (t remoteTileState) AdjacentMines() int {
+	return t.tile.AdjacentMines
+}
+
+// RemoteGame is a game whose board is kept by a server, so the player can not
+// learn where the mines are.
+// The actions are sent to the server, which answers with the tiles they changed.
+// Every exported method holds the mutex, and the unexported methods expect it to
+// be held.
+type remoteGame struct {
+	mutex      sync.RWMutex
+	gameConfig GameConfig
+	startTs    time.Time
+	endTs      time.Time
+	numLayers  int
+	numRows    int
+	numCols    int
+	state      int
+	// The visible state of every cell of the grid, by tile index
+	tiles []RemoteTile
+	// The cells of the grid that are not tiles of the board
+	masked map[int]bool
+	// Sends an action to the server, which is one of "reveal", "flag" or "chord"
+	send func(action string, rowIndex int, colIndex int) error
+	now  func() time.Time
+	// The channels of the subscribers to the game's events
+	subscribers subscribers
+}
+
+/*
+NewRemote creates a game played on a server, with the configuration of the
+server's game, whose seed and mine tiles are not needed.
+Every action is given to send, and the tiles it changed must be given back to
+Update once the server answers.
+The errors of send are returned by the actions.
+Returns an error if the configuration is not valid.
+*/
+func NewRemote(args GameConfig, send func(action string, rowIndex int, colIndex int) error) (IRemoteGame, error) {
+	error := Validate(args)
+	if error != nil {
+		return nil, error
+	}
+
+	newGame := &remoteGame{
+		gameConfig:  args,
+		numLayers:   numLayers(args),
+		numRows:     numLayers(args) * args.NumRows,
+		numCols:     args.NumCols,
+		state:       configs.StateOnGoing,
+		masked:      make(map[int]bool, len(args.MaskedTiles)),
+		send:        send,
+		now:         time.Now,
+		subscribers: subscribers{},
+	}
+
+	newGame.tiles = make([]RemoteTile, newGame.numRows*newGame.numCols)
+	for tileIndex := range newGame.tiles {
+		newGame.tiles[tileIndex].TileIndex = tileIndex
+	}
+	for _, tileIndex := range args.MaskedTiles {
+		newGame.masked[tileIndex] = true
+	}
+
+	return newGame, nil
+}
+
+/*
+Config returns the configuration data for a game.
+*/
+func (game *remoteGame) Config() *config {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+
+	return &config{
+		NumMines:  game.gameConfig.NumMines,
+		NumRows:   game.numRows,
+		NumCols:   game.numCols,
+		NumLayers: game.numLayers,
+	}
+}
+
+/*
+GameConfig returns the configuration the game was created with.
+*/
+func (game *remoteGame) GameConfig() GameConfig {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+
+	return game.gameConfig
+}
+
+/*
+StartTime returns the Time object of when the game started.
+The game starts when the server answers with the first revealed tiles, before
+that the zero Time is returned.
+*/
+func (game *remoteGame) StartTime() time.Time {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+
+	return game.startTs
+}
+
+/*
+State returns the game's state, as last answered by the server.
+*/
+func (game *remoteGame) State() int {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+
+	return game.state
+}
+
+/*
+Tile searches for the tile in the requested row and column.
+The returned tile is a copy, which is not updated by later actions.
+*/
+func (game *remoteGame) Tile(rowIndex int, colIndex int) (minefield.ITile, error) {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+
+	tileIndex, error := game.tileIndex(rowIndex, colIndex)
+	if error != nil {
+		return nil, error
+	}
+
+	return remoteTileState{tile: game.tiles[tileIndex]}, nil
+}
+
+/*
+RevealTile sends the reveal of the requested tile to the server.
+The tiles are revealed once the server answers, so no tile indexes are returned.
+*/
+func (game *remoteGame) RevealTile(rowIndex int, colIndex int) ([]int, error) {
+	return nil, game.sendAction("reveal", rowIndex, colIndex)
+}
+
+/*
+ToggleFlag sends the flag of the requested tile to the server.
+*/
+func (game *remoteGame) ToggleFlag(rowIndex int, colIndex int) error {
+	if !game.GameConfig().FlagsEnabled {
+		return nil
+	}
+
+	return game.sendAction("flag", rowIndex, colIndex)
+}
+
+/*
+ProcessAdjacentTiles sends the reveal of the tiles adjacent to the requested
+tile to the server.
+The tiles are revealed once the server answers, so no tile indexes are returned.
+*/
+func (game *remoteGame) ProcessAdjacentTiles(rowIndex int, colIndex int) ([]int, error) {
+	return nil, game.sendAction("chord", rowIndex, colIndex)
+}
+
+/*
+Undo is not available, since the server does not undo actions.
+*/
+func (game *remoteGame) Undo() ([]int, error) {
+	return nil, remoteActionError{
+		Action: "undo",
+	}
+}
+
+/*
+Redo is not available, since the server does not undo actions.
+*/
+func (game *remoteGame) Redo() ([]int, error) {
+	return nil, remoteActionError{
+		Action: "redo",
+	}
+}
+
+/*
+Hint is not available, since it needs the board kept by the server.
+*/
+func (game *remoteGame) Hint() (hint, error) {
+	return hint{TileIndex: -1}, remoteActionError{
+		Action: "hint",
+	}
+}
+
+/*
+Stats returns information about the current game.
+The 3BV and the clicks are not known, since they need the board kept by the
+server.
+*/
+func (game *remoteGame) Stats() stats {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+
+	numFlags := 0
+	numMinesRevealed := 0
+	for _, tile := range game.tiles {
+		if tile.HasFlag {
+			numFlags++
+		}
+		if tile.Revealed && tile.HasMine {
+			numMinesRevealed++
+		}
+	}
+
+	return stats{
+		StartTime:      game.startTs,
+		EndTime:        game.endTs,
+		RemainingMines: game.gameConfig.NumMines - numFlags,
+		RemainingLives: game.gameConfig.Lives - numMinesRevealed,
+	}
+}
+
+/*
+Subscribe returns a channel that receives every event of the game, in the order
+the server's answers were given to Update, and the function that cancels the
+subscription and closes the channel.
+A subscriber that has too many events waiting is removed, and its channel
+closed.
+*/
+func (game *remoteGame) Subscribe() (<-chan event, func()) {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+
+	events := game.subscribers.add()
+
+	return events, func() {
+		game.mutex.Lock()
+		defer game.mutex.Unlock()
+
+		game.subscribers.remove(events)
+	}
+}
+
+/*
+Save is not available, since the game can only be resumed on the server.
+*/
+func (game *remoteGame) Save() ([]byte, error) {
+	return nil, remoteActionError{
+		Action: "save",
+	}
+}
+
+/*
+SaveReplay is not available, since a replay needs the board kept by the server.
+*/
+func (game *remoteGame) SaveReplay() ([]byte, error) {
+	return nil, remoteActionError{
+		Action: "save replay",
+	}
+}
+
+/*
+ExportRAWVF is not available, since a video needs the board kept by the server.
+*/
+func (game *remoteGame) ExportRAWVF() ([]byte, error) {
+	return nil, remoteActionError{
+		Action: "export RAWVF",
+	}
+}
+
+/*
+Update sets the visible state of the tiles changed on the server, and the
+game's state, and publishes the events of the changes.
+Tiles that are not on the board are ignored.
+*/
+func (game *remoteGame) Update(tiles []RemoteTile, state int) {
+	game.mutex.Lock()
+	defer game.mutex.Unlock()
+
+	now := game.now()
+	revealedTileIndexes := []int{}
+	mineTileIndexes := []int{}
+	hiddenTileIndexes := []int{}
+	flagTileIndexes := []int{}
+
+	for _, tile := range tiles {
+		if tile.TileIndex < 0 || tile.TileIndex > len(game.tiles)-1 || game.masked[tile.TileIndex] {
+			continue
+		}
+
+		previousTile := game.tiles[tile.TileIndex]
+		game.tiles[tile.TileIndex] = tile
+
+		switch {
+		case tile.Revealed && !previousTile.Revealed:
+			revealedTileIndexes = append(revealedTileIndexes, tile.TileIndex)
+			if tile.HasMine {
+				mineTileIndexes = append(mineTileIndexes, tile.TileIndex)
+			}
+		case !tile.Revealed && previousTile.Revealed:
+			hiddenTileIndexes = append(hiddenTileIndexes, tile.TileIndex)
+		case tile.HasFlag != previousTile.HasFlag:
+			flagTileIndexes = append(flagTileIndexes, tile.TileIndex)
+		}
+	}
+
+	if len(revealedTileIndexes) > 0 && game.startTs.IsZero() {
+		game.startTs = now
+		game.subscribers.publish(configs.EventTimerStarted, []int{}, now)
+	}
+	game.publishTileEvent(configs.EventTileRevealed, revealedTileIndexes, now)
+	game.publishTileEvent(configs.EventLifeLost, mineTileIndexes, now)
+	game.publishTileEvent(configs.EventTileHidden, hiddenTileIndexes, now)
+	game.publishTileEvent(configs.EventFlagToggled, flagTileIndexes, now)
+
+	if state == game.state {
+		return
+	}
+	game.state = state
+
+	switch state {
+	case configs.StateWin:
+		game.endTs = now
+		game.subscribers.publish(configs.EventGameWon, []int{}, now)
+	case configs.StateLoss:
+		game.endTs = now
+		game.subscribers.publish(configs.EventGameLost, []int{}, now)
+	default:
+		game.endTs = time.Time{}
+		game.subscribers.publish(configs.EventGameResumed, []int{}, now)
+	}
+}
+
+/*
+publishTileEvent sends an event for the changed tiles, if any tile changed.
+*/
+func (game *remoteGame) publishTileEvent(kind int, tileIndexes []int, timestamp time.Time) {
+	if len(tileIndexes) == 0 {
+		return
+	}
+
+	game.subscribers.publish(kind, tileIndexes, timestamp)
+}
+
+/*
+sendAction gives the action on the requested tile to send, if the game is on
+going and the action can change the tile.
+The mutex is not held while the action is sent, so the answer can be given to
+Update.
+Returns the error of send, if any.
+*/
+func (game *remoteGame) sendAction(action string, rowIndex int, colIndex int) error {
+	game.mutex.RLock()
+	tileIndex, error := game.tileIndex(rowIndex, colIndex)
+	changesTile := error == nil && game.state == configs.StateOnGoing &&
+		changesTile(action, game.tiles[tileIndex])
+	game.mutex.RUnlock()
+
+	if !changesTile {
+		return error
+	}
+
+	return game.send(action, rowIndex, colIndex)
+}
+
+/*
+changesTile returns true if the action can change the visible state of the
+board.
+Only hidden tiles without a flag are revealed, only hidden tiles have their
+flag toggled and only revealed tiles with adjacent mines reveal the tiles
+adjacent to them.
+*/
+func changesTile(action string, tile RemoteTile) bool {
+	switch action {
+	case "reveal":
+		return !tile.Revealed && !tile.HasFlag
+	case "flag":
+		return !tile.Revealed
+	case "chord":
+		return tile.Revealed && tile.AdjacentMines > 0
+	}
+
+	return false
+}
+
+/*
+tileIndex returns the index of the tile in the requested row and column.
+Returns an error if the cell is outside the board or is not a tile.
+*/
+func (game *remoteGame) tileIndex(rowIndex int, colIndex int) (int, error) {
+	tileIndex := rowIndex*game.numCols + colIndex
+	if rowIndex < 0 || rowIndex > game.numRows-1 || colIndex < 0 || colIndex > game.numCols-1 ||
+		game.masked[tileIndex] {
+		return 0, tileNotFoundError{
+			RowIndex: rowIndex,
+			ColIndex: colIndex,
+		}
+	}
+
+	return tileIndex, nil
+}
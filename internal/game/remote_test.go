@@ -0,0 +1,131 @@
+package game_test
+
+import (
+	"testing"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type remoteTestSuite struct {
+	suite.Suite
+	sut game.IRemoteGame
+	// The actions given to the game's send function
+	sent []string
+}
+
+func (suite *remoteTestSuite) SetupTest() {
+	suite.sent = []string{}
+	suite.sut, _ = game.NewRemote(game.GameConfig{
+		NumRows:      3,
+		NumCols:      3,
+		NumMines:     1,
+		FlagsEnabled: true,
+		Lives:        2,
+		MaskedTiles:  []int{8},
+	}, func(action string, rowIndex int, colIndex int) error {
+		suite.sent = append(suite.sent, action)
+		return nil
+	})
+}
+
+func (suite *remoteTestSuite) TestRevealTileSendsTheActionWithoutChangingTheTile() {
+	tileIndexes, err := suite.sut.RevealTile(1, 1)
+
+	require.Nil(suite.T(), err)
+	require.Empty(suite.T(), tileIndexes)
+	require.Equal(suite.T(), []string{"reveal"}, suite.sent)
+	tile, _ := suite.sut.Tile(1, 1)
+	require.Equal(suite.T(), false, tile.Revealed())
+}
+
+func (suite *remoteTestSuite) TestTheActionsOnACellThatIsNotATileReturnAnErrorAndAreNotSent() {
+	_, err := suite.sut.RevealTile(2, 2)
+
+	require.EqualError(suite.T(), err, "Tile not found for row index '2' and col index '2'")
+	require.Empty(suite.T(), suite.sent)
+}
+
+func (suite *remoteTestSuite) TestUpdateShowsTheTilesAndPublishesTheirEvents() {
+	events, _ := suite.sut.Subscribe()
+
+	suite.sut.Update([]game.RemoteTile{
+		{TileIndex: 0, Revealed: true, HasMine: true},
+		{TileIndex: 4, Revealed: true, AdjacentMines: 1},
+		{TileIndex: 5, HasFlag: true},
+	}, configs.StateOnGoing)
+
+	require.Equal(suite.T(), 4, len(events))
+	require.Equal(suite.T(), configs.EventTimerStarted, (<-events).Kind)
+	event := <-events
+	require.Equal(suite.T(), configs.EventTileRevealed, event.Kind)
+	require.Equal(suite.T(), []int{0, 4}, event.TileIndexes)
+	event = <-events
+	require.Equal(suite.T(), configs.EventLifeLost, event.Kind)
+	require.Equal(suite.T(), []int{0}, event.TileIndexes)
+	event = <-events
+	require.Equal(suite.T(), configs.EventFlagToggled, event.Kind)
+	require.Equal(suite.T(), []int{5}, event.TileIndexes)
+
+	tile, _ := suite.sut.Tile(1, 1)
+	require.Equal(suite.T(), 1, tile.AdjacentMines())
+	require.Equal(suite.T(), 0, suite.sut.Stats().RemainingMines)
+	require.Equal(suite.T(), 1, suite.sut.Stats().RemainingLives)
+}
+
+func (suite *remoteTestSuite) TestUpdateEndsTheGameWithTheServersState() {
+	events, _ := suite.sut.Subscribe()
+
+	suite.sut.Update([]game.RemoteTile{{TileIndex: 4, Revealed: true}}, configs.StateWin)
+
+	require.Equal(suite.T(), configs.StateWin, suite.sut.State())
+	require.Equal(suite.T(), false, suite.sut.Stats().EndTime.IsZero())
+	<-events
+	<-events
+	require.Equal(suite.T(), configs.EventGameWon, (<-events).Kind)
+}
+
+func (suite *remoteTestSuite) TestTheActionsAreNotSentOnceTheGameEnded() {
+	suite.sut.Update([]game.RemoteTile{}, configs.StateLoss)
+
+	suite.sut.RevealTile(1, 1)
+	suite.sut.ToggleFlag(1, 1)
+
+	require.Empty(suite.T(), suite.sent)
+}
+
+func (suite *remoteTestSuite) TestTheActionsThatCanNotChangeTheTileAreNotSent() {
+	suite.sut.Update([]game.RemoteTile{
+		{TileIndex: 3, Revealed: true},
+		{TileIndex: 4, Revealed: true, AdjacentMines: 1},
+		{TileIndex: 5, HasFlag: true},
+	}, configs.StateOnGoing)
+
+	suite.sut.RevealTile(1, 1)
+	suite.sut.RevealTile(1, 2)
+	suite.sut.ToggleFlag(1, 1)
+	suite.sut.ProcessAdjacentTiles(1, 0)
+	suite.sut.ProcessAdjacentTiles(0, 0)
+	require.Empty(suite.T(), suite.sent)
+
+	suite.sut.ProcessAdjacentTiles(1, 1)
+	suite.sut.ToggleFlag(1, 2)
+	require.Equal(suite.T(), []string{"chord", "flag"}, suite.sent)
+}
+
+func (suite *remoteTestSuite) TestTheActionsThatNeedTheBoardReturnAnError() {
+	_, err := suite.sut.Hint()
+	require.EqualError(suite.T(), err, "The action 'hint' is not available in a game played on a server")
+
+	_, err = suite.sut.Undo()
+	require.NotNil(suite.T(), err)
+
+	_, err = suite.sut.SaveReplay()
+	require.NotNil(suite.T(), err)
+}
+
+func TestRemoteSuite(t *testing.T) {
+	suite.Run(t, new(remoteTestSuite))
+}
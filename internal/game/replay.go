@@ -0,0 +1,303 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+)
+
+// The version of the replay document written by SaveReplay
+const replayVersion int = 2
+
+// Error: The replay document was written by an unsupported version
+type unsupportedReplayVersionError struct {
+	Version int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e unsupportedReplayVersionError) Error() string {
+	return fmt.Sprintf("The replay version '%v' is not supported", e.Version)
+}
+
+// Error: Replaying the actions did not reproduce the recorded game
+type replayMismatchError struct {
+	Expected replayResult
+	Actual   replayResult
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e replayMismatchError) Error() string {
+	return fmt.Sprintf(
+		"The replay ended with '%+v' but the recorded game ended with '%+v'",
+		e.Actual, e.Expected)
+}
+
+// Error: The replay action has an unknown kind
+type unknownReplayActionError struct {
+	Kind int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e unknownReplayActionError) Error() string {
+	return fmt.Sprintf("The replay action kind '%v' is not known", e.Kind)
+}
+
+// ReplayAction describes an action processed by a game and when it happened
+type replayAction struct {
+	// One of the configs.Action* constants
+	Kind int
+	// The coordinates of the tile the action was made on, -1 if not applicable
+	RowIndex  int
+	ColIndex  int
+	Timestamp time.Time
+}
+
+// ReplayResult contains the state and stats a replay must reproduce
+type replayResult struct {
+	State int
+	Stats stats
+}
+
+// ReplayDocument contains everything needed to replay a game
+type replayDocument struct {
+	Version int
+	Config  GameConfig
+	Actions []replayAction
+	Result  replayResult
+}
+
+/*
+logReplayAction adds an action to the log used to replay the game.
+*/
+func (game *game) logReplayAction(kind int, rowIndex int, colIndex int, timestamp time.Time) {
+	game.replayActions = append(game.replayActions, replayAction{
+		Kind:      kind,
+		RowIndex:  rowIndex,
+		ColIndex:  colIndex,
+		Timestamp: timestamp,
+	})
+}
+
+/*
+result returns the state and stats of the game that a replay must reproduce.
+*/
+func (game *game) result() replayResult {
+	return replayResult{
+		State: game.state(),
+		Stats: game.stats(),
+	}
+}
+
+/*
+SaveReplay serializes every action processed by the game, with the game's
+configuration and seed, into a versioned JSON document that can be given to
+NewReplayer.
+*/
+func (game *game) SaveReplay() ([]byte, error) {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+
+	return json.Marshal(replayDocument{
+		Version: replayVersion,
+		Config:  game.gameConfig,
+		Actions: game.replayActions,
+		Result:  game.result(),
+	})
+}
+
+/*
+NewReplayer creates a replayer for a document created by SaveReplay.
+The board is generated again from the recorded seed.
+*/
+func NewReplayer(data []byte) (IReplayer, error) {
+	document := replayDocument{}
+	error := json.Unmarshal(data, &document)
+	if error != nil {
+		return nil, error
+	}
+	if document.Version != replayVersion {
+		return nil, unsupportedReplayVersionError{
+			Version: document.Version,
+		}
+	}
+
+	gameInterface, error := Generate(document.Config)
+	if error != nil {
+		return nil, error
+	}
+
+	replayer := &replayer{
+		game:     gameInterface.(*game),
+		document: document,
+	}
+	// The game's clock follows the recorded timestamps
+	replayer.game.now = func() time.Time {
+		replayer.mutex.Lock()
+		defer replayer.mutex.Unlock()
+
+		if replayer.done() {
+			return time.Now()
+		}
+		return replayer.document.Actions[replayer.nextAction].Timestamp
+	}
+
+	return replayer, nil
+}
+
+// Replayer applies the actions of a recorded game, one at a time.
+// The replayer and its game can be read by several goroutines, but only one may
+// call Step.
+type replayer struct {
+	game     *game
+	document replayDocument
+	// Held while the next action is read or changed. It is not held while the
+	// action is applied, since the game's clock reads the next action
+	mutex sync.Mutex
+	// The index of the next action to apply
+	nextAction int
+}
+
+/*
+Game returns the game the actions are applied to.
+*/
+func (replayer *replayer) Game() IGame {
+	return replayer.game
+}
+
+/*
+Done returns true if every action was applied.
+*/
+func (replayer *replayer) Done() bool {
+	replayer.mutex.Lock()
+	defer replayer.mutex.Unlock()
+
+	return replayer.done()
+}
+
+/*
+NextDelay returns the time between the previous action and the next action, as
+it happened in the recorded game.
+*/
+func (replayer *replayer) NextDelay() time.Duration {
+	replayer.mutex.Lock()
+	defer replayer.mutex.Unlock()
+
+	if replayer.nextAction == 0 || replayer.done() {
+		return 0
+	}
+
+	return replayer.document.Actions[replayer.nextAction].Timestamp.Sub(
+		replayer.document.Actions[replayer.nextAction-1].Timestamp)
+}
+
+/*
+Now returns the time, in the recorded game, of the last action applied.
+Before the first action the zero Time is returned.
+*/
+func (replayer *replayer) Now() time.Time {
+	replayer.mutex.Lock()
+	defer replayer.mutex.Unlock()
+
+	if replayer.nextAction == 0 {
+		return time.Time{}
+	}
+
+	return replayer.document.Actions[replayer.nextAction-1].Timestamp
+}
+
+/*
+Step applies the next action and returns the indexes of the tiles that changed.
+After the last action the game is checked against the recorded game and an
+error is returned if they do not match.
+*/
+func (replayer *replayer) Step() ([]int, error) {
+	replayer.mutex.Lock()
+	if replayer.done() {
+		replayer.mutex.Unlock()
+		return []int{}, nil
+	}
+	action := replayer.document.Actions[replayer.nextAction]
+	replayer.mutex.Unlock()
+
+	tileIndexes := []int{}
+	var error error
+
+	switch action.Kind {
+	case configs.ActionReveal:
+		tileIndexes, error = replayer.game.RevealTile(action.RowIndex, action.ColIndex)
+	case configs.ActionFlag:
+		tileIndexes = []int{action.RowIndex*replayer.game.numCols + action.ColIndex}
+		error = replayer.game.ToggleFlag(action.RowIndex, action.ColIndex)
+	case configs.ActionProcessAdjacent:
+		tileIndexes, error = replayer.game.ProcessAdjacentTiles(action.RowIndex, action.ColIndex)
+	case configs.ActionUndo:
+		tileIndexes, error = replayer.game.Undo()
+	case configs.ActionRedo:
+		tileIndexes, error = replayer.game.Redo()
+	case configs.ActionHint:
+		_, error = replayer.game.Hint()
+	default:
+		error = unknownReplayActionError{
+			Kind: action.Kind,
+		}
+	}
+	if error != nil {
+		return nil, error
+	}
+
+	replayer.mutex.Lock()
+	replayer.nextAction++
+	done := replayer.done()
+	replayer.mutex.Unlock()
+
+	if done {
+		expected := replayer.document.Result
+		replayer.game.mutex.RLock()
+		actual := replayer.game.result()
+		replayer.game.mutex.RUnlock()
+		if !sameReplayResult(expected, actual) {
+			return tileIndexes, replayMismatchError{
+				Expected: expected,
+				Actual:   actual,
+			}
+		}
+	}
+
+	return tileIndexes, nil
+}
+
+/*
+done returns true if every action was applied.
+The replayer's mutex must be held.
+*/
+func (replayer *replayer) done() bool {
+	return replayer.nextAction >= len(replayer.document.Actions)
+}
+
+/*
+sameReplayResult returns true if both results have the same state and every
+stat.
+The times are compared as instants, since a time read from a document can have
+a different location than the time it was written from.
+*/
+func sameReplayResult(expected replayResult, actual replayResult) bool {
+	if !expected.Stats.StartTime.Equal(actual.Stats.StartTime) ||
+		!expected.Stats.EndTime.Equal(actual.Stats.EndTime) {
+		return false
+	}
+
+	expected.Stats.StartTime, expected.Stats.EndTime = time.Time{}, time.Time{}
+	actual.Stats.StartTime, actual.Stats.EndTime = time.Time{}, time.Time{}
+
+	return reflect.DeepEqual(expected, actual)
+}
@@ -0,0 +1,275 @@
+package game_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type replayTestSuite struct {
+	suite.Suite
+	sut game.IGame
+}
+
+func (suite *replayTestSuite) SetupTest() {
+	suite.sut, _ = game.Generate(game.GameConfig{
+		NumRows:      10,
+		NumCols:      11,
+		NumMines:     20,
+		FlagsEnabled: true,
+		Lives:        2,
+		Seed:         "hello",
+	})
+}
+
+/*
+replayAll applies every action of the SUT's replay and returns the replayed game.
+*/
+func (suite *replayTestSuite) replayAll() (game.IGame, error) {
+	data, err := suite.sut.SaveReplay()
+	require.Nil(suite.T(), err)
+
+	replayer, err := game.NewReplayer(data)
+	require.Nil(suite.T(), err)
+
+	for !replayer.Done() {
+		_, err = replayer.Step()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return replayer.Game(), nil
+}
+
+/*
+requireSameGame requires both games to have the same state, stats and tiles.
+*/
+func (suite *replayTestSuite) requireSameGame(expected game.IGame, actual game.IGame) {
+	require.Equal(suite.T(), expected.State(), actual.State())
+
+	expectedStats := expected.Stats()
+	actualStats := actual.Stats()
+	require.Equal(suite.T(), true, expectedStats.StartTime.Equal(actualStats.StartTime))
+	require.Equal(suite.T(), true, expectedStats.EndTime.Equal(actualStats.EndTime))
+	expectedStats.StartTime, expectedStats.EndTime = time.Time{}, time.Time{}
+	actualStats.StartTime, actualStats.EndTime = time.Time{}, time.Time{}
+	require.Equal(suite.T(), expectedStats, actualStats)
+
+	config := expected.Config()
+	for rIndex := 0; rIndex < config.NumRows; rIndex++ {
+		for cIndex := 0; cIndex < config.NumCols; cIndex++ {
+			expectedTile, _ := expected.Tile(rIndex, cIndex)
+			actualTile, _ := actual.Tile(rIndex, cIndex)
+
+			require.Equalf(suite.T(), expectedTile.Revealed(), actualTile.Revealed(), "row index: %v | col index: %v", rIndex, cIndex)
+			require.Equalf(suite.T(), expectedTile.HasFlag(), actualTile.HasFlag(), "row index: %v | col index: %v", rIndex, cIndex)
+		}
+	}
+}
+
+func (suite *replayTestSuite) TestReplayReproducesAGameThatEndedInALoss() {
+	suite.sut.RevealTile(4, 6)
+	suite.sut.ToggleFlag(3, 4)
+	suite.sut.ProcessAdjacentTiles(3, 3)
+	suite.sut.Hint()
+	suite.sut.RevealTile(2, 0)
+	suite.sut.RevealTile(0, 5)
+
+	actual, err := suite.replayAll()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), configs.StateLoss, actual.State())
+	suite.requireSameGame(suite.sut, actual)
+}
+
+func (suite *replayTestSuite) TestReplayReproducesTheUndoneAndRedoneActions() {
+	suite.sut.RevealTile(2, 0)
+	suite.sut.RevealTile(0, 5)
+	suite.sut.Undo()
+	suite.sut.ToggleFlag(0, 5)
+	suite.sut.Undo()
+	suite.sut.Redo()
+	suite.sut.Undo()
+	suite.sut.Undo()
+
+	actual, err := suite.replayAll()
+
+	require.Nil(suite.T(), err)
+	suite.requireSameGame(suite.sut, actual)
+}
+
+func (suite *replayTestSuite) TestReplayReproducesANoGuessGameWithASafeStart() {
+	suite.sut, _ = game.Generate(game.GameConfig{
+		NumRows:   16,
+		NumCols:   16,
+		NumMines:  40,
+		Lives:     1,
+		SafeStart: configs.SafeStartArea,
+		NoGuess:   true,
+	})
+	suite.sut.RevealTile(8, 8)
+	for suite.sut.State() == configs.StateOnGoing {
+		hint, _ := suite.sut.Hint()
+		suite.sut.RevealTile(hint.TileIndex/16, hint.TileIndex%16)
+	}
+
+	actual, err := suite.replayAll()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), configs.StateWin, actual.State())
+	suite.requireSameGame(suite.sut, actual)
+}
+
+func (suite *replayTestSuite) TestReplayReproducesAGameResumedFromASave() {
+	suite.sut.RevealTile(4, 6)
+	data, _ := suite.sut.Save()
+	suite.sut, _ = game.Load(data)
+	suite.sut.ToggleFlag(3, 4)
+	suite.sut.Undo()
+	suite.sut.RevealTile(2, 0)
+
+	actual, err := suite.replayAll()
+
+	require.Nil(suite.T(), err)
+	suite.requireSameGame(suite.sut, actual)
+}
+
+func (suite *replayTestSuite) TestStepReturnsTheIndexesOfTheTilesThatChanged() {
+	suite.sut.ToggleFlag(3, 4)
+	data, _ := suite.sut.SaveReplay()
+	replayer, _ := game.NewReplayer(data)
+
+	actual, err := replayer.Step()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{3*11 + 4}, actual)
+	require.Equal(suite.T(), true, replayer.Done())
+}
+
+func (suite *replayTestSuite) TestNextDelayReturnsTheTimeBetweenTheRecordedActions() {
+	suite.sut.ToggleFlag(3, 4)
+	suite.sut.ToggleFlag(3, 4)
+	data, _ := suite.sut.SaveReplay()
+
+	document := map[string]interface{}{}
+	json.Unmarshal(data, &document)
+	actions := document["Actions"].([]interface{})
+	firstTs, _ := time.Parse(time.RFC3339Nano, actions[0].(map[string]interface{})["Timestamp"].(string))
+	actions[1].(map[string]interface{})["Timestamp"] = firstTs.Add(1500 * time.Millisecond)
+	data, _ = json.Marshal(document)
+	replayer, _ := game.NewReplayer(data)
+
+	require.Equal(suite.T(), time.Duration(0), replayer.NextDelay())
+	replayer.Step()
+	require.Equal(suite.T(), 1500*time.Millisecond, replayer.NextDelay())
+}
+
+func (suite *replayTestSuite) TestNowReturnsTheTimeOfTheLastAppliedAction() {
+	suite.sut.RevealTile(4, 6)
+	data, _ := suite.sut.SaveReplay()
+	replayer, _ := game.NewReplayer(data)
+
+	require.Equal(suite.T(), true, replayer.Now().IsZero())
+	replayer.Step()
+	require.Equal(suite.T(), true, suite.sut.StartTime().Equal(replayer.Now()))
+}
+
+func (suite *replayTestSuite) TestNowCanBeReadWhileTheActionsAreApplied() {
+	for flag := 0; flag < 50; flag++ {
+		suite.sut.ToggleFlag(3, 4)
+	}
+	suite.sut.RevealTile(4, 6)
+	data, _ := suite.sut.SaveReplay()
+	replayer, _ := game.NewReplayer(data)
+
+	// Reads the replayer like the replay screen's clock, while the actions are
+	// applied, so the race detector can check the reads against the steps
+	stop := make(chan struct{})
+	readerStarted := make(chan struct{})
+	readerDone := make(chan struct{})
+	go func() {
+		defer close(readerDone)
+		close(readerStarted)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+
+			replayer.Now()
+			replayer.NextDelay()
+		}
+	}()
+	<-readerStarted
+
+	for !replayer.Done() {
+		_, err := replayer.Step()
+		require.Nil(suite.T(), err)
+	}
+	close(stop)
+	<-readerDone
+
+	require.Equal(suite.T(), true, suite.sut.StartTime().Equal(replayer.Now()))
+}
+
+func (suite *replayTestSuite) TestStepReturnsAnErrorIfTheReplayDoesNotReproduceTheRecordedGame() {
+	suite.sut.RevealTile(2, 0)
+	data, _ := suite.sut.SaveReplay()
+
+	document := map[string]interface{}{}
+	json.Unmarshal(data, &document)
+	document["Result"].(map[string]interface{})["Stats"].(map[string]interface{})["RemainingLives"] = 2
+	data, _ = json.Marshal(document)
+	replayer, _ := game.NewReplayer(data)
+
+	_, err := replayer.Step()
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *replayTestSuite) TestStepReturnsAnErrorIfTheReplayDoesNotReproduceTheClicksOfTheRecordedGame() {
+	suite.sut.RevealTile(2, 0)
+	data, _ := suite.sut.SaveReplay()
+
+	document := map[string]interface{}{}
+	json.Unmarshal(data, &document)
+	document["Result"].(map[string]interface{})["Stats"].(map[string]interface{})["WastedClicks"] = 3
+	data, _ = json.Marshal(document)
+	replayer, _ := game.NewReplayer(data)
+
+	_, err := replayer.Step()
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *replayTestSuite) TestStepReturnsAnErrorIfTheActionKindIsNotKnown() {
+	suite.sut.RevealTile(2, 0)
+	data, _ := suite.sut.SaveReplay()
+
+	document := map[string]interface{}{}
+	json.Unmarshal(data, &document)
+	document["Actions"].([]interface{})[0].(map[string]interface{})["Kind"] = 999
+	data, _ = json.Marshal(document)
+	replayer, _ := game.NewReplayer(data)
+
+	_, err := replayer.Step()
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *replayTestSuite) TestNewReplayerReturnsAnErrorIfTheVersionIsNotSupported() {
+	_, err := game.NewReplayer([]byte(`{"Version": 999}`))
+
+	require.NotNil(suite.T(), err)
+}
+
+func TestReplaySuite(t *testing.T) {
+	suite.Run(t, new(replayTestSuite))
+}
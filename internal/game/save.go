@@ -0,0 +1,115 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
+)
+
+// The version of the save document written by Save
+const saveVersion int = 1
+
+// Error: The save document was written by an unsupported version
+type unsupportedSaveVersionError struct {
+	Version int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e unsupportedSaveVersionError) Error() string {
+	return fmt.Sprintf("The save version '%v' is not supported", e.Version)
+}
+
+// SaveDocument contains everything needed to rebuild a game
+type saveDocument struct {
+	Version   int
+	Config    GameConfig
+	Minefield minefield.Snapshot
+	// Informative only, the lives used are restored from the revealed mines
+	LivesUsed int
+	HintsUsed int
+	UndosUsed int
+	// The clicks that changed tiles and the ones that did not
+	EffectiveClicks int
+	WastedClicks    int
+	// Zero if the game has not started or ended when it was saved
+	StartTime time.Time
+	EndTime   time.Time
+	SavedAt   time.Time
+	// The actions processed by the game, to replay it
+	Actions []replayAction
+}
+
+/*
+Save serializes the game into a versioned JSON document that can be given to
+Load to rebuild the game.
+*/
+func (game *game) Save() ([]byte, error) {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+
+	return json.Marshal(saveDocument{
+		Version:         saveVersion,
+		Config:          game.gameConfig,
+		Minefield:       game.minefield.Snapshot(),
+		LivesUsed:       game.minefield.Stats().NumMinesRevealed,
+		HintsUsed:       game.hintsUsed,
+		UndosUsed:       game.undosUsed,
+		EffectiveClicks: game.effectiveClicks,
+		WastedClicks:    game.wastedClicks,
+		StartTime:       game.startTs,
+		EndTime:         game.endTs,
+		SavedAt:         game.now(),
+		Actions:         game.replayActions,
+	})
+}
+
+/*
+Load rebuilds a game from a document created by Save.
+The game clock resumes from the saved elapsed time, by moving all the saved
+times forward by the time the game was saved for.
+*/
+func Load(data []byte) (IGame, error) {
+	document := saveDocument{}
+	error := json.Unmarshal(data, &document)
+	if error != nil {
+		return nil, error
+	}
+	if document.Version != saveVersion {
+		return nil, unsupportedSaveVersionError{
+			Version: document.Version,
+		}
+	}
+
+	gameInterface, error := Generate(document.Config)
+	if error != nil {
+		return nil, error
+	}
+	game := gameInterface.(*game)
+
+	error = game.minefield.Restore(document.Minefield)
+	if error != nil {
+		return nil, error
+	}
+
+	offset := game.now().Sub(document.SavedAt)
+	game.hintsUsed = document.HintsUsed
+	game.undosUsed = document.UndosUsed
+	game.effectiveClicks = document.EffectiveClicks
+	game.wastedClicks = document.WastedClicks
+	if !document.StartTime.IsZero() {
+		game.startTs = document.StartTime.Add(offset)
+	}
+	if !document.EndTime.IsZero() {
+		game.endTs = document.EndTime.Add(offset)
+	}
+	for _, action := range document.Actions {
+		action.Timestamp = action.Timestamp.Add(offset)
+		game.replayActions = append(game.replayActions, action)
+	}
+
+	return game, nil
+}
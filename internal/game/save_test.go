@@ -0,0 +1,188 @@
+package game_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type saveTestSuite struct {
+	suite.Suite
+	sutArgs game.GameConfig
+}
+
+func (suite *saveTestSuite) SetupTest() {
+	suite.sutArgs = game.GameConfig{
+		NumRows:      10,
+		NumCols:      11,
+		NumMines:     20,
+		FlagsEnabled: true,
+		Lives:        2,
+		Seed:         "hello",
+	}
+}
+
+/*
+requireSameTiles requires every tile of both games to have the same content.
+*/
+func (suite *saveTestSuite) requireSameTiles(expected game.IGame, actual game.IGame) {
+	config := expected.Config()
+	for rIndex := 0; rIndex < config.NumRows; rIndex++ {
+		for cIndex := 0; cIndex < config.NumCols; cIndex++ {
+			expectedTile, _ := expected.Tile(rIndex, cIndex)
+			actualTile, err := actual.Tile(rIndex, cIndex)
+
+			require.Nil(suite.T(), err)
+			require.Equalf(suite.T(), expectedTile.Revealed(), actualTile.Revealed(), "row index: %v | col index: %v", rIndex, cIndex)
+			require.Equalf(suite.T(), expectedTile.HasFlag(), actualTile.HasFlag(), "row index: %v | col index: %v", rIndex, cIndex)
+			require.Equalf(suite.T(), expectedTile.HasMine(), actualTile.HasMine(), "row index: %v | col index: %v", rIndex, cIndex)
+			require.Equalf(suite.T(), expectedTile.AdjacentMines(), actualTile.AdjacentMines(), "row index: %v | col index: %v", rIndex, cIndex)
+		}
+	}
+}
+
+func (suite *saveTestSuite) TestLoadRebuildsTheTilesOfTheSavedGame() {
+	sut, _ := game.Generate(suite.sutArgs)
+	sut.RevealTile(0, 0)
+	sut.RevealTile(2, 0)
+	sut.ToggleFlag(3, 4)
+
+	data, err := sut.Save()
+	require.Nil(suite.T(), err)
+	actual, err := game.Load(data)
+
+	require.Nil(suite.T(), err)
+	suite.requireSameTiles(sut, actual)
+	require.Equal(suite.T(), sut.State(), actual.State())
+	require.Equal(suite.T(), sut.Stats().RemainingLives, actual.Stats().RemainingLives)
+	require.Equal(suite.T(), sut.Stats().RemainingMines, actual.Stats().RemainingMines)
+}
+
+func (suite *saveTestSuite) TestLoadKeepsTheClicksOfTheSavedGame() {
+	sut, _ := game.Generate(suite.sutArgs)
+	sut.RevealTile(0, 0)
+	sut.RevealTile(0, 0)
+	sut.ToggleFlag(3, 4)
+
+	data, _ := sut.Save()
+	actual, err := game.Load(data)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), sut.Stats().EffectiveClicks, actual.Stats().EffectiveClicks)
+	require.Equal(suite.T(), sut.Stats().WastedClicks, actual.Stats().WastedClicks)
+}
+
+func (suite *saveTestSuite) TestLoadRebuildsAGameWithADeferredSafeStart() {
+	suite.sutArgs.SafeStart = configs.SafeStartArea
+	suite.sutArgs.Seed = ""
+	sut, _ := game.Generate(suite.sutArgs)
+	sut.RevealTile(4, 6)
+
+	data, err := sut.Save()
+	require.Nil(suite.T(), err)
+	actual, err := game.Load(data)
+
+	require.Nil(suite.T(), err)
+	suite.requireSameTiles(sut, actual)
+}
+
+func (suite *saveTestSuite) TestLoadRebuildsAGameThatHasNotStarted() {
+	sut, _ := game.Generate(suite.sutArgs)
+
+	data, err := sut.Save()
+	require.Nil(suite.T(), err)
+	actual, err := game.Load(data)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), true, actual.StartTime().IsZero())
+	require.Equal(suite.T(), configs.StateOnGoing, actual.State())
+}
+
+func (suite *saveTestSuite) TestLoadResumesTheClockFromTheElapsedTime() {
+	sut, _ := game.Generate(suite.sutArgs)
+	sut.RevealTile(0, 0)
+
+	data, _ := sut.Save()
+	document := map[string]interface{}{}
+	json.Unmarshal(data, &document)
+	savedAt, _ := time.Parse(time.RFC3339Nano, document["SavedAt"].(string))
+	document["StartTime"] = savedAt.Add(-90 * time.Second)
+	data, _ = json.Marshal(document)
+
+	actual, err := game.Load(data)
+
+	require.Nil(suite.T(), err)
+	require.InDelta(suite.T(), 90.0, time.Since(actual.StartTime()).Seconds(), 1)
+	require.Equal(suite.T(), true, actual.Stats().EndTime.IsZero())
+}
+
+func (suite *saveTestSuite) TestLoadKeepsTheHintsUsed() {
+	sut, _ := game.Generate(suite.sutArgs)
+	sut.Hint()
+
+	data, _ := sut.Save()
+	actual, err := game.Load(data)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), 1, actual.Stats().HintsUsed)
+}
+
+func (suite *saveTestSuite) TestLoadKeepsTheUndosUsed() {
+	sut, _ := game.Generate(suite.sutArgs)
+	sut.RevealTile(2, 0)
+	sut.Undo()
+
+	data, _ := sut.Save()
+	actual, err := game.Load(data)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), 1, actual.Stats().UndosUsed)
+}
+
+func (suite *saveTestSuite) TestLoadKeepsAnEndedGameInTheEndState() {
+	sut, _ := game.Generate(suite.sutArgs)
+	sut.RevealTile(2, 0)
+	sut.RevealTile(0, 5)
+
+	data, _ := sut.Save()
+	actual, err := game.Load(data)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), configs.StateLoss, actual.State())
+	require.Equal(suite.T(), false, actual.Stats().EndTime.IsZero())
+}
+
+func (suite *saveTestSuite) TestLoadReturnsAnErrorIfTheVersionIsNotSupported() {
+	_, err := game.Load([]byte(`{"Version": 999}`))
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *saveTestSuite) TestLoadReturnsAnErrorIfTheDocumentIsNotValidJSON() {
+	_, err := game.Load([]byte(`not json`))
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *saveTestSuite) TestLoadKeepsTheWrapAroundEdgesOfTheSavedGame() {
+	suite.sutArgs.Topology = configs.TopologyTorus
+	sut, _ := game.Generate(suite.sutArgs)
+	sut.RevealTile(0, 0)
+
+	data, err := sut.Save()
+	require.Nil(suite.T(), err)
+	actual, err := game.Load(data)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), configs.TopologyTorus, actual.GameConfig().Topology)
+	suite.requireSameTiles(sut, actual)
+}
+
+func TestSaveSuite(t *testing.T) {
+	suite.Run(t, new(saveTestSuite))
+}
@@ -0,0 +1,110 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The first part of a share code, with the version of its format
+const shareCodePrefix string = "MS1"
+
+// The number of parts of a share code, separated by dashes
+const numShareCodeParts int = 7
+
+// Error: The text is not a share code
+type invalidShareCodeError struct {
+	Code string
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e invalidShareCodeError) Error() string {
+	return fmt.Sprintf("The share code '%v' is not valid", e.Code)
+}
+
+/*
+ShareCode returns a text that describes the board of the configuration, so the
+same board can be played by someone else.
+The code has the size, with the layers of a layered board, mines, topology,
+first click and no guess options and the seed, but not the lives, flags, mine
+layout or masked tiles.
+*/
+func ShareCode(args GameConfig) string {
+	noGuess := 0
+	if args.NoGuess {
+		noGuess = 1
+	}
+
+	size := fmt.Sprintf("%vx%v", args.NumRows, args.NumCols)
+	if args.NumLayers > 1 {
+		size = fmt.Sprintf("%vx%v", args.NumLayers, size)
+	}
+
+	return fmt.Sprintf("%v-%v-%v-%v-%v-%v-%v", shareCodePrefix, size,
+		args.NumMines, args.Topology, args.SafeStart, noGuess, args.Seed)
+}
+
+/*
+ParseShareCode returns the configuration of the board described by a share
+code, with its lives and flags left at their zero values.
+Returns an error if the text is not a share code or its board is not valid.
+*/
+func ParseShareCode(code string) (GameConfig, error) {
+	invalidError := invalidShareCodeError{
+		Code: code,
+	}
+
+	// The seed is the last part and may contain dashes
+	parts := strings.SplitN(strings.TrimSpace(code), "-", numShareCodeParts)
+	if len(parts) != numShareCodeParts || parts[0] != shareCodePrefix || parts[6] == "" {
+		return GameConfig{}, invalidError
+	}
+
+	// The size of a layered board starts with its number of layers
+	size := strings.Split(parts[1], "x")
+	numLayers := "1"
+	if len(size) == 3 {
+		numLayers, size = size[0], size[1:]
+	}
+	if len(size) != 2 {
+		return GameConfig{}, invalidError
+	}
+
+	numbers := []string{size[0], size[1], parts[2], parts[3], parts[4], parts[5], numLayers}
+	values := make([]int, len(numbers))
+	for index, number := range numbers {
+		value, error := strconv.Atoi(number)
+		if error != nil {
+			return GameConfig{}, invalidError
+		}
+		values[index] = value
+	}
+	if values[5] != 0 && values[5] != 1 {
+		return GameConfig{}, invalidError
+	}
+
+	args := GameConfig{
+		NumRows:   values[0],
+		NumCols:   values[1],
+		NumMines:  values[2],
+		Topology:  values[3],
+		SafeStart: values[4],
+		NoGuess:   values[5] == 1,
+		Seed:      parts[6],
+	}
+	if values[6] != 1 {
+		args.NumLayers = values[6]
+	}
+
+	// The lives are not part of the share code, so the board is checked with one life
+	validationArgs := args
+	validationArgs.Lives = 1
+	error := Validate(validationArgs)
+	if error != nil {
+		return GameConfig{}, error
+	}
+
+	return args, nil
+}
@@ -0,0 +1,104 @@
+package game_test
+
+import (
+	"testing"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type shareTestSuite struct {
+	suite.Suite
+}
+
+func (suite *shareTestSuite) TestShareCodeDescribesTheBoardOfTheConfiguration() {
+	code := game.ShareCode(game.GameConfig{
+		NumRows:   16,
+		NumCols:   30,
+		NumMines:  99,
+		Lives:     3,
+		Topology:  configs.TopologyTorus,
+		SafeStart: configs.SafeStartArea,
+		NoGuess:   true,
+		Seed:      "hello",
+	})
+
+	require.Equal(suite.T(), "MS1-16x30-99-2-2-1-hello", code)
+}
+
+func (suite *shareTestSuite) TestParseShareCodeReturnsTheBoardOfTheSharedConfiguration() {
+	config := game.GameConfig{
+		NumRows:   9,
+		NumCols:   9,
+		NumMines:  10,
+		Topology:  configs.TopologyTorus,
+		SafeStart: configs.SafeStartTile,
+		Seed:      "a-seed-with-dashes",
+	}
+
+	actual, err := game.ParseShareCode(game.ShareCode(config))
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), config, actual)
+}
+
+func (suite *shareTestSuite) TestShareCodeStartsTheSizeOfALayeredBoardWithItsLayers() {
+	config := game.GameConfig{
+		NumLayers: 3,
+		NumRows:   4,
+		NumCols:   5,
+		NumMines:  6,
+		Seed:      "hello",
+	}
+
+	code := game.ShareCode(config)
+	actual, err := game.ParseShareCode(code)
+
+	require.Equal(suite.T(), "MS1-3x4x5-6-0-0-0-hello", code)
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), config, actual)
+}
+
+func (suite *shareTestSuite) TestParseShareCodeGeneratesTheSameBoard() {
+	config := game.GameConfig{
+		NumRows:  9,
+		NumCols:  9,
+		NumMines: 10,
+		Lives:    1,
+		Topology: configs.TopologyTorus,
+		Seed:     "hello",
+	}
+	original, _ := game.Generate(config)
+
+	shared, err := game.ParseShareCode(game.ShareCode(config))
+	require.Nil(suite.T(), err)
+	shared.Lives = 1
+	sut, err := game.Generate(shared)
+	require.Nil(suite.T(), err)
+
+	for tileIndex := 0; tileIndex < 81; tileIndex++ {
+		expected, _ := original.Tile(tileIndex/9, tileIndex%9)
+		actual, _ := sut.Tile(tileIndex/9, tileIndex%9)
+		require.Equal(suite.T(), expected, actual)
+	}
+}
+
+func (suite *shareTestSuite) TestParseShareCodeReturnsAnErrorIfTheTextIsNotAShareCode() {
+	for _, code := range []string{"hello", "MS1-9x9-10-0-0-0-", "MS2-9x9-10-0-0-0-hello", "MS1-9-10-0-0-0-hello", "MS1-9x9-ten-0-0-0-hello", "MS1-9x9-10-0-0-2-hello"} {
+		_, err := game.ParseShareCode(code)
+
+		require.EqualErrorf(suite.T(), err, "The share code '"+code+"' is not valid", "code: %v", code)
+	}
+}
+
+func (suite *shareTestSuite) TestParseShareCodeReturnsAnErrorIfTheBoardIsNotValid() {
+	_, err := game.ParseShareCode("MS1-3x3-9-0-0-0-hello")
+
+	require.EqualError(suite.T(), err, "The number of mines '9' is not valid, it must be between '0' and '5'")
+}
+
+func TestShareSuite(t *testing.T) {
+	suite.Run(t, new(shareTestSuite))
+}
@@ -0,0 +1,232 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
+)
+
+// The maximum number of tiles of a game
+const maxTiles int = 10000
+
+// Error: The board of the configuration is empty or too large
+type invalidBoardSizeError struct {
+	NumRows  int
+	NumCols  int
+	MaxTiles int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e invalidBoardSizeError) Error() string {
+	return fmt.Sprintf(
+		"The board with '%v' rows and '%v' columns is not valid, it must have at least one tile and at most '%v' tiles",
+		e.NumRows, e.NumCols, e.MaxTiles)
+}
+
+// Error: The number of layers of the configuration is negative
+type invalidNumLayersError struct {
+	NumLayers int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e invalidNumLayersError) Error() string {
+	return fmt.Sprintf("The number of layers '%v' is not valid, it can not be negative", e.NumLayers)
+}
+
+// Error: A game needs at least one life
+type invalidLivesError struct {
+	Lives int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e invalidLivesError) Error() string {
+	return fmt.Sprintf("The number of lives '%v' is not valid, it must be at least '1'", e.Lives)
+}
+
+// Error: Only boards of square tiles can have layers
+type layeredTopologyError struct {
+	Topology int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e layeredTopologyError) Error() string {
+	return fmt.Sprintf("The boards with more than one layer must have square tiles, not the topology '%v'", e.Topology)
+}
+
+// Error: A masked tile is outside the board, or the mask removes every tile
+type invalidMaskError struct {
+	NumRows int
+	NumCols int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e invalidMaskError) Error() string {
+	return fmt.Sprintf(
+		"The mask is not valid for the board with '%v' rows and '%v' columns, its cells must be on the board and leave at least one tile",
+		e.NumRows, e.NumCols)
+}
+
+// Error: The board of the configuration can not hold its mines
+type invalidNumMinesError struct {
+	NumMines int
+	MaxMines int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e invalidNumMinesError) Error() string {
+	return fmt.Sprintf("The number of mines '%v' is not valid, it must be between '0' and '%v'",
+		e.NumMines, e.MaxMines)
+}
+
+/*
+Validate returns an error if a game can not be generated with the provided
+configuration.
+The board must have between 1 and 10000 tiles, across its layers, the topology
+must be one of the configs.Topology* constants, or square tiles if the board has
+layers, the masked tiles must be on the board and leave at least one tile and
+the board must leave at least one tile without a mine, or the whole first click
+area if a safe area is requested, and the game must have at least one life.
+*/
+func Validate(args GameConfig) error {
+	if args.NumLayers < 0 {
+		return invalidNumLayersError{
+			NumLayers: args.NumLayers,
+		}
+	}
+
+	numTiles := numLayers(args) * args.NumRows * args.NumCols
+	if args.NumRows <= 0 || args.NumCols <= 0 || numTiles > maxTiles {
+		return invalidBoardSizeError{
+			NumRows:  args.NumRows,
+			NumCols:  args.NumCols,
+			MaxTiles: maxTiles,
+		}
+	}
+
+	if numLayers(args) > 1 && args.Topology != configs.TopologySquare {
+		return layeredTopologyError{
+			Topology: args.Topology,
+		}
+	}
+
+	_, error := minefield.NewTopology(args.Topology, args.NumRows, args.NumCols)
+	if error != nil {
+		return error
+	}
+
+	if args.MaskedTiles != nil {
+		masked := make(map[int]bool, len(args.MaskedTiles))
+		for _, tileIndex := range args.MaskedTiles {
+			if tileIndex < 0 || tileIndex > numTiles-1 {
+				return invalidMaskError{
+					NumRows: args.NumRows,
+					NumCols: args.NumCols,
+				}
+			}
+			masked[tileIndex] = true
+		}
+		if len(masked) == numTiles {
+			return invalidMaskError{
+				NumRows: args.NumRows,
+				NumCols: args.NumCols,
+			}
+		}
+	}
+
+	maxMines := MaxMines(args)
+	if args.NumMines < 0 || args.NumMines > maxMines {
+		return invalidNumMinesError{
+			NumMines: args.NumMines,
+			MaxMines: maxMines,
+		}
+	}
+
+	if args.Lives <= 0 {
+		return invalidLivesError{
+			Lives: args.Lives,
+		}
+	}
+
+	return nil
+}
+
+/*
+MaxMines returns the maximum number of mines the board of the configuration can
+hold, keeping enough tiles without a mine for the first click.
+A safe area needs room for the tile with the most adjacent tiles in the
+configuration's topology, the initial patch revealed when there is no safe start
+needs room for the tile with the fewest, and a safe tile and boards with a mine
+layout only need one tile without a mine.
+The masked tiles are not counted.
+*/
+func MaxMines(args GameConfig) int {
+	numSafeTiles := 1
+	numRows := numLayers(args) * args.NumRows
+	if args.NumRows <= 0 || args.NumCols <= 0 {
+		return numRows*args.NumCols - numSafeTiles
+	}
+
+	topology, error := minefield.NewTopology(args.Topology, args.NumRows, args.NumCols)
+	if error != nil {
+		return numRows*args.NumCols - numSafeTiles
+	}
+	if numLayers(args) > 1 {
+		topology = minefield.NewLayeredTopology(numLayers(args), args.NumRows, args.NumCols)
+	}
+	if args.MaskedTiles != nil {
+		topology = minefield.NewMaskedTopology(topology, args.MaskedTiles)
+	}
+
+	// The fewest tiles without a mine the initial patch needs, if it is revealed
+	numPatchTiles := 0
+	numTiles := 0
+	for rowIndex := 0; rowIndex < numRows; rowIndex++ {
+		for colIndex := 0; colIndex < args.NumCols; colIndex++ {
+			tileIndex, ok := topology.TileIndex(rowIndex, colIndex)
+			if !ok {
+				continue
+			}
+			numTiles++
+
+			numAdjacent := len(topology.AdjacentTiles(tileIndex))
+			if args.SafeStart == configs.SafeStartArea && args.MineTiles == nil && numAdjacent+1 > numSafeTiles {
+				numSafeTiles = numAdjacent + 1
+			}
+			if args.SafeStart == configs.SafeStartNone && args.MineTiles == nil &&
+				(numPatchTiles == 0 || numAdjacent+1 < numPatchTiles) {
+				numPatchTiles = numAdjacent + 1
+			}
+		}
+	}
+
+	if numPatchTiles > numSafeTiles {
+		numSafeTiles = numPatchTiles
+	}
+
+	return numTiles - numSafeTiles
+}
+
+/*
+numLayers returns the number of layers of the configuration's board, which is 1
+for a flat board.
+*/
+func numLayers(args GameConfig) int {
+	if args.NumLayers < 1 {
+		return 1
+	}
+
+	return args.NumLayers
+}
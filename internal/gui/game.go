@@ -7,6 +7,7 @@ import (
 
 	"github.com/pedrohenriques/go-minesweeper/internal/configs"
 	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -17,112 +18,212 @@ import (
 
 /*
 createGameGui generates the CanvasObject for the game screen.
+The screen's background work runs until stop is closed.
 */
-func createGameGui(game game.IGame, new func(), reset func(), onGameEnd func(state int)) fyne.CanvasObject {
-	navContainer := buildNavContainer(new, reset)
-	statsContainer, statsDataBinds := buildStatsContainer(game)
-	boardContainer := buildBoardContainer(game, statsDataBinds, onGameEnd)
+func createGameGui(windowCanvas fyne.Canvas, game game.IGame, stop <-chan struct{}, new func(), reset func(), onGameEnd func(state int)) fyne.CanvasObject {
+	statsContainer, statsDataBinds := buildStatsContainer(game, time.Now, stop)
+	boardContainer, tileWidgets := buildBoardContainer(game, statsDataBinds, onGameEnd, false, stop)
+	navContainer := buildNavContainer(new, reset, hintHandler(game, tileWidgets))
+
+	undoHandler := historyHandler(game.Undo)
+	redoHandler := historyHandler(game.Redo)
+	windowCanvas.AddShortcut(undoShortcut, func(_ fyne.Shortcut) { undoHandler() })
+	windowCanvas.AddShortcut(redoShortcut, func(_ fyne.Shortcut) { redoHandler() })
 
 	return container.NewVBox(navContainer, statsContainer, boardContainer)
 }
 
 /*
-clickHandler will call the Game's functionality to action the clicked tile, based on the type of click,
-and will call all affected tile widgets to update their rendering.
+clickHandler will call the Game's functionality to action the clicked tile,
+based on the type of click.
+The tile widgets are updated by the game's events.
 
 clickType: 0 = primary | 1 = secondary | 2 = both
 */
-func clickHandler(game game.IGame, tileWidgets *[]ITileWidget, clickType int, statsDataBinds *statsDataBinds, onGameEnd func(state int)) func(int, int) {
+func clickHandler(game game.IGame, clickType int) func(int, int) {
 	return func(rowIndex int, colIndex int) {
 		if game.State() != configs.StateOnGoing {
 			return
 		}
 
-		var tileIndexes []int
 		var err error
 
 		switch clickType {
 		case configs.PrimaryClick:
-			tileIndexes, err = game.RevealTile(rowIndex, colIndex)
+			_, err = game.RevealTile(rowIndex, colIndex)
 		case configs.SecondaryClick:
-			gameConfig := game.Config()
-
-			tileIndexes = []int{rowIndex*gameConfig.NumCols + colIndex}
 			err = game.ToggleFlag(rowIndex, colIndex)
 		case configs.BothClick:
-			tileIndexes, err = game.ProcessAdjacentTiles(rowIndex, colIndex)
+			_, err = game.ProcessAdjacentTiles(rowIndex, colIndex)
 		}
 
 		if err != nil {
 			log.Println(err)
 		}
+	}
+}
 
-		if game.State() != configs.StateOnGoing {
-			tileIndexes = make([]int, game.Config().NumRows*game.Config().NumCols)
-			for tileIndex := 0; tileIndex < game.Config().NumRows*game.Config().NumCols; tileIndex++ {
-				tileIndexes = append(tileIndexes, tileIndex)
-			}
-			onGameEnd(game.State())
+/*
+historyHandler will call the provided Game's undo or redo functionality.
+The tile widgets are updated by the game's events.
+*/
+func historyHandler(move func() ([]int, error)) func() {
+	return func() {
+		_, err := move()
+		if err != nil {
+			log.Println(err)
 		}
+	}
+}
 
-		for _, tIndex := range tileIndexes {
-			(*tileWidgets)[tIndex].updateWidget(game.State() != configs.StateOnGoing)
-		}
+/*
+watchEvents updates the tile widgets and the stats affected by each event of
+the game, until stop is closed or the game's events are closed.
+If the game ended, or is on going again, all the tile widgets are updated.
+*/
+func watchEvents(game game.IGame, tileWidgets *[]ITileWidget, statsDataBinds *statsDataBinds, onGameEnd func(state int), stop <-chan struct{}) {
+	events, cancel := game.Subscribe()
 
-		gameStats := game.Stats()
+	go func() {
+		<-stop
+		cancel()
+	}()
 
-		err = statsDataBinds.livesLeft.Set(fmt.Sprint(gameStats.RemainingLives))
-		if err != nil {
-			log.Println(err)
+	go func() {
+		for event := range events {
+			state := game.State()
+
+			switch event.Kind {
+			case configs.EventTileRevealed, configs.EventTileHidden, configs.EventFlagToggled:
+				for _, tIndex := range event.TileIndexes {
+					(*tileWidgets)[tIndex].updateWidget(state != configs.StateOnGoing)
+				}
+			case configs.EventGameWon, configs.EventGameLost, configs.EventGameResumed:
+				for _, tileWidget := range *tileWidgets {
+					tileWidget.updateWidget(state != configs.StateOnGoing)
+				}
+			}
+
+			updateStats(game, statsDataBinds)
+
+			if event.Kind == configs.EventGameWon || event.Kind == configs.EventGameLost {
+				onGameEnd(state)
+			}
 		}
+	}()
+}
 
-		err = statsDataBinds.minesLeft.Set(fmt.Sprint(gameStats.RemainingMines))
+/*
+updateStats sets the remaining lives and mines of the game on the stats data
+binds.
+*/
+func updateStats(game game.IGame, statsDataBinds *statsDataBinds) {
+	gameStats := game.Stats()
+
+	err := statsDataBinds.livesLeft.Set(fmt.Sprint(gameStats.RemainingLives))
+	if err != nil {
+		log.Println(err)
+	}
+
+	err = statsDataBinds.minesLeft.Set(fmt.Sprint(gameStats.RemainingMines))
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+/*
+hintHandler will ask the Game for a hint and highlight the suggested tile widget.
+*/
+func hintHandler(game game.IGame, tileWidgets *[]ITileWidget) func() {
+	return func() {
+		hint, err := game.Hint()
 		if err != nil {
 			log.Println(err)
+			return
 		}
+		if hint.TileIndex < 0 {
+			return
+		}
+
+		(*tileWidgets)[hint.TileIndex].highlight()
 	}
 }
 
 /*
-buildBoardContainer will create the container with the game statistics.
+buildBoardContainer will create the container with the game's tiles, which are
+hexagons on boards of hexagonal tiles.
+The cells removed by a mask are left as empty space, and the boards with layers
+show one layer at a time.
+If readOnly is true clicking the tiles does nothing.
+The tiles follow the game's events until stop is closed.
 */
-func buildBoardContainer(game game.IGame, statsDataBinds *statsDataBinds, onGameEnd func(state int)) *fyne.Container {
+func buildBoardContainer(game game.IGame, statsDataBinds *statsDataBinds, onGameEnd func(state int), readOnly bool, stop <-chan struct{}) (*fyne.Container, *[]ITileWidget) {
 	gameConfig := game.Config()
 
 	boardContainer := container.NewGridWithColumns(gameConfig.NumCols)
+	newWidget := newTileWidget
+	if game.GameConfig().Topology == configs.TopologyHex {
+		boardContainer = container.New(&hexLayout{cols: gameConfig.NumCols})
+		newWidget = newHexTileWidget
+	}
 	tileWidgets := make([]ITileWidget, gameConfig.NumRows*gameConfig.NumCols)
-
-	primaryHandler := clickHandler(game, &tileWidgets, configs.PrimaryClick, statsDataBinds, onGameEnd)
-	secondaryHandler := clickHandler(game, &tileWidgets, configs.SecondaryClick, statsDataBinds, onGameEnd)
-	bothClickHandler := clickHandler(game, &tileWidgets, configs.BothClick, statsDataBinds, onGameEnd)
+	canvasObjects := make([]fyne.CanvasObject, len(tileWidgets))
+
+	primaryHandler := clickHandler(game, configs.PrimaryClick)
+	secondaryHandler := clickHandler(game, configs.SecondaryClick)
+	bothClickHandler := clickHandler(game, configs.BothClick)
+	if readOnly {
+		primaryHandler = func(int, int) {}
+		secondaryHandler = func(int, int) {}
+		bothClickHandler = func(int, int) {}
+	}
 
 	for rowIndex := 0; rowIndex < gameConfig.NumRows; rowIndex++ {
 		for colIndex := 0; colIndex < gameConfig.NumCols; colIndex++ {
-			tile, _ := game.Tile(rowIndex, colIndex)
+			tileRowIndex, tileColIndex := rowIndex, colIndex
 
 			widgetArgs := newTileWidgetArgs{
-				rowIndex:       rowIndex,
-				colIndex:       colIndex,
-				tile:           tile,
+				rowIndex: rowIndex,
+				colIndex: colIndex,
+				tile: func() minefield.ITile {
+					tile, _ := game.Tile(tileRowIndex, tileColIndex)
+					return tile
+				},
 				primaryClick:   primaryHandler,
 				secondaryClick: secondaryHandler,
 				bothClick:      bothClickHandler,
 			}
 
-			canvasObj, tileWidget := newTileWidget(widgetArgs)
+			newCellWidget := newWidget
+			if _, err := game.Tile(rowIndex, colIndex); err != nil {
+				newCellWidget = newMaskedTileWidget
+			}
+			canvasObj, tileWidget := newCellWidget(widgetArgs)
 
-			boardContainer.Add(canvasObj)
+			canvasObjects[rowIndex*gameConfig.NumCols+colIndex] = canvasObj
 			tileWidgets[rowIndex*gameConfig.NumCols+colIndex] = tileWidget
 		}
 	}
 
-	return boardContainer
+	if gameConfig.NumLayers > 1 {
+		boardContainer = buildLayersContainer(game, canvasObjects, tileWidgets)
+	} else {
+		for _, canvasObj := range canvasObjects {
+			boardContainer.Add(canvasObj)
+		}
+	}
+
+	watchEvents(game, &tileWidgets, statsDataBinds, onGameEnd, stop)
+
+	return boardContainer, &tileWidgets
 }
 
 /*
 buildStatsContainer will create the container with the game statistics.
+The time elapsed is measured with the provided clock, every second until stop
+is closed.
 */
-func buildStatsContainer(game game.IGame) (*fyne.Container, *statsDataBinds) {
+func buildStatsContainer(game game.IGame, now func() time.Time, stop <-chan struct{}) (*fyne.Container, *statsDataBinds) {
 	gameStats := game.Stats()
 
 	statsDataBinds := &statsDataBinds{
@@ -153,12 +254,22 @@ func buildStatsContainer(game game.IGame) (*fyne.Container, *statsDataBinds) {
 
 		var err error
 
-		for timestamp := range ticker.C {
-			if game.State() != configs.StateOnGoing {
+		for {
+			select {
+			case <-stop:
 				return
+			case <-ticker.C:
+			}
+
+			// The game may go back to on going with an undo
+			if game.State() != configs.StateOnGoing {
+				continue
+			}
+			if game.StartTime().IsZero() {
+				continue
 			}
 
-			err = statsDataBinds.timeElapsed.Set(fmt.Sprint(timestamp.Sub(game.StartTime()).Truncate(time.Second)))
+			err = statsDataBinds.timeElapsed.Set(fmt.Sprint(now().Sub(game.StartTime()).Truncate(time.Second)))
 			if err != nil {
 				log.Println(err)
 			}
@@ -188,11 +299,12 @@ func buildStatsContainer(game game.IGame) (*fyne.Container, *statsDataBinds) {
 /*
 buildNavContainer will create the container with the navigation elements.
 */
-func buildNavContainer(new func(), reset func()) *fyne.Container {
+func buildNavContainer(new func(), reset func(), hint func()) *fyne.Container {
 	navContainer := container.NewGridWithRows(1)
 
 	navContainer.Add(widget.NewButton("New Game", new))
 	navContainer.Add(widget.NewButton("Reset Game", reset))
+	navContainer.Add(widget.NewButton("Hint", hint))
 
 	return navContainer
 }
@@ -1,16 +1,67 @@
 package gui
 
 import (
+	"fmt"
+	"io"
+
 	"github.com/pedrohenriques/go-minesweeper/internal/configs"
 	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/pedrohenriques/go-minesweeper/internal/leaderboard"
+	"github.com/pedrohenriques/go-minesweeper/internal/mask"
+	"github.com/pedrohenriques/go-minesweeper/internal/match"
+	"github.com/pedrohenriques/go-minesweeper/internal/presets"
+	"github.com/pedrohenriques/go-minesweeper/internal/statistics"
+	"github.com/pedrohenriques/go-minesweeper/internal/storage"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/widget"
 )
 
+// The name of the file where the game in progress is saved on exit
+const saveFileName string = "last-game.json"
+
+// The name of the file where the replay of the last finished game is saved
+const replayFileName string = "last-replay.json"
+
+// The keyboard shortcuts to undo and redo the player's actions
+var undoShortcut = &desktop.CustomShortcut{KeyName: fyne.KeyZ, Modifier: fyne.KeyModifierShortcutDefault}
+var redoShortcut = &desktop.CustomShortcut{KeyName: fyne.KeyY, Modifier: fyne.KeyModifierShortcutDefault}
+
+// GuiState holds the game being played, shared by the screens and the window
+type guiState struct {
+	gameConfig   game.GameConfig
+	gameInstance game.IGame
+	replayer     game.IReplayer
+	// The client of the race being played, nil if the game is not in a race
+	raceClient match.IClient
+	// Closed to stop following the progress of the race
+	raceStop chan struct{}
+	// Closed when the screen being shown is replaced, to stop its background work
+	screenStop chan struct{}
+	// The records of past wins, nil if they could not be read
+	leaderboard leaderboard.ILeaderboard
+	// The results of past games, nil if they could not be read
+	statistics statistics.IStatistics
+	// The game of the screen being shown, added to the statistics when the
+	// screen is left, so an end that is undone is not recorded
+	screenGame game.IGame
+	// The player's custom difficulties, nil if they could not be read
+	presets presets.IPresets
+}
+
+// GuiEvent is a screen to show, or an other event, sent to processGuiEvent
+type guiEvent struct {
+	name string
+	// Applied to the state before the event is handled, nil if the event does
+	// not change the state
+	update func(state *guiState) error
+}
+
 type statsDataBinds struct {
 	minesLeft   binding.String
 	livesLeft   binding.String
@@ -28,56 +79,478 @@ func Run(configs *configs.Configs) {
 	window := app.NewWindow("Main")
 	window.SetMaster()
 
-	guiChannel := make(chan string, 1)
-	go processGuiEvent(configs, &guiChannel, &window)
+	state := &guiState{}
+	board, error := leaderboard.Load()
+	if error != nil {
+		fmt.Printf("Error loading the leaderboard: %v\n", error)
+	} else {
+		state.leaderboard = board
+	}
+	stats, error := statistics.Load()
+	if error != nil {
+		fmt.Printf("Error loading the statistics: %v\n", error)
+	} else {
+		state.statistics = stats
+	}
+	userPresets, error := presets.Load()
+	if error != nil {
+		fmt.Printf("Error loading the presets: %v\n", error)
+	} else {
+		state.presets = userPresets
+	}
+	guiChannel := make(chan guiEvent, 1)
+	go processGuiEvent(configs, state, &guiChannel, &window)
 
-	guiChannel <- "setup"
+	// The state is only used by processGuiEvent, so it saves the game on close
+	window.SetCloseIntercept(func() {
+		guiChannel <- guiEvent{name: "close"}
+	})
+
+	guiChannel <- guiEvent{name: "setup"}
 
 	window.ShowAndRun()
 }
 
 /*
 processGuiEvent listens for events on the provided channel and handles them.
+It is the only goroutine that uses the state, so the callbacks of the screens
+and the window send the changes to the state with the events instead.
 */
-func processGuiEvent(config *configs.Configs, guiChannel *chan string, window *fyne.Window) {
-	var gameConfig game.GameConfig
-	var gameInstance game.IGame
-
+func processGuiEvent(config *configs.Configs, state *guiState, guiChannel *chan guiEvent, window *fyne.Window) {
 	for event := range *guiChannel {
-		if event == "setup" {
-			(*window).SetContent(createSetupGui(config, func(config game.GameConfig) {
-				gameConfig = config
-				gameInstance = game.Generate(gameConfig)
-				*guiChannel <- "game"
+		if event.update != nil {
+			error := event.update(state)
+			if error != nil {
+				dialog.ShowError(error, *window)
+				continue
+			}
+		}
+
+		if event.name == "end" {
+			// The game of the screen may have been replaced before the event arrived
+			if state.screenGame != nil && state.screenGame.State() != configs.StateOnGoing {
+				saveReplay(state)
+				showGameEndPopup(config, state, *window, state.screenGame.State())
+			}
+			continue
+		}
+
+		leaveScreen(config, state, *window)
+
+		if event.name == "close" {
+			saveGame(state)
+			leaveRace(state)
+			(*window).Close()
+			return
+		}
+
+		if event.name == "setup" {
+			leaveRace(state)
+
+			var resumeGame func()
+			if storage.Exists(saveFileName) {
+				resumeGame = func() {
+					*guiChannel <- guiEvent{name: "game", update: func(state *guiState) error {
+						data, error := storage.Read(saveFileName)
+						if error != nil {
+							return error
+						}
+						gameInstance, error := game.Load(data)
+						if error != nil {
+							return error
+						}
+
+						state.gameInstance = gameInstance
+						state.gameConfig = gameInstance.GameConfig()
+						return nil
+					}}
+				}
+			}
+
+			var watchReplay func()
+			if storage.Exists(replayFileName) {
+				watchReplay = func() {
+					*guiChannel <- guiEvent{name: "replay", update: func(state *guiState) error {
+						data, error := storage.Read(replayFileName)
+						if error != nil {
+							return error
+						}
+						replayer, error := game.NewReplayer(data)
+						if error != nil {
+							return error
+						}
+
+						state.replayer = replayer
+						return nil
+					}}
+				}
+			}
+
+			(*window).SetContent(createSetupGui(config, state.presets, func(config game.GameConfig) {
+				*guiChannel <- guiEvent{name: "game", update: func(state *guiState) error {
+					newGameInstance, error := game.Generate(config)
+					if error != nil {
+						return error
+					}
+
+					state.gameConfig = config
+					state.gameInstance = newGameInstance
+					return nil
+				}}
+			}, resumeGame, watchReplay, func() {
+				importVideo(guiChannel, *window)
+			}, func(callback func(boardMask mask.Mask, name string)) {
+				loadMask(*window, callback)
+			}, func() {
+				showJoinRaceForm(*window, func(client match.IClient) {
+					*guiChannel <- guiEvent{name: "race", update: func(state *guiState) error {
+						state.gameConfig = client.GameConfig()
+						state.gameInstance = client.Game()
+						state.raceClient = client
+						state.raceStop = make(chan struct{})
+						return nil
+					}}
+				})
+			}, func() {
+				// The leaderboard, the statistics and the presets are not replaced
+				// after Run loads them
+				if state.leaderboard == nil {
+					dialog.ShowInformation("Leaderboard", "The leaderboard could not be loaded.", *window)
+					return
+				}
+
+				*guiChannel <- guiEvent{name: "leaderboard"}
+			}, func() {
+				if state.statistics == nil {
+					dialog.ShowInformation("Statistics", "The statistics could not be loaded.", *window)
+					return
+				}
+
+				*guiChannel <- guiEvent{name: "statistics"}
 			}))
-		} else if event == "game" {
-			(*window).SetContent(createGameGui(gameInstance,
+		} else if event.name == "game" {
+			(*window).SetContent(createGameGui((*window).Canvas(), state.gameInstance, state.screenStop,
+				func() {
+					*guiChannel <- guiEvent{name: "setup"}
+				},
 				func() {
-					*guiChannel <- "setup"
+					*guiChannel <- guiEvent{name: "game", update: func(state *guiState) error {
+						newGameInstance, error := game.Generate(state.gameConfig)
+						if error != nil {
+							return error
+						}
+
+						state.gameInstance = newGameInstance
+						return nil
+					}}
 				},
+				func(gameState int) {
+					*guiChannel <- guiEvent{name: "end"}
+				}))
+			state.screenGame = state.gameInstance
+		} else if event.name == "race" {
+			gameGui := createGameGui((*window).Canvas(), state.gameInstance, state.screenStop,
 				func() {
-					gameInstance = game.Generate(gameConfig)
-					*guiChannel <- "game"
+					*guiChannel <- guiEvent{name: "setup"}
 				},
-				func(state int) {
-					var labelText string
-					switch state {
-					case configs.StateWin:
-						labelText = "You have won!"
-					case configs.StateLoss:
-						labelText = "You have lost!"
-					}
+				func() {
+					dialog.ShowInformation("Race", "A race can not be reset.", *window)
+				},
+				func(gameState int) {
+					*guiChannel <- guiEvent{name: "end"}
+				})
+			state.screenGame = state.gameInstance
+			raceClient := state.raceClient
+			raceProgressGui := createRaceProgressGui(raceClient, state.raceStop, func(winner int) {
+				message := fmt.Sprintf("Player %v has won the race!", winner+1)
+				if winner == raceClient.Player() {
+					message = "You have won the race!"
+				}
+				dialog.ShowInformation("Race", message, *window)
+			})
 
-					var popupWidget *widget.PopUp
-					container := container.NewGridWithColumns(1)
-					container.Add(widget.NewLabel(labelText))
-					container.Add(widget.NewButton("Close", func() {
-						popupWidget.Hide()
-					}))
+			(*window).SetContent(container.NewVBox(gameGui, raceProgressGui))
+		} else if event.name == "leaderboard" {
+			(*window).SetContent(createLeaderboardGui(config, state.leaderboard, func() {
+				*guiChannel <- guiEvent{name: "setup"}
+			}))
+		} else if event.name == "statistics" {
+			(*window).SetContent(createStatisticsGui(config, state.statistics, func() {
+				*guiChannel <- guiEvent{name: "setup"}
+			}))
+		} else if event.name == "replay" {
+			(*window).SetContent(createReplayGui(state.replayer, state.screenStop,
+				func() {
+					*guiChannel <- guiEvent{name: "setup"}
+				},
+				func(error error) {
+					if error != nil {
+						dialog.ShowError(error, *window)
+						return
+					}
 
-					popupWidget = widget.NewModalPopUp(container, (*window).Canvas())
-					popupWidget.Show()
+					dialog.ShowInformation("Replay", "The replay matches the recorded game.", *window)
 				}))
 		}
 	}
 }
+
+/*
+showGameEndPopup shows the result of the game, with the options to export it
+and to copy the share code of its board.
+Wins fast enough for the leaderboard can be added to it.
+*/
+func showGameEndPopup(config *configs.Configs, state *guiState, window fyne.Window, gameState int) {
+	var labelText string
+	switch gameState {
+	case configs.StateWin:
+		labelText = "You have won!"
+	case configs.StateLoss:
+		labelText = "You have lost!"
+	}
+
+	// The buttons are used after processGuiEvent has moved on, so they do not
+	// use the state
+	gameInstance := state.gameInstance
+	isRace := state.raceClient != nil
+
+	var popupWidget *widget.PopUp
+	container := container.NewGridWithColumns(1)
+	container.Add(widget.NewLabel(labelText))
+	// The 3BV of a race is not known, since its board is kept by the server
+	if gameState == configs.StateWin && !isRace {
+		gameStats := gameInstance.Stats()
+		container.Add(widget.NewLabel(fmt.Sprintf("3BV: %v | 3BV/s: %.2f | IOE: %.2f | Throughput: %.2f",
+			gameStats.ThreeBV, gameStats.ThreeBVPerSecond, gameStats.IOE, gameStats.Throughput)))
+	}
+	if !isRace {
+		container.Add(widget.NewButton("Export RAWVF", func() {
+			exportVideo(gameInstance, window)
+		}))
+	}
+	// A share code does not describe the shape of a masked board, nor a board
+	// without a seed, and the seed of a race is kept by the server
+	gameConfig := gameInstance.GameConfig()
+	if !isRace && gameConfig.Seed != "" && gameConfig.MaskedTiles == nil {
+		container.Add(widget.NewButton("Copy share code", func() {
+			window.Clipboard().SetContent(game.ShareCode(gameConfig))
+		}))
+	}
+	difficulty, isSizeOption := leaderboard.Difficulty(config, state.gameConfig)
+	if gameState == configs.StateWin && state.leaderboard != nil && isSizeOption {
+		gameStats := gameInstance.Stats()
+		category := leaderboard.CategoryOf(difficulty, gameInstance)
+		if state.leaderboard.Qualifies(category, gameStats.EndTime.Sub(gameStats.StartTime)) {
+			container.Add(widget.NewButton("Add to leaderboard", func() {
+				popupWidget.Hide()
+				showRecordForm(window, state.leaderboard, difficulty, gameInstance)
+			}))
+		}
+	}
+	container.Add(widget.NewButton("Close", func() {
+		popupWidget.Hide()
+	}))
+
+	popupWidget = widget.NewModalPopUp(container, window.Canvas())
+	popupWidget.Show()
+}
+
+/*
+leaveScreen stops the background work of the screen being shown, removes its
+keyboard shortcuts and records its game, before the screen is replaced.
+*/
+func leaveScreen(config *configs.Configs, state *guiState, window fyne.Window) {
+	recordStatistics(config, state)
+
+	if state.screenStop != nil {
+		close(state.screenStop)
+	}
+	state.screenStop = make(chan struct{})
+
+	window.Canvas().RemoveShortcut(undoShortcut)
+	window.Canvas().RemoveShortcut(redoShortcut)
+}
+
+/*
+leaveRace stops following the race being played, if any.
+*/
+func leaveRace(state *guiState) {
+	if state.raceClient == nil {
+		return
+	}
+
+	close(state.raceStop)
+	state.raceClient.Close()
+	state.raceClient = nil
+}
+
+/*
+saveGame saves the game in progress, so it can be resumed on the next run.
+If the game has ended the previous save is removed.
+Games in a race are not saved, since they can not be resumed in the race.
+*/
+func saveGame(state *guiState) {
+	if state.gameInstance == nil || state.raceClient != nil {
+		return
+	}
+
+	if state.gameInstance.State() != configs.StateOnGoing {
+		error := storage.Delete(saveFileName)
+		if error != nil {
+			fmt.Printf("Error deleting the saved game: %v\n", error)
+		}
+		return
+	}
+
+	data, error := state.gameInstance.Save()
+	if error == nil {
+		error = storage.Write(saveFileName, data)
+	}
+	if error != nil {
+		fmt.Printf("Error saving the game: %v\n", error)
+	}
+}
+
+/*
+saveReplay saves the replay of the game that just ended, so it can be watched
+from the setup screen.
+Games in a race have no replay, since their board is kept by the server.
+*/
+func saveReplay(state *guiState) {
+	if state.raceClient != nil {
+		return
+	}
+
+	data, error := state.gameInstance.SaveReplay()
+	if error == nil {
+		error = storage.Write(replayFileName, data)
+	}
+	if error != nil {
+		fmt.Printf("Error saving the replay: %v\n", error)
+	}
+}
+
+/*
+recordStatistics adds the game of the screen being left to the statistics, with
+the state it ended in, once.
+Games that are on going and games with a custom size are not added.
+*/
+func recordStatistics(config *configs.Configs, state *guiState) {
+	gameInstance := state.screenGame
+	state.screenGame = nil
+	if state.statistics == nil || gameInstance == nil || gameInstance.State() == configs.StateOnGoing {
+		return
+	}
+
+	difficulty, isSizeOption := leaderboard.Difficulty(config, gameInstance.GameConfig())
+	if !isSizeOption {
+		return
+	}
+
+	error := state.statistics.Record(difficulty, gameInstance)
+	if error != nil {
+		fmt.Printf("Error saving the statistics: %v\n", error)
+	}
+}
+
+/*
+importVideo asks for a RAWVF video file and shows its replay.
+*/
+func importVideo(guiChannel *chan guiEvent, window fyne.Window) {
+	dialog.ShowFileOpen(func(reader fyne.URIReadCloser, error error) {
+		if error != nil {
+			dialog.ShowError(error, window)
+			return
+		}
+		if reader == nil {
+			return
+		}
+		defer reader.Close()
+
+		data, error := io.ReadAll(reader)
+		if error != nil {
+			dialog.ShowError(error, window)
+			return
+		}
+
+		*guiChannel <- importedVideoEvent(data)
+	}, window)
+}
+
+/*
+importedVideoEvent creates the event that shows the replay of the provided
+RAWVF video.
+*/
+func importedVideoEvent(data []byte) guiEvent {
+	return guiEvent{name: "replay", update: func(state *guiState) error {
+		replayData, error := game.ImportRAWVF(data)
+		if error != nil {
+			return error
+		}
+		replayer, error := game.NewReplayer(replayData)
+		if error != nil {
+			return error
+		}
+
+		state.replayer = replayer
+		return nil
+	}}
+}
+
+/*
+loadMask asks for a text or PNG mask file and gives the board shape it describes,
+with the name of the file, to the callback.
+*/
+func loadMask(window fyne.Window, callback func(boardMask mask.Mask, name string)) {
+	dialog.ShowFileOpen(func(reader fyne.URIReadCloser, error error) {
+		if error != nil {
+			dialog.ShowError(error, window)
+			return
+		}
+		if reader == nil {
+			return
+		}
+		defer reader.Close()
+
+		data, error := io.ReadAll(reader)
+		if error != nil {
+			dialog.ShowError(error, window)
+			return
+		}
+
+		boardMask, error := mask.Parse(data)
+		if error != nil {
+			dialog.ShowError(error, window)
+			return
+		}
+
+		callback(boardMask, reader.URI().Name())
+	}, window)
+}
+
+/*
+exportVideo asks for a file and writes the provided game to it as a RAWVF video.
+*/
+func exportVideo(gameInstance game.IGame, window fyne.Window) {
+	data, exportError := gameInstance.ExportRAWVF()
+	if exportError != nil {
+		dialog.ShowError(exportError, window)
+		return
+	}
+
+	dialog.ShowFileSave(func(writer fyne.URIWriteCloser, error error) {
+		if error != nil {
+			dialog.ShowError(error, window)
+			return
+		}
+		if writer == nil {
+			return
+		}
+		defer writer.Close()
+
+		_, error = writer.Write(data)
+		if error != nil {
+			dialog.ShowError(error, window)
+		}
+	}, window)
+}
@@ -0,0 +1,302 @@
+package gui
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sync"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Declare conformity with fyne.CanvasObject interface
+var _ fyne.CanvasObject = (*hexTile)(nil)
+
+// The smallest width of a hexagonal tile
+const hexTileMinWidth float32 = 32
+
+// The height of a hexagonal tile, with a pointy top, relative to its width
+var hexTileHeightRatio = float32(2 / math.Sqrt(3))
+
+// The part of the tile's bounds filled by the hexagon, leaving a gap between
+// adjacent tiles
+const hexTileFill float64 = 0.92
+
+// hexTile represents a tile on a board of hexagonal tiles.
+type hexTile struct {
+	widget.BaseWidget
+	rowIndex int
+	colIndex int
+	// Returns the current state of the linked tile
+	tile           func() minefield.ITile
+	primaryClick   func(rowIndex int, colIndex int)
+	secondaryClick func(rowIndex int, colIndex int)
+	bothClick      func(rowIndex int, colIndex int)
+	clickTimer     *clickTimer
+	// Held while the fields shown by the renderer are used, since the widget is
+	// updated by the game's events on their own goroutine
+	propertyLock sync.RWMutex
+	text         string
+	icon         fyne.Resource
+	revealed     bool
+	highlighted  bool
+}
+
+/*
+updateWidget sets the state of the widget based on the linked tile state.
+*/
+func (t *hexTile) updateWidget(forceReveal bool) {
+	tile := t.tile()
+
+	t.propertyLock.Lock()
+	t.highlighted = false
+	t.revealed = tile.Revealed() || forceReveal
+
+	if t.revealed {
+		if tile.HasFlag() {
+			if !tile.HasMine() {
+				t.icon = resourceIncorrectFlagPng
+			}
+		} else if tile.HasMine() {
+			t.icon = resourceMinePng
+		} else {
+			t.icon = nil
+			t.text = ""
+			if tile.AdjacentMines() > 0 {
+				t.text = fmt.Sprint(tile.AdjacentMines())
+			}
+		}
+	} else {
+		// The tile may have been hidden again by an undo
+		t.text = ""
+		t.icon = nil
+		if tile.HasFlag() {
+			t.icon = resourceFlagPng
+		}
+	}
+	t.propertyLock.Unlock()
+
+	t.Refresh()
+}
+
+/*
+highlight marks the widget as the tile suggested by a hint, until the widget is
+updated again.
+*/
+func (t *hexTile) highlight() {
+	t.propertyLock.Lock()
+	t.highlighted = true
+	t.propertyLock.Unlock()
+
+	t.Refresh()
+}
+
+/*
+Tapped handles LMB clicks.
+*/
+func (t *hexTile) Tapped(_ *fyne.PointEvent) {
+	t.clickTimer.handleClick(configs.PrimaryClick, t.rowIndex, t.colIndex, t.primaryClick, t.bothClick)
+}
+
+/*
+TappedSecondary handles RMB clicks.
+*/
+func (t *hexTile) TappedSecondary(_ *fyne.PointEvent) {
+	t.clickTimer.handleClick(configs.SecondaryClick, t.rowIndex, t.colIndex, t.secondaryClick, t.bothClick)
+}
+
+/*
+CreateRenderer creates the renderer that draws the hexagon, with the tile's
+number or icon in its center.
+*/
+func (t *hexTile) CreateRenderer() fyne.WidgetRenderer {
+	renderer := &hexTileRenderer{
+		hexTile: t,
+		text:    canvas.NewText("", theme.ForegroundColor()),
+		icon:    canvas.NewImageFromResource(nil),
+	}
+	renderer.background = canvas.NewRasterWithPixels(func(x int, y int, width int, height int) color.Color {
+		if !insideHexagon(x, y, width, height) {
+			return color.Transparent
+		}
+		return renderer.fillColor
+	})
+	renderer.text.TextStyle = fyne.TextStyle{Bold: true}
+	renderer.text.Alignment = fyne.TextAlignCenter
+	renderer.icon.FillMode = canvas.ImageFillContain
+	renderer.objects = []fyne.CanvasObject{renderer.background, renderer.text, renderer.icon}
+	renderer.Refresh()
+
+	return renderer
+}
+
+// hexTileRenderer draws a hexTile
+type hexTileRenderer struct {
+	hexTile    *hexTile
+	background *canvas.Raster
+	fillColor  color.Color
+	text       *canvas.Text
+	icon       *canvas.Image
+	objects    []fyne.CanvasObject
+}
+
+/*
+Layout fills the bounds with the hexagon and centers the number and the icon.
+*/
+func (r *hexTileRenderer) Layout(size fyne.Size) {
+	r.background.Resize(size)
+
+	r.text.Resize(size)
+	r.text.Move(fyne.NewPos(0, 0))
+
+	iconSize := fyne.NewSize(size.Width/2, size.Height/2)
+	r.icon.Resize(iconSize)
+	r.icon.Move(fyne.NewPos((size.Width-iconSize.Width)/2, (size.Height-iconSize.Height)/2))
+}
+
+/*
+MinSize returns the size of a hexagon with the smallest tile width.
+*/
+func (r *hexTileRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(hexTileMinWidth, hexTileMinWidth*hexTileHeightRatio)
+}
+
+/*
+Refresh draws the current state of the tile.
+*/
+func (r *hexTileRenderer) Refresh() {
+	r.hexTile.propertyLock.RLock()
+	switch {
+	case r.hexTile.highlighted:
+		r.fillColor = theme.PrimaryColor()
+	case r.hexTile.revealed:
+		r.fillColor = theme.DisabledButtonColor()
+	default:
+		r.fillColor = theme.ButtonColor()
+	}
+	r.text.Text = r.hexTile.text
+	r.icon.Resource = r.hexTile.icon
+	r.hexTile.propertyLock.RUnlock()
+
+	r.text.Color = theme.ForegroundColor()
+	r.text.TextSize = theme.TextSize()
+	r.icon.Hidden = r.icon.Resource == nil
+
+	r.background.Refresh()
+	r.text.Refresh()
+	r.icon.Refresh()
+}
+
+/*
+Objects returns the objects that draw the tile.
+*/
+func (r *hexTileRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+/*
+Destroy does nothing, the renderer holds no resources.
+*/
+func (r *hexTileRenderer) Destroy() {}
+
+/*
+insideHexagon returns true if the pixel is inside the hexagon, with a pointy top,
+that fills the provided bounds.
+*/
+func insideHexagon(x int, y int, width int, height int) bool {
+	halfWidth := float64(width) / 2 * hexTileFill
+	halfHeight := float64(height) / 2 * hexTileFill
+	offsetX := math.Abs(float64(x)+0.5-float64(width)/2) / halfWidth
+	offsetY := math.Abs(float64(y)+0.5-float64(height)/2) / halfHeight
+
+	return offsetX <= 1 && offsetY <= 1-offsetX/2
+}
+
+/*
+newHexTileWidget creates a hexTile instance.
+*/
+func newHexTileWidget(args newTileWidgetArgs) (fyne.CanvasObject, ITileWidget) {
+	tile := &hexTile{
+		rowIndex:       args.rowIndex,
+		colIndex:       args.colIndex,
+		tile:           args.tile,
+		primaryClick:   args.primaryClick,
+		secondaryClick: args.secondaryClick,
+		bothClick:      args.bothClick,
+		clickTimer: &clickTimer{
+			button: noButtonClick,
+		},
+	}
+	tile.ExtendBaseWidget(tile)
+
+	tile.updateWidget(false)
+
+	return tile, tile
+}
+
+// HexLayout places the tiles of a board of hexagonal tiles in rows, with the
+// odd rows shifted right by half a tile and each row overlapping the previous
+// one by a quarter of a tile
+type hexLayout struct {
+	cols int
+}
+
+/*
+tileSize returns the size of the largest tiles that fit, with their rows, in
+the provided size.
+*/
+func (layout *hexLayout) tileSize(numObjects int, size fyne.Size) fyne.Size {
+	rows := (numObjects + layout.cols - 1) / layout.cols
+
+	width := size.Width / (float32(layout.cols) + 0.5)
+	if maxHeight := size.Height / (0.75*float32(rows) + 0.25); width*hexTileHeightRatio > maxHeight {
+		width = maxHeight / hexTileHeightRatio
+	}
+
+	return fyne.NewSize(width, width*hexTileHeightRatio)
+}
+
+/*
+Layout places the tiles, in row order, in their rows.
+*/
+func (layout *hexLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	tileSize := layout.tileSize(len(objects), size)
+
+	for index, object := range objects {
+		rowIndex := index / layout.cols
+		colIndex := index % layout.cols
+
+		x := float32(colIndex) * tileSize.Width
+		if rowIndex%2 == 1 {
+			x += tileSize.Width / 2
+		}
+		y := float32(rowIndex) * tileSize.Height * 0.75
+
+		object.Move(fyne.NewPos(x, y))
+		object.Resize(tileSize)
+	}
+}
+
+/*
+MinSize returns the size of the rows of tiles with their smallest size.
+*/
+func (layout *hexLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	if len(objects) == 0 {
+		return fyne.NewSize(0, 0)
+	}
+
+	rows := (len(objects) + layout.cols - 1) / layout.cols
+	// The cells removed by a mask have no size
+	tileSize := fyne.NewSize(0, 0)
+	for _, object := range objects {
+		tileSize = tileSize.Max(object.MinSize())
+	}
+
+	return fyne.NewSize(tileSize.Width*(float32(layout.cols)+0.5), tileSize.Height*(0.75*float32(rows)+0.25))
+}
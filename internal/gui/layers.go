@@ -0,0 +1,186 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// layerTile represents a tile on a board with layers, with ghosted hints of the
+// tiles on the same row and col of the layers above and below.
+type layerTile struct {
+	// The widget of the tile itself
+	tileWidget ITileWidget
+	ghost      *canvas.Text
+	// Return the current state of the tiles on the layers above and below, or nil
+	// if there is no such tile
+	above func() minefield.ITile
+	below func() minefield.ITile
+	// The tiles on the layers above and below, whose ghosted hints show this tile
+	linked []*layerTile
+	// Shows the layer of the tile
+	showLayer func()
+}
+
+/*
+updateWidget updates the tile and the ghosted hints of the tiles on the layers
+above and below.
+*/
+func (t *layerTile) updateWidget(forceReveal bool) {
+	t.tileWidget.updateWidget(forceReveal)
+	t.updateGhost(forceReveal)
+
+	for _, linkedTile := range t.linked {
+		linkedTile.updateGhost(forceReveal)
+	}
+}
+
+/*
+highlight shows the layer of the tile and marks it as the tile suggested by a
+hint, until the widget is updated again.
+*/
+func (t *layerTile) highlight() {
+	t.showLayer()
+	t.tileWidget.highlight()
+}
+
+/*
+updateGhost sets the ghosted hints with the state of the tiles on the layers
+above and below.
+*/
+func (t *layerTile) updateGhost(forceReveal bool) {
+	hints := []string{}
+	if hint := ghostHint(t.above(), forceReveal); hint != "" {
+		hints = append(hints, "↑"+hint)
+	}
+	if hint := ghostHint(t.below(), forceReveal); hint != "" {
+		hints = append(hints, "↓"+hint)
+	}
+
+	t.ghost.Text = strings.Join(hints, " ")
+	t.ghost.Refresh()
+}
+
+/*
+ghostHint returns the text that shows the visible state of a tile on another
+layer, which is empty if the tile is hidden or there is no tile.
+*/
+func ghostHint(tile minefield.ITile, forceReveal bool) string {
+	switch {
+	case tile == nil:
+		return ""
+	case tile.HasFlag():
+		return "F"
+	case !tile.Revealed() && !forceReveal:
+		return ""
+	case tile.HasMine():
+		return "*"
+	case tile.AdjacentMines() > 0:
+		return fmt.Sprint(tile.AdjacentMines())
+	}
+
+	return ""
+}
+
+/*
+layerTileState returns a function with the current state of the tile with the
+provided index, or nil if the game has no such tile.
+*/
+func layerTileState(game game.IGame, tileIndex int) func() minefield.ITile {
+	gameConfig := game.Config()
+
+	return func() minefield.ITile {
+		if tileIndex < 0 || tileIndex > gameConfig.NumRows*gameConfig.NumCols-1 {
+			return nil
+		}
+
+		tile, error := game.Tile(tileIndex/gameConfig.NumCols, tileIndex%gameConfig.NumCols)
+		if error != nil {
+			return nil
+		}
+		return tile
+	}
+}
+
+/*
+buildLayersContainer places the tiles of a board with layers in one grid per
+layer and shows the layer picked with a selector.
+The tile widgets are replaced by widgets that also show the ghosted hints of
+the tiles on the layers above and below.
+*/
+func buildLayersContainer(game game.IGame, canvasObjects []fyne.CanvasObject, tileWidgets []ITileWidget) *fyne.Container {
+	gameConfig := game.Config()
+	layerSize := gameConfig.NumRows / gameConfig.NumLayers * gameConfig.NumCols
+
+	layerLabels := make([]string, gameConfig.NumLayers)
+	layerGrids := make([]*fyne.Container, gameConfig.NumLayers)
+	gridObjects := make([]fyne.CanvasObject, gameConfig.NumLayers)
+	for layerIndex := range layerGrids {
+		layerLabels[layerIndex] = fmt.Sprintf("Layer %v of %v", layerIndex+1, gameConfig.NumLayers)
+		layerGrids[layerIndex] = container.NewGridWithColumns(gameConfig.NumCols)
+		gridObjects[layerIndex] = layerGrids[layerIndex]
+	}
+
+	layerSelect := widget.NewSelect(layerLabels, func(value string) {
+		for layerIndex, layerGrid := range layerGrids {
+			if layerLabels[layerIndex] == value {
+				layerGrid.Show()
+			} else {
+				layerGrid.Hide()
+			}
+		}
+	})
+
+	layerTiles := make([]*layerTile, len(tileWidgets))
+	for tileIndex, tileWidget := range tileWidgets {
+		layerIndex := tileIndex / layerSize
+
+		// The cells removed by a mask have no hints
+		if _, masked := tileWidget.(*maskedTile); masked {
+			layerGrids[layerIndex].Add(canvasObjects[tileIndex])
+			continue
+		}
+
+		ghost := canvas.NewText("", theme.DisabledColor())
+		ghost.TextSize = theme.CaptionTextSize()
+		ghost.Alignment = fyne.TextAlignTrailing
+
+		layerTiles[tileIndex] = &layerTile{
+			tileWidget: tileWidget,
+			ghost:      ghost,
+			above:      layerTileState(game, tileIndex-layerSize),
+			below:      layerTileState(game, tileIndex+layerSize),
+			showLayer: func() {
+				layerSelect.SetSelected(layerLabels[layerIndex])
+			},
+		}
+		tileWidgets[tileIndex] = layerTiles[tileIndex]
+
+		layerGrids[layerIndex].Add(container.NewMax(canvasObjects[tileIndex], container.NewVBox(ghost)))
+	}
+
+	for tileIndex, tile := range layerTiles {
+		if tile == nil {
+			continue
+		}
+
+		for _, linkedIndex := range []int{tileIndex - layerSize, tileIndex + layerSize} {
+			if linkedIndex >= 0 && linkedIndex < len(layerTiles) && layerTiles[linkedIndex] != nil {
+				tile.linked = append(tile.linked, layerTiles[linkedIndex])
+			}
+		}
+		tile.updateGhost(false)
+	}
+
+	layerSelect.SetSelectedIndex(0)
+
+	return container.NewBorder(layerSelect, nil, nil, nil, container.NewMax(gridObjects...))
+}
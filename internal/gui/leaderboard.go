@@ -0,0 +1,115 @@
+package gui
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/pedrohenriques/go-minesweeper/internal/leaderboard"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+/*
+createLeaderboardGui generates the CanvasObject for the leaderboard screen,
+with the records of the selected category.
+*/
+func createLeaderboardGui(config *configs.Configs, board leaderboard.ILeaderboard, back func()) fyne.CanvasObject {
+	difficulties := make([]string, 0, len(config.SizeOptions))
+	for key := range config.SizeOptions {
+		difficulties = append(difficulties, key)
+	}
+	sort.Slice(difficulties, func(i, j int) bool {
+		return config.SizeOptions[difficulties[i]].NumMines < config.SizeOptions[difficulties[j]].NumMines
+	})
+	livesLabels := []string{"One life", "Multiple lives"}
+	hintsLabels := []string{"No hints", "With hints"}
+	undoLabels := []string{"No undo", "With undo"}
+
+	difficultySelect := widget.NewSelect(difficulties, nil)
+	livesSelect := widget.NewSelect(livesLabels, nil)
+	hintsSelect := widget.NewSelect(hintsLabels, nil)
+	undoSelect := widget.NewSelect(undoLabels, nil)
+	recordsContainer := container.NewGridWithColumns(6)
+
+	showRecords := func(string) {
+		if difficultySelect.Selected == "" || livesSelect.Selected == "" || hintsSelect.Selected == "" ||
+			undoSelect.Selected == "" {
+			return
+		}
+
+		recordsCategory := leaderboard.NewCategory(difficultySelect.Selected,
+			livesSelect.Selected == livesLabels[1], hintsSelect.Selected == hintsLabels[1],
+			undoSelect.Selected == undoLabels[1])
+
+		recordsContainer.RemoveAll()
+		for _, label := range []string{"Rank", "Player", "Time", "Date", "Lives", "Flags"} {
+			recordsContainer.Add(widget.NewLabelWithStyle(label, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+		}
+		for index, record := range board.Records(recordsCategory) {
+			flags := "Off"
+			if record.FlagsEnabled {
+				flags = "On"
+			}
+
+			recordsContainer.Add(widget.NewLabel(fmt.Sprint(index + 1)))
+			recordsContainer.Add(widget.NewLabel(record.Player))
+			recordsContainer.Add(widget.NewLabel(fmt.Sprint(record.Time.Truncate(time.Millisecond))))
+			recordsContainer.Add(widget.NewLabel(record.Date.Format("2006-01-02")))
+			recordsContainer.Add(widget.NewLabel(fmt.Sprint(record.Lives)))
+			recordsContainer.Add(widget.NewLabel(flags))
+		}
+		recordsContainer.Refresh()
+	}
+	difficultySelect.OnChanged = showRecords
+	livesSelect.OnChanged = showRecords
+	hintsSelect.OnChanged = showRecords
+	undoSelect.OnChanged = showRecords
+
+	navContainer := container.NewGridWithRows(1)
+	navContainer.Add(widget.NewButton("Back", back))
+	navContainer.Add(difficultySelect)
+	navContainer.Add(livesSelect)
+	navContainer.Add(hintsSelect)
+	navContainer.Add(undoSelect)
+
+	livesSelect.SetSelectedIndex(0)
+	hintsSelect.SetSelectedIndex(0)
+	undoSelect.SetSelectedIndex(0)
+	if len(difficulties) > 0 {
+		difficultySelect.SetSelectedIndex(0)
+	}
+
+	return container.NewVBox(navContainer, recordsContainer)
+}
+
+/*
+showRecordForm asks for the player's name and adds the won game, played on the
+difficulty, to the leaderboard.
+*/
+func showRecordForm(window fyne.Window, board leaderboard.ILeaderboard, difficulty string, gameInstance game.IGame) {
+	playerEntry := widget.NewEntry()
+
+	dialog.ShowForm("New record", "Save", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("Name", playerEntry),
+		},
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+
+			rank, error := board.Add(difficulty, playerEntry.Text, gameInstance)
+			if error != nil {
+				dialog.ShowError(error, window)
+				return
+			}
+
+			dialog.ShowInformation("Leaderboard", fmt.Sprintf("Your time is number %v on the leaderboard!", rank), window)
+		}, window)
+}
@@ -0,0 +1,105 @@
+package gui
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/match"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// The server suggested when joining a race
+const defaultRaceServer string = "http://localhost:8080"
+
+/*
+showJoinRaceForm asks for the server hosting a race and the ID of the player's
+game, and calls the callback with the client of the race.
+*/
+func showJoinRaceForm(window fyne.Window, callback func(client match.IClient)) {
+	serverEntry := widget.NewEntry()
+	serverEntry.SetText(defaultRaceServer)
+	gameIdEntry := widget.NewEntry()
+
+	dialog.ShowForm("Join race", "Join", "Cancel",
+		[]*widget.FormItem{
+			widget.NewFormItem("Server", serverEntry),
+			widget.NewFormItem("Game ID", gameIdEntry),
+		},
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+
+			client, error := match.Join(serverEntry.Text, gameIdEntry.Text)
+			if error != nil {
+				dialog.ShowError(error, window)
+				return
+			}
+
+			callback(client)
+		}, window)
+}
+
+/*
+createRaceProgressGui generates the CanvasObject with a progress bar for each
+player of the race, updated every second until stop is closed.
+onRaceEnd is called once, when the race is decided, with the winning player.
+*/
+func createRaceProgressGui(client match.IClient, stop chan struct{}, onRaceEnd func(winner int)) fyne.CanvasObject {
+	progressContainer := container.NewGridWithColumns(2)
+	progressBinds := []binding.Float{}
+
+	addPlayers := func(numPlayers int) {
+		for player := len(progressBinds); player < numPlayers; player++ {
+			label := fmt.Sprintf("Player %v", player+1)
+			if player == client.Player() {
+				label += " (you)"
+			}
+
+			progressBind := binding.NewFloat()
+			progressBinds = append(progressBinds, progressBind)
+			progressContainer.Add(widget.NewLabel(label))
+			progressContainer.Add(widget.NewProgressBarWithData(progressBind))
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+
+			status, error := client.Status()
+			if error != nil {
+				log.Println(error)
+				continue
+			}
+
+			addPlayers(len(status.Progress))
+			for player, playerProgress := range status.Progress {
+				error = progressBinds[player].Set(playerProgress.Percentage / 100)
+				if error != nil {
+					log.Println(error)
+				}
+			}
+
+			if status.Result.Done {
+				onRaceEnd(status.Result.Winner)
+				return
+			}
+		}
+	}()
+
+	return progressContainer
+}
@@ -0,0 +1,145 @@
+package gui
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ReplayPlayback holds the playback settings of a replay
+type replayPlayback struct {
+	mutex sync.Mutex
+	// Held while an action is applied, so the timer and the step button do not
+	// apply actions at the same time
+	stepMutex sync.Mutex
+	// The speed multiplier of the replay, 0 to play it one step at a time
+	speed   int
+	playing bool
+	timer   *time.Timer
+}
+
+/*
+createReplayGui generates the CanvasObject for the replay screen.
+The screen's background work runs until stop is closed.
+*/
+func createReplayGui(replayer game.IReplayer, stop <-chan struct{}, back func(), onReplayEnd func(error error)) fyne.CanvasObject {
+	replayGame := replayer.Game()
+	statsContainer, statsDataBinds := buildStatsContainer(replayGame, replayer.Now, stop)
+	boardContainer, _ := buildBoardContainer(replayGame, statsDataBinds, func(int) {}, true, stop)
+
+	playback := &replayPlayback{speed: 1}
+
+	step := func() {
+		playback.stepMutex.Lock()
+		defer playback.stepMutex.Unlock()
+
+		if replayer.Done() {
+			return
+		}
+		_, error := replayer.Step()
+
+		if error != nil || replayer.Done() {
+			playback.stop()
+			onReplayEnd(error)
+		}
+	}
+
+	var playNext func()
+	playNext = func() {
+		playback.mutex.Lock()
+		defer playback.mutex.Unlock()
+
+		if !playback.playing || playback.speed == 0 || replayer.Done() {
+			return
+		}
+
+		delay := replayer.NextDelay() / time.Duration(playback.speed)
+		playback.timer = time.AfterFunc(delay, func() {
+			step()
+			playNext()
+		})
+	}
+
+	speedSelect := createReplaySpeedSelect(func(speed int) {
+		playback.mutex.Lock()
+		playback.speed = speed
+		if speed == 0 {
+			playback.playing = false
+		}
+		playback.mutex.Unlock()
+	})
+
+	navContainer := container.NewGridWithRows(1)
+	navContainer.Add(widget.NewButton("Back", func() {
+		playback.stop()
+		back()
+	}))
+	navContainer.Add(speedSelect)
+	navContainer.Add(widget.NewButton("Play", func() {
+		if playback.start() {
+			playNext()
+		}
+	}))
+	navContainer.Add(widget.NewButton("Pause", playback.stop))
+	navContainer.Add(widget.NewButton("Step", func() {
+		playback.stop()
+		step()
+	}))
+
+	return container.NewVBox(navContainer, statsContainer, boardContainer)
+}
+
+/*
+start marks the replay as playing.
+Returns false if it was already playing or it is set to play one step at a
+time.
+*/
+func (playback *replayPlayback) start() bool {
+	playback.mutex.Lock()
+	defer playback.mutex.Unlock()
+
+	if playback.playing || playback.speed == 0 {
+		return false
+	}
+
+	playback.playing = true
+	return true
+}
+
+/*
+stop pauses the replay, cancelling the next scheduled step.
+*/
+func (playback *replayPlayback) stop() {
+	playback.mutex.Lock()
+	defer playback.mutex.Unlock()
+
+	playback.playing = false
+	if playback.timer != nil {
+		playback.timer.Stop()
+	}
+}
+
+/*
+createReplaySpeedSelect creates the CanvasObject with the replay speed options.
+*/
+func createReplaySpeedSelect(callback func(speed int)) fyne.CanvasObject {
+	optionLabels := []string{"Real speed", "2x", "4x", "Step by step"}
+	optionValues := map[string]int{
+		optionLabels[0]: 1,
+		optionLabels[1]: 2,
+		optionLabels[2]: 4,
+		optionLabels[3]: 0,
+	}
+
+	selectWidget := widget.NewSelect(optionLabels, func(value string) {
+		callback(optionValues[value])
+	})
+	selectWidget.SetSelectedIndex(0)
+
+	return selectWidget
+}
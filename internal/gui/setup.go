@@ -2,10 +2,13 @@ package gui
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
-	"time"
 
 	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/pedrohenriques/go-minesweeper/internal/mask"
+	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
+	"github.com/pedrohenriques/go-minesweeper/internal/presets"
 
 	"github.com/pedrohenriques/go-minesweeper/internal/configs"
 
@@ -14,20 +17,77 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
+// The label of the difficulty option that shows the custom difficulty editor
+const customDifficultyLabel string = "Custom"
+
+// Error: The value of an input is not a number
+type invalidNumberError struct {
+	Label string
+	Value string
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e invalidNumberError) Error() string {
+	return fmt.Sprintf("The number of %v '%v' is not a number", e.Label, e.Value)
+}
+
 /*
 createSetupGui generates the CanvasObject for the setup screen.
+If resumeGame is not nil a button to resume the last game is added.
+If watchReplay is not nil a button to watch the replay of the last finished game
+is added.
+If userPresets is not nil the player's presets can be chosen and saved.
 */
-func createSetupGui(config *configs.Configs, startGame func(config game.GameConfig)) fyne.CanvasObject {
+func createSetupGui(config *configs.Configs, userPresets presets.IPresets, startGame func(config game.GameConfig), resumeGame func(), watchReplay func(), importVideo func(), loadMask func(callback func(boardMask mask.Mask, name string)), joinRace func(), showLeaderboard func(), showStatistics func()) fyne.CanvasObject {
 	gameArgs := game.GameConfig{}
 
-	container := container.NewGridWithColumns(1)
+	container := container.NewVBox()
 
-	container.Add(createDifficultySelect(config, func(option configs.SizeOption) {
+	container.Add(createDifficultySelect(config, userPresets, func(option configs.SizeOption) {
 		gameArgs.NumMines = option.NumMines
 		gameArgs.NumRows = option.NumRows
 		gameArgs.NumCols = option.NumCols
 	}))
 
+	container.Add(createSafeStartSelect(func(safeStart int) {
+		gameArgs.SafeStart = safeStart
+	}))
+
+	tileShape := configs.TopologySquare
+	wrapEdges := false
+	wrapCheck := createWrapEdgesCheck(func(enabled bool) {
+		wrapEdges = enabled
+		gameArgs.Topology = boardTopology(tileShape, wrapEdges)
+	})
+
+	container.Add(createTopologySelect(func(topology int) {
+		tileShape = topology
+		gameArgs.Topology = boardTopology(tileShape, wrapEdges)
+
+		if topology == configs.TopologySquare {
+			wrapCheck.Enable()
+		} else {
+			wrapCheck.Disable()
+		}
+	}))
+
+	container.Add(wrapCheck)
+
+	container.Add(createLayersSelect(func(numLayers int) {
+		gameArgs.NumLayers = numLayers
+	}))
+
+	var boardMask *mask.Mask
+	container.Add(createMaskPicker(loadMask, func(loadedMask *mask.Mask) {
+		boardMask = loadedMask
+	}))
+
+	container.Add(createNoGuessCheck(func(enabled bool) {
+		gameArgs.NoGuess = enabled
+	}))
+
 	container.Add(createFlagEnabledCheck(func(enabled bool) {
 		gameArgs.FlagsEnabled = enabled
 	}))
@@ -51,12 +111,72 @@ func createSetupGui(config *configs.Configs, startGame func(config game.GameConf
 	}))
 
 	container.Add(widget.NewButton("Start Game", func() {
-		startGame(gameArgs)
+		// A share code replaces the board options, keeping the player's lives
+		// and flags
+		sharedArgs, error := game.ParseShareCode(gameArgs.Seed)
+		if error != nil {
+			// A mask replaces the size of the difficulty, keeping its mines
+			boardArgs := gameArgs
+			if boardMask != nil {
+				boardArgs.NumRows = boardMask.NumRows
+				boardArgs.NumCols = boardMask.NumCols
+				boardArgs.MaskedTiles = maskedLayerTiles(boardMask, boardArgs.NumLayers)
+			}
+			startGame(boardArgs)
+			return
+		}
+
+		sharedArgs.Lives = gameArgs.Lives
+		sharedArgs.FlagsEnabled = gameArgs.FlagsEnabled
+		startGame(sharedArgs)
 	}))
 
+	if resumeGame != nil {
+		container.Add(widget.NewButton("Resume last game", resumeGame))
+	}
+
+	if watchReplay != nil {
+		container.Add(widget.NewButton("Watch last replay", watchReplay))
+	}
+
+	container.Add(widget.NewButton("Watch RAWVF video", importVideo))
+
+	container.Add(widget.NewButton("Join race", joinRace))
+
+	container.Add(widget.NewButton("Leaderboard", showLeaderboard))
+
+	container.Add(widget.NewButton("Statistics", showStatistics))
+
 	return container
 }
 
+/*
+createMaskPicker creates the CanvasObject with the board shape, which is a
+rectangle unless a mask file is loaded.
+The callback receives the loaded mask, or nil once it is cleared.
+*/
+func createMaskPicker(loadMask func(callback func(boardMask mask.Mask, name string)), callback func(boardMask *mask.Mask)) fyne.CanvasObject {
+	shapeLabel := widget.NewLabel("Board shape: Rectangle")
+
+	var clearButton *widget.Button
+	clearButton = widget.NewButton("Clear mask", func() {
+		shapeLabel.SetText("Board shape: Rectangle")
+		clearButton.Disable()
+		callback(nil)
+	})
+	clearButton.Disable()
+
+	loadButton := widget.NewButton("Load mask", func() {
+		loadMask(func(boardMask mask.Mask, name string) {
+			shapeLabel.SetText(fmt.Sprintf("Board shape: %v (%vx%v)", name, boardMask.NumRows, boardMask.NumCols))
+			clearButton.Enable()
+			callback(&boardMask)
+		})
+	})
+
+	return container.NewHBox(shapeLabel, loadButton, clearButton)
+}
+
 /*
 createFlagEnabledCheck creates the CanvasObject with the flags enabled check.
 */
@@ -68,24 +188,236 @@ func createFlagEnabledCheck(callback func(checked bool)) fyne.CanvasObject {
 }
 
 /*
-createDifficultySelect creates the CanvasObject with the board size options.
+createNoGuessCheck creates the CanvasObject with the no guess boards check.
+*/
+func createNoGuessCheck(callback func(checked bool)) fyne.CanvasObject {
+	return widget.NewCheck("No guessing required", callback)
+}
+
+/*
+createDifficultySelect creates the CanvasObject with the board size options,
+followed by the player's presets and the custom difficulty editor.
+If userPresets is nil the presets are not listed and can not be saved.
 */
-func createDifficultySelect(config *configs.Configs, callback func(option configs.SizeOption)) fyne.CanvasObject {
-	optionLabels := make([]string, len(config.SizeOptions))
-	index := 0
+func createDifficultySelect(config *configs.Configs, userPresets presets.IPresets, callback func(option configs.SizeOption)) fyne.CanvasObject {
+	optionLabels := make([]string, 0, len(config.SizeOptions))
 	for key := range config.SizeOptions {
-		optionLabels[index] = key
-		index++
+		optionLabels = append(optionLabels, key)
 	}
 
-	container := container.NewGridWithRows(1)
+	presetOptions := map[string]configs.SizeOption{}
+	if userPresets != nil {
+		presetOptions = userPresets.Options()
+	}
+	presetLabels := make([]string, 0, len(presetOptions))
+	for name := range presetOptions {
+		presetLabels = append(presetLabels, name)
+	}
+	sort.Strings(presetLabels)
 
-	container.Add(widget.NewLabel("Difficulty:"))
-	selectWidget := widget.NewSelect(optionLabels, func(value string) {
+	var selectWidget *widget.Select
+	var customEditor fyne.CanvasObject
+	var customOption configs.SizeOption
+
+	removeButton := widget.NewButton("Remove preset", func() {
+		name := selectWidget.Selected
+		error := userPresets.Remove(name)
+		if error != nil {
+			fmt.Printf("Error removing the preset: %v\n", error)
+			return
+		}
+
+		delete(presetOptions, name)
+		selectWidget.Options = removeLabel(selectWidget.Options, name)
+		selectWidget.SetSelectedIndex(0)
+	})
+	removeButton.Hide()
+
+	customEditor = createCustomDifficultyEditor(config, userPresets, func(option configs.SizeOption) {
+		customOption = option
+		callback(option)
+	}, func(name string, option configs.SizeOption) {
+		if _, ok := presetOptions[name]; !ok {
+			selectWidget.Options = append(removeLabel(selectWidget.Options, customDifficultyLabel), name, customDifficultyLabel)
+		}
+		presetOptions[name] = option
+		selectWidget.SetSelected(name)
+	})
+	customEditor.Hide()
+
+	selectWidget = widget.NewSelect(append(append(optionLabels, presetLabels...), customDifficultyLabel), func(value string) {
+		removeButton.Hide()
+		customEditor.Hide()
+
+		if value == customDifficultyLabel {
+			customEditor.Show()
+			callback(customOption)
+			return
+		}
+		if option, ok := presetOptions[value]; ok {
+			removeButton.Show()
+			callback(option)
+			return
+		}
 		if option, ok := config.SizeOptions[value]; ok {
 			callback(option)
 		}
 	})
+
+	selectContainer := container.NewGridWithRows(1)
+
+	selectContainer.Add(widget.NewLabel("Difficulty:"))
+	selectContainer.Add(selectWidget)
+	if userPresets != nil {
+		selectContainer.Add(removeButton)
+	}
+
+	selectWidget.SetSelectedIndex(0)
+
+	return container.NewVBox(selectContainer, customEditor)
+}
+
+/*
+createCustomDifficultyEditor creates the CanvasObject with the inputs of a
+custom difficulty.
+The option is sent to the callback whenever an input changes, even if it is not
+valid, in which case the reason is shown below the inputs.
+If userPresets is not nil the option can be saved as a preset, which is sent to
+onSave.
+*/
+func createCustomDifficultyEditor(config *configs.Configs, userPresets presets.IPresets, callback func(option configs.SizeOption), onSave func(name string, option configs.SizeOption)) fyne.CanvasObject {
+	option := configs.SizeOption{}
+	var inputError error
+
+	errorLabel := widget.NewLabel("")
+	errorLabel.Wrapping = fyne.TextWrapWord
+
+	onChanged := func(value string, label string, target *int) {
+		number, error := strconv.Atoi(value)
+		if error != nil {
+			inputError = invalidNumberError{
+				Label: label,
+				Value: value,
+			}
+		} else {
+			*target = number
+			inputError = presets.Validate(option)
+		}
+
+		errorLabel.SetText("")
+		if inputError != nil {
+			errorLabel.SetText(inputError.Error())
+		}
+		callback(option)
+	}
+
+	rowsInput := widget.NewEntry()
+	colsInput := widget.NewEntry()
+	minesInput := widget.NewEntry()
+	rowsInput.OnChanged = func(value string) {
+		onChanged(value, "rows", &option.NumRows)
+	}
+	colsInput.OnChanged = func(value string) {
+		onChanged(value, "columns", &option.NumCols)
+	}
+	minesInput.OnChanged = func(value string) {
+		onChanged(value, "mines", &option.NumMines)
+	}
+
+	editorContainer := container.NewVBox(
+		container.NewGridWithRows(1, widget.NewLabel("Rows:"), rowsInput),
+		container.NewGridWithRows(1, widget.NewLabel("Columns:"), colsInput),
+		container.NewGridWithRows(1, widget.NewLabel("Mines:"), minesInput),
+	)
+
+	if userPresets != nil {
+		nameInput := widget.NewEntry()
+		nameInput.SetPlaceHolder("Preset name")
+		editorContainer.Add(container.NewGridWithRows(1, nameInput, widget.NewButton("Save preset", func() {
+			name := nameInput.Text
+			if _, ok := config.SizeOptions[name]; ok {
+				errorLabel.SetText(fmt.Sprintf("The difficulty '%v' already exists", name))
+				return
+			}
+			if inputError != nil {
+				errorLabel.SetText(inputError.Error())
+				return
+			}
+
+			error := userPresets.Save(name, option)
+			if error != nil {
+				errorLabel.SetText(error.Error())
+				return
+			}
+
+			nameInput.SetText("")
+			onSave(name, option)
+		})))
+	}
+
+	editorContainer.Add(errorLabel)
+
+	rowsInput.SetText("16")
+	colsInput.SetText("30")
+	minesInput.SetText("99")
+
+	return editorContainer
+}
+
+/*
+removeLabel returns the labels without the provided one.
+*/
+func removeLabel(labels []string, label string) []string {
+	output := make([]string, 0, len(labels))
+	for _, value := range labels {
+		if value != label {
+			output = append(output, value)
+		}
+	}
+
+	return output
+}
+
+/*
+createSafeStartSelect creates the CanvasObject with the first click options.
+*/
+func createSafeStartSelect(callback func(safeStart int)) fyne.CanvasObject {
+	optionLabels := []string{"Random opening", "Safe first click", "Safe first click area"}
+	optionValues := map[string]int{
+		optionLabels[0]: configs.SafeStartNone,
+		optionLabels[1]: configs.SafeStartTile,
+		optionLabels[2]: configs.SafeStartArea,
+	}
+
+	container := container.NewGridWithRows(1)
+
+	container.Add(widget.NewLabel("First click:"))
+	selectWidget := widget.NewSelect(optionLabels, func(value string) {
+		callback(optionValues[value])
+	})
+	container.Add(selectWidget)
+
+	selectWidget.SetSelectedIndex(0)
+
+	return container
+}
+
+/*
+createTopologySelect creates the CanvasObject with the tile shape options.
+*/
+func createTopologySelect(callback func(topology int)) fyne.CanvasObject {
+	optionLabels := []string{"Square", "Hexagonal"}
+	optionValues := map[string]int{
+		optionLabels[0]: configs.TopologySquare,
+		optionLabels[1]: configs.TopologyHex,
+	}
+
+	container := container.NewGridWithRows(1)
+
+	container.Add(widget.NewLabel("Tiles:"))
+	selectWidget := widget.NewSelect(optionLabels, func(value string) {
+		callback(optionValues[value])
+	})
 	container.Add(selectWidget)
 
 	selectWidget.SetSelectedIndex(0)
@@ -93,6 +425,72 @@ func createDifficultySelect(config *configs.Configs, callback func(option config
 	return container
 }
 
+/*
+createWrapEdgesCheck creates the CanvasObject with the wrap-around edges check.
+*/
+func createWrapEdgesCheck(callback func(checked bool)) *widget.Check {
+	return widget.NewCheck("Wrap-around edges", callback)
+}
+
+/*
+boardTopology returns the topology of a board with the tile shape, one of the
+configs.Topology* constants, and edges that wrap around if requested.
+Only boards of square tiles can wrap around.
+*/
+func boardTopology(tileShape int, wrapEdges bool) int {
+	if wrapEdges && tileShape == configs.TopologySquare {
+		return configs.TopologyTorus
+	}
+
+	return tileShape
+}
+
+/*
+createLayersSelect creates the CanvasObject with the number of layers of the
+board.
+*/
+func createLayersSelect(callback func(numLayers int)) fyne.CanvasObject {
+	optionLabels := []string{"1", "2", "3", "4", "5"}
+
+	container := container.NewGridWithRows(1)
+
+	container.Add(widget.NewLabel("Layers:"))
+	selectWidget := widget.NewSelect(optionLabels, func(value string) {
+		numLayers, error := strconv.Atoi(value)
+		if error != nil {
+			fmt.Printf("Error converting the number of layers to integer: %v\n", error)
+			return
+		}
+
+		callback(numLayers)
+	})
+	container.Add(selectWidget)
+
+	selectWidget.SetSelectedIndex(0)
+
+	return container
+}
+
+/*
+maskedLayerTiles returns the masked tiles of a board with layers, where the
+mask is repeated on every layer.
+*/
+func maskedLayerTiles(boardMask *mask.Mask, numLayers int) []int {
+	if boardMask.MaskedTiles == nil || numLayers <= 1 {
+		return boardMask.MaskedTiles
+	}
+
+	layerSize := boardMask.NumRows * boardMask.NumCols
+	maskedTiles := make([]int, 0, numLayers*len(boardMask.MaskedTiles))
+	for layerIndex := 0; layerIndex < numLayers; layerIndex++ {
+		for _, tileIndex := range boardMask.MaskedTiles {
+			maskedTiles = append(maskedTiles, layerIndex*layerSize+tileIndex)
+		}
+	}
+
+	return maskedTiles
+}
+
 /*
 createNumLivesInput creates the CanvasObject for the number of lives.
 */
@@ -111,18 +509,19 @@ func createNumLivesInput(callback func(value string)) fyne.CanvasObject {
 }
 
 /*
-createSeedInput creates the CanvasObject for the number of lives.
+createSeedInput creates the CanvasObject for the seed, which can also be a share
+code.
 */
 func createSeedInput(callback func(value string)) fyne.CanvasObject {
 	container := container.NewGridWithRows(1)
 
-	container.Add(widget.NewLabel("Seed:"))
+	container.Add(widget.NewLabel("Seed or share code:"))
 
 	inputWidget := widget.NewEntry()
 	inputWidget.OnChanged = callback
 	container.Add(inputWidget)
 
-	inputWidget.SetText(strconv.FormatInt(time.Now().Unix(), 10))
+	inputWidget.SetText(minefield.NewSeed())
 
 	return container
 }
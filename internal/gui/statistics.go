@@ -0,0 +1,86 @@
+package gui
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/statistics"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// The option of the statistics screen with the games of every difficulty
+const allDifficultiesLabel string = "All difficulties"
+
+/*
+createStatisticsGui generates the CanvasObject for the statistics screen, with
+the statistics of the selected difficulty.
+*/
+func createStatisticsGui(config *configs.Configs, stats statistics.IStatistics, back func()) fyne.CanvasObject {
+	difficulties := make([]string, 0, len(config.SizeOptions))
+	for key := range config.SizeOptions {
+		difficulties = append(difficulties, key)
+	}
+	sort.Slice(difficulties, func(i, j int) bool {
+		return config.SizeOptions[difficulties[i]].NumMines < config.SizeOptions[difficulties[j]].NumMines
+	})
+
+	summaryContainer := container.NewGridWithColumns(2)
+	histogramContainer := container.NewGridWithColumns(2)
+
+	difficultySelect := widget.NewSelect(append([]string{allDifficultiesLabel}, difficulties...), func(value string) {
+		summary := stats.Overall()
+		if value != allDifficultiesLabel {
+			summary = stats.Summary(value)
+		}
+
+		summaryContainer.RemoveAll()
+		for _, row := range [][2]string{
+			{"Games played", fmt.Sprint(summary.GamesPlayed)},
+			{"Games won", fmt.Sprint(summary.GamesWon)},
+			{"Win rate", fmt.Sprintf("%.1f%%", summary.WinRate)},
+			{"Current streak", fmt.Sprint(summary.CurrentStreak)},
+			{"Longest streak", fmt.Sprint(summary.LongestStreak)},
+			{"Average time", fmt.Sprint(summary.AverageTime.Truncate(time.Millisecond))},
+			{"Median time", fmt.Sprint(summary.MedianTime.Truncate(time.Millisecond))},
+			{"Tiles revealed", fmt.Sprint(summary.TilesRevealed)},
+		} {
+			summaryContainer.Add(widget.NewLabel(row[0]))
+			summaryContainer.Add(widget.NewLabel(row[1]))
+		}
+		summaryContainer.Refresh()
+
+		maxCount := 0
+		for _, bucket := range summary.Histogram {
+			if bucket.Count > maxCount {
+				maxCount = bucket.Count
+			}
+		}
+
+		histogramContainer.RemoveAll()
+		for _, bucket := range summary.Histogram {
+			label := fmt.Sprintf("%v - %v (%v)", bucket.From.Truncate(time.Second), bucket.To.Truncate(time.Second), bucket.Count)
+			bar := widget.NewProgressBar()
+			bar.TextFormatter = func() string { return "" }
+			bar.SetValue(float64(bucket.Count) / float64(maxCount))
+
+			histogramContainer.Add(widget.NewLabel(label))
+			histogramContainer.Add(bar)
+		}
+		histogramContainer.Refresh()
+	})
+
+	navContainer := container.NewGridWithRows(1)
+	navContainer.Add(widget.NewButton("Back", back))
+	navContainer.Add(difficultySelect)
+
+	difficultySelect.SetSelectedIndex(0)
+
+	return container.NewVBox(navContainer, summaryContainer,
+		widget.NewLabelWithStyle("Completion times", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		histogramContainer)
+}
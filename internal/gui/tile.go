@@ -2,12 +2,15 @@ package gui
 
 import (
 	"fmt"
+	"image/color"
+	"sync"
 	"time"
 
 	"github.com/pedrohenriques/go-minesweeper/internal/configs"
 	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/widget"
 )
 
@@ -17,21 +20,31 @@ var _ fyne.CanvasObject = (*tileButton)(nil)
 const noButtonClick = -1
 const clickDelayMS = 200
 
+// ClickTimer holds the click waiting to be handled, until it is known if the
+// other button was also clicked.
+// The timer callbacks run on their own goroutines, so the mutex is held while
+// the fields are used.
 type clickTimer struct {
-	timer  time.Timer
+	mutex  sync.Mutex
+	timer  *time.Timer
 	button int
+	// Increased on every new click, so a callback can tell if its click was
+	// already handled as part of a click with both buttons
+	clickId int
 }
 
 type ITileWidget interface {
 	updateWidget(forceReveal bool)
+	highlight()
 }
 
 // tileButton represents a revealed tile on the board.
 type tileButton struct {
 	widget.Button
-	rowIndex       int
-	colIndex       int
-	tile           minefield.ITile
+	rowIndex int
+	colIndex int
+	// Returns the current state of the linked tile
+	tile           func() minefield.ITile
 	primaryClick   func(rowIndex int, colIndex int)
 	secondaryClick func(rowIndex int, colIndex int)
 	bothClick      func(rowIndex int, colIndex int)
@@ -42,67 +55,99 @@ type tileButton struct {
 updateWidget sets the state of the widget based on the linked tile state.
 */
 func (t *tileButton) updateWidget(forceReveal bool) {
-	if t.tile.Revealed() || forceReveal {
-		if t.tile.HasFlag() {
-			if !t.tile.HasMine() {
+	t.Importance = widget.MediumImportance
+	tile := t.tile()
+
+	if tile.Revealed() || forceReveal {
+		if tile.HasFlag() {
+			if !tile.HasMine() {
 				t.SetIcon(resourceIncorrectFlagPng)
 			}
-		} else if t.tile.HasMine() {
+		} else if tile.HasMine() {
 			t.SetIcon(resourceMinePng)
-		} else if t.tile.AdjacentMines() > 0 {
+		} else if tile.AdjacentMines() > 0 {
 			t.SetIcon(nil)
-			t.SetText(fmt.Sprint(fmt.Sprint(t.tile.AdjacentMines())))
+			t.SetText(fmt.Sprint(fmt.Sprint(tile.AdjacentMines())))
 		} else {
 			t.SetIcon(nil)
 			t.Disable()
 		}
-	} else if t.tile.HasFlag() {
-		t.SetIcon(resourceFlagPng)
 	} else {
-		t.SetIcon(nil)
+		// The tile may have been hidden again by an undo
+		t.SetText("")
+		t.Enable()
+
+		if tile.HasFlag() {
+			t.SetIcon(resourceFlagPng)
+		} else {
+			t.SetIcon(nil)
+		}
 	}
 }
 
+/*
+highlight marks the widget as the tile suggested by a hint, until the widget is
+updated again.
+*/
+func (t *tileButton) highlight() {
+	t.Importance = widget.HighImportance
+	t.Refresh()
+}
+
 /*
 Tapped handles LMB clicks.
 */
 func (t *tileButton) Tapped(_ *fyne.PointEvent) {
-	if t.clickTimer.button == noButtonClick {
-		t.clickTimer.button = configs.PrimaryClick
-		t.clickTimer.timer = *time.AfterFunc(clickDelayMS*time.Millisecond, func() {
-			t.clickTimer.button = noButtonClick
-			t.primaryClick(t.rowIndex, t.colIndex)
-		})
-	} else if t.clickTimer.button != configs.PrimaryClick {
-		t.clickTimer.timer.Stop()
-		t.clickTimer.button = noButtonClick
-
-		t.bothClick(t.rowIndex, t.colIndex)
-	}
+	t.clickTimer.handleClick(configs.PrimaryClick, t.rowIndex, t.colIndex, t.primaryClick, t.bothClick)
 }
 
 /*
 TappedSecondary handles RMB clicks.
 */
 func (t *tileButton) TappedSecondary(_ *fyne.PointEvent) {
-	if t.clickTimer.button == noButtonClick {
-		t.clickTimer.button = configs.SecondaryClick
-		t.clickTimer.timer = *time.AfterFunc(clickDelayMS*time.Millisecond, func() {
-			t.clickTimer.button = noButtonClick
-			t.secondaryClick(t.rowIndex, t.colIndex)
+	t.clickTimer.handleClick(configs.SecondaryClick, t.rowIndex, t.colIndex, t.secondaryClick, t.bothClick)
+}
+
+/*
+handleClick waits for the other button to be clicked before calling the
+callback of the clicked button.
+If the other button was already clicked, bothClick is called instead.
+*/
+func (c *clickTimer) handleClick(button int, rowIndex int, colIndex int, callback func(rowIndex int, colIndex int), bothClick func(rowIndex int, colIndex int)) {
+	c.mutex.Lock()
+
+	if c.button == noButtonClick {
+		c.button = button
+		c.clickId++
+		clickId := c.clickId
+		c.timer = time.AfterFunc(clickDelayMS*time.Millisecond, func() {
+			c.mutex.Lock()
+			pending := c.clickId == clickId && c.button != noButtonClick
+			if pending {
+				c.button = noButtonClick
+			}
+			c.mutex.Unlock()
+
+			if pending {
+				callback(rowIndex, colIndex)
+			}
 		})
-	} else if t.clickTimer.button != configs.SecondaryClick {
-		t.clickTimer.timer.Stop()
-		t.clickTimer.button = noButtonClick
+		c.mutex.Unlock()
+	} else if c.button != button {
+		c.timer.Stop()
+		c.button = noButtonClick
+		c.mutex.Unlock()
 
-		t.bothClick(t.rowIndex, t.colIndex)
+		bothClick(rowIndex, colIndex)
+	} else {
+		c.mutex.Unlock()
 	}
 }
 
 type newTileWidgetArgs struct {
 	rowIndex       int
 	colIndex       int
-	tile           minefield.ITile
+	tile           func() minefield.ITile
 	primaryClick   func(rowIndex int, colIndex int)
 	secondaryClick func(rowIndex int, colIndex int)
 	bothClick      func(rowIndex int, colIndex int)
@@ -129,3 +174,24 @@ func newTileWidget(args newTileWidgetArgs) (fyne.CanvasObject, ITileWidget) {
 
 	return button, button
 }
+
+// maskedTile represents a cell of the grid removed from the board by a mask,
+// which is drawn as empty space and never changes
+type maskedTile struct{}
+
+/*
+updateWidget does nothing, the cell has no tile.
+*/
+func (t *maskedTile) updateWidget(forceReveal bool) {}
+
+/*
+highlight does nothing, the cell has no tile.
+*/
+func (t *maskedTile) highlight() {}
+
+/*
+newMaskedTileWidget creates the empty space of a cell removed by a mask.
+*/
+func newMaskedTileWidget(args newTileWidgetArgs) (fyne.CanvasObject, ITileWidget) {
+	return canvas.NewRectangle(color.Transparent), &maskedTile{}
+}
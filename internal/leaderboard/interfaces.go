@@ -0,0 +1,26 @@
+package leaderboard
+
+import (
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+)
+
+type ILeaderboard interface {
+	/*
+		Add records the won game, played on the difficulty, for the player and
+		writes the leaderboard to the user's configuration directory.
+		Returns the one-indexed rank of the record in its category, or 0 if it is
+		not fast enough to be kept.
+	*/
+	Add(difficulty string, player string, gameInstance game.IGame) (int, error)
+	/*
+		Qualifies returns true if a game won in the provided time would be kept in
+		the category.
+	*/
+	Qualifies(recordCategory category, time time.Duration) bool
+	/*
+		Records returns the records of the category, fastest first.
+	*/
+	Records(recordCategory category) []record
+}
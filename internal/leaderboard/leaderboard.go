@@ -0,0 +1,265 @@
+/*
+Package leaderboard keeps the best win times of each difficulty, in a file of
+the user's configuration directory
+*/
+package leaderboard
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/pedrohenriques/go-minesweeper/internal/storage"
+)
+
+// The name of the file where the leaderboard is kept
+const fileName string = "leaderboard.json"
+
+// The version of the leaderboard file format
+const leaderboardVersion int = 1
+
+// The number of records kept in each category
+const maxRecords int = 10
+
+// Error: The leaderboard file was written by an incompatible version
+type unsupportedLeaderboardVersionError struct {
+	Version int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e unsupportedLeaderboardVersionError) Error() string {
+	return fmt.Sprintf("The leaderboard version '%v' is not supported", e.Version)
+}
+
+// Error: Only won games can be recorded
+type gameNotWonError struct {
+	State int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e gameNotWonError) Error() string {
+	return fmt.Sprintf("A game with the state '%v' can not be recorded, only won games can", e.State)
+}
+
+// Category groups the records that are comparable with each other
+type category struct {
+	// The key of the configs.SizeOption the games were played on
+	Difficulty string
+	// True for games with more than one life
+	MultipleLives bool
+	// True for games where a hint was used
+	HintAssisted bool
+	// True for games where an action was undone
+	UndoAssisted bool
+}
+
+// Record describes a won game
+type record struct {
+	Player string
+	// The time taken to win the game
+	Time time.Duration
+	// When the game was won
+	Date         time.Time
+	Seed         string
+	Lives        int
+	FlagsEnabled bool
+}
+
+// CategoryRecords contains the records of a category, as written to the file
+type categoryRecords struct {
+	Category category
+	Records  []record
+}
+
+// LeaderboardDocument contains everything written to the leaderboard file
+type leaderboardDocument struct {
+	Version    int
+	Categories []categoryRecords
+}
+
+// Leaderboard holds the records of every category, fastest first
+type leaderboard struct {
+	mutex   sync.Mutex
+	records map[category][]record
+}
+
+/*
+Load reads the leaderboard from the user's configuration directory.
+If no leaderboard was written yet an empty one is returned.
+*/
+func Load() (ILeaderboard, error) {
+	output := &leaderboard{
+		records: map[category][]record{},
+	}
+
+	data, error := storage.Read(fileName)
+	if errors.Is(error, fs.ErrNotExist) {
+		return output, nil
+	}
+	if error != nil {
+		return nil, error
+	}
+
+	document := leaderboardDocument{}
+	error = json.Unmarshal(data, &document)
+	if error != nil {
+		return nil, error
+	}
+	if document.Version != leaderboardVersion {
+		return nil, unsupportedLeaderboardVersionError{
+			Version: document.Version,
+		}
+	}
+
+	for _, entry := range document.Categories {
+		output.records[entry.Category] = entry.Records
+	}
+
+	return output, nil
+}
+
+/*
+Difficulty returns the key of the size option the game configuration matches.
+Returns false for custom sizes and boards with another topology, with masked
+tiles or with more than one layer, which are not recorded.
+*/
+func Difficulty(config *configs.Configs, gameConfig game.GameConfig) (string, bool) {
+	if gameConfig.Topology != configs.TopologySquare || gameConfig.MaskedTiles != nil || gameConfig.NumLayers > 1 {
+		return "", false
+	}
+
+	for key, option := range config.SizeOptions {
+		if option.NumRows == gameConfig.NumRows &&
+			option.NumCols == gameConfig.NumCols &&
+			option.NumMines == gameConfig.NumMines {
+			return key, true
+		}
+	}
+
+	return "", false
+}
+
+/*
+NewCategory returns the category of the games played on the difficulty, with
+more than one life, with hints and with undone actions as requested.
+*/
+func NewCategory(difficulty string, multipleLives bool, hintAssisted bool, undoAssisted bool) category {
+	return category{
+		Difficulty:    difficulty,
+		MultipleLives: multipleLives,
+		HintAssisted:  hintAssisted,
+		UndoAssisted:  undoAssisted,
+	}
+}
+
+/*
+CategoryOf returns the category a game played on the difficulty is recorded in.
+*/
+func CategoryOf(difficulty string, gameInstance game.IGame) category {
+	gameStats := gameInstance.Stats()
+	return NewCategory(difficulty, gameInstance.GameConfig().Lives > 1, gameStats.HintAssisted, gameStats.UndoAssisted)
+}
+
+/*
+Add records the won game, played on the difficulty, for the player and writes
+the leaderboard to the user's configuration directory.
+Returns the one-indexed rank of the record in its category, or 0 if it is not
+fast enough to be kept.
+*/
+func (leaderboard *leaderboard) Add(difficulty string, player string, gameInstance game.IGame) (int, error) {
+	if state := gameInstance.State(); state != configs.StateWin {
+		return 0, gameNotWonError{
+			State: state,
+		}
+	}
+
+	gameConfig := gameInstance.GameConfig()
+	gameStats := gameInstance.Stats()
+	newRecord := record{
+		Player:       player,
+		Time:         gameStats.EndTime.Sub(gameStats.StartTime),
+		Date:         gameStats.EndTime,
+		Seed:         gameConfig.Seed,
+		Lives:        gameConfig.Lives,
+		FlagsEnabled: gameConfig.FlagsEnabled,
+	}
+	recordCategory := CategoryOf(difficulty, gameInstance)
+
+	leaderboard.mutex.Lock()
+	defer leaderboard.mutex.Unlock()
+
+	records := leaderboard.records[recordCategory]
+	rank := sort.Search(len(records), func(index int) bool {
+		return records[index].Time > newRecord.Time
+	})
+	if rank >= maxRecords {
+		return 0, nil
+	}
+
+	records = append(records[:rank:rank], append([]record{newRecord}, records[rank:]...)...)
+	if len(records) > maxRecords {
+		records = records[:maxRecords]
+	}
+	leaderboard.records[recordCategory] = records
+
+	return rank + 1, leaderboard.write()
+}
+
+/*
+Qualifies returns true if a game won in the provided time would be kept in the
+category.
+*/
+func (leaderboard *leaderboard) Qualifies(recordCategory category, time time.Duration) bool {
+	leaderboard.mutex.Lock()
+	defer leaderboard.mutex.Unlock()
+
+	records := leaderboard.records[recordCategory]
+	return len(records) < maxRecords || time < records[len(records)-1].Time
+}
+
+/*
+Records returns the records of the category, fastest first.
+*/
+func (leaderboard *leaderboard) Records(recordCategory category) []record {
+	leaderboard.mutex.Lock()
+	defer leaderboard.mutex.Unlock()
+
+	return append([]record{}, leaderboard.records[recordCategory]...)
+}
+
+/*
+write replaces the leaderboard file with the current records.
+The mutex must be held.
+*/
+func (leaderboard *leaderboard) write() error {
+	document := leaderboardDocument{
+		Version:    leaderboardVersion,
+		Categories: []categoryRecords{},
+	}
+	for recordCategory, records := range leaderboard.records {
+		document.Categories = append(document.Categories, categoryRecords{
+			Category: recordCategory,
+			Records:  records,
+		})
+	}
+	sort.Slice(document.Categories, func(i int, j int) bool {
+		return fmt.Sprint(document.Categories[i].Category) < fmt.Sprint(document.Categories[j].Category)
+	})
+
+	data, error := json.Marshal(document)
+	if error != nil {
+		return error
+	}
+
+	return storage.Write(fileName, data)
+}
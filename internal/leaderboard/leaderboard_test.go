@@ -0,0 +1,236 @@
+package leaderboard_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/pedrohenriques/go-minesweeper/internal/leaderboard"
+	"github.com/pedrohenriques/go-minesweeper/internal/storage"
+	"github.com/pedrohenriques/go-minesweeper/internal/storage/storagetest"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type leaderboardTestSuite struct {
+	suite.Suite
+	sutArgs game.GameConfig
+	// The time read by the games, moved forward by the tests
+	now time.Time
+}
+
+func (suite *leaderboardTestSuite) SetupTest() {
+	storagetest.UseTempDir(suite.T())
+	suite.now = time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	// The only mine is on the center tile, so every other tile must be revealed
+	suite.sutArgs = game.GameConfig{
+		NumRows:      3,
+		NumCols:      3,
+		NumMines:     1,
+		FlagsEnabled: true,
+		Lives:        1,
+		Seed:         "hello",
+		MineTiles:    []int{4},
+	}
+}
+
+/*
+wonGame plays a game to a win, taking the provided time after the first reveal.
+*/
+func (suite *leaderboardTestSuite) wonGame(delay time.Duration) game.IGame {
+	gameInstance, err := game.GenerateWithClock(suite.sutArgs, func() time.Time {
+		return suite.now
+	})
+	require.Nil(suite.T(), err)
+
+	for _, tileIndex := range []int{0, 1, 2, 3, 5, 6, 7, 8} {
+		gameInstance.RevealTile(tileIndex/3, tileIndex%3)
+		if tileIndex == 0 {
+			suite.now = suite.now.Add(delay)
+		}
+	}
+	require.Equal(suite.T(), configs.StateWin, gameInstance.State())
+
+	return gameInstance
+}
+
+func (suite *leaderboardTestSuite) TestLoadReturnsAnEmptyLeaderboardIfNoneWasWritten() {
+	sut, err := leaderboard.Load()
+
+	require.Nil(suite.T(), err)
+	require.Empty(suite.T(), sut.Records(leaderboard.NewCategory("Easy", false, false, false)))
+}
+
+func (suite *leaderboardTestSuite) TestAddKeepsTheDetailsOfTheWonGame() {
+	gameInstance := suite.wonGame(0)
+	sut, _ := leaderboard.Load()
+
+	rank, err := sut.Add("Easy", "ana", gameInstance)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), 1, rank)
+	actual := sut.Records(leaderboard.CategoryOf("Easy", gameInstance))
+	require.Equal(suite.T(), 1, len(actual))
+	require.Equal(suite.T(), "ana", actual[0].Player)
+	require.Equal(suite.T(), "hello", actual[0].Seed)
+	require.Equal(suite.T(), 1, actual[0].Lives)
+	require.Equal(suite.T(), true, actual[0].FlagsEnabled)
+	require.Equal(suite.T(), gameInstance.Stats().EndTime, actual[0].Date)
+	require.Equal(suite.T(), gameInstance.Stats().EndTime.Sub(gameInstance.StartTime()), actual[0].Time)
+}
+
+func (suite *leaderboardTestSuite) TestAddReturnsAnErrorIfTheGameWasNotWon() {
+	gameInstance, _ := game.Generate(suite.sutArgs)
+	sut, _ := leaderboard.Load()
+
+	_, err := sut.Add("Easy", "ana", gameInstance)
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *leaderboardTestSuite) TestRecordsAreOrderedFastestFirst() {
+	sut, _ := leaderboard.Load()
+	sut.Add("Easy", "slow", suite.wonGame(20*time.Millisecond))
+
+	rank, _ := sut.Add("Easy", "fast", suite.wonGame(0))
+
+	require.Equal(suite.T(), 1, rank)
+	actual := sut.Records(leaderboard.NewCategory("Easy", false, false, false))
+	require.Equal(suite.T(), "fast", actual[0].Player)
+	require.Equal(suite.T(), "slow", actual[1].Player)
+}
+
+func (suite *leaderboardTestSuite) TestOnlyTheFastestRecordsOfACategoryAreKept() {
+	sut, _ := leaderboard.Load()
+	for index := 0; index < 10; index++ {
+		sut.Add("Easy", "fast", suite.wonGame(0))
+	}
+	slowGame := suite.wonGame(20 * time.Millisecond)
+
+	require.Equal(suite.T(), false, sut.Qualifies(leaderboard.NewCategory("Easy", false, false, false), slowGame.Stats().EndTime.Sub(slowGame.StartTime())))
+	rank, err := sut.Add("Easy", "slow", slowGame)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), 0, rank)
+	require.Equal(suite.T(), 10, len(sut.Records(leaderboard.NewCategory("Easy", false, false, false))))
+}
+
+func (suite *leaderboardTestSuite) TestGamesWithMoreThanOneLifeOrHintsAreRecordedInSeparateCategories() {
+	sut, _ := leaderboard.Load()
+	suite.sutArgs.Lives = 3
+	livesGame := suite.wonGame(0)
+	suite.sutArgs.Lives = 1
+	hintGame, _ := game.Generate(suite.sutArgs)
+	hintGame.Hint()
+	for _, tileIndex := range []int{0, 1, 2, 3, 5, 6, 7, 8} {
+		hintGame.RevealTile(tileIndex/3, tileIndex%3)
+	}
+
+	sut.Add("Easy", "lives", livesGame)
+	sut.Add("Easy", "hint", hintGame)
+
+	require.Empty(suite.T(), sut.Records(leaderboard.NewCategory("Easy", false, false, false)))
+	require.Equal(suite.T(), "lives", sut.Records(leaderboard.NewCategory("Easy", true, false, false))[0].Player)
+	require.Equal(suite.T(), "hint", sut.Records(leaderboard.NewCategory("Easy", false, true, false))[0].Player)
+}
+
+func (suite *leaderboardTestSuite) TestALossThatWasUndoneAndThenWonIsNotRecordedAsAGameWithoutUndo() {
+	sut, _ := leaderboard.Load()
+	undoGame, _ := game.Generate(suite.sutArgs)
+	undoGame.RevealTile(1, 1)
+	require.Equal(suite.T(), configs.StateLoss, undoGame.State())
+	undoGame.Undo()
+	for _, tileIndex := range []int{0, 1, 2, 3, 5, 6, 7, 8} {
+		undoGame.RevealTile(tileIndex/3, tileIndex%3)
+	}
+
+	sut.Add("Easy", "undo", undoGame)
+
+	require.Empty(suite.T(), sut.Records(leaderboard.NewCategory("Easy", false, false, false)))
+	require.Equal(suite.T(), "undo", sut.Records(leaderboard.NewCategory("Easy", false, false, true))[0].Player)
+}
+
+func (suite *leaderboardTestSuite) TestLoadReadsTheRecordsThatWereAdded() {
+	first, _ := leaderboard.Load()
+	first.Add("Easy", "ana", suite.wonGame(0))
+
+	sut, err := leaderboard.Load()
+
+	require.Nil(suite.T(), err)
+	expected := first.Records(leaderboard.NewCategory("Easy", false, false, false))
+	actual := sut.Records(leaderboard.NewCategory("Easy", false, false, false))
+	require.Equal(suite.T(), 1, len(actual))
+	require.Equal(suite.T(), expected[0].Player, actual[0].Player)
+	require.Equal(suite.T(), expected[0].Time, actual[0].Time)
+	require.Equal(suite.T(), true, expected[0].Date.Equal(actual[0].Date))
+}
+
+func (suite *leaderboardTestSuite) TestLoadReturnsAnErrorForAnUnsupportedVersion() {
+	storage.Write("leaderboard.json", []byte(`{"Version": 99}`))
+
+	_, err := leaderboard.Load()
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *leaderboardTestSuite) TestDifficultyReturnsTheKeyOfTheMatchingSizeOption() {
+	config := &configs.Configs{
+		SizeOptions: map[string]configs.SizeOption{
+			"Easy":   {NumRows: 3, NumCols: 3, NumMines: 1},
+			"Medium": {NumRows: 16, NumCols: 16, NumMines: 40},
+		},
+	}
+
+	actual, ok := leaderboard.Difficulty(config, suite.sutArgs)
+	require.Equal(suite.T(), true, ok)
+	require.Equal(suite.T(), "Easy", actual)
+
+	suite.sutArgs.NumMines = 2
+	_, ok = leaderboard.Difficulty(config, suite.sutArgs)
+	require.Equal(suite.T(), false, ok)
+}
+
+func (suite *leaderboardTestSuite) TestDifficultyDoesNotMatchABoardOfHexagonalTiles() {
+	config := &configs.Configs{
+		SizeOptions: map[string]configs.SizeOption{
+			"Easy": {NumRows: 3, NumCols: 3, NumMines: 1},
+		},
+	}
+	suite.sutArgs.Topology = configs.TopologyHex
+
+	_, ok := leaderboard.Difficulty(config, suite.sutArgs)
+
+	require.Equal(suite.T(), false, ok)
+}
+
+func (suite *leaderboardTestSuite) TestDifficultyDoesNotMatchABoardWithMaskedTiles() {
+	config := &configs.Configs{
+		SizeOptions: map[string]configs.SizeOption{
+			"Easy": {NumRows: 3, NumCols: 3, NumMines: 1},
+		},
+	}
+	suite.sutArgs.MaskedTiles = []int{0}
+
+	_, ok := leaderboard.Difficulty(config, suite.sutArgs)
+
+	require.Equal(suite.T(), false, ok)
+}
+
+func (suite *leaderboardTestSuite) TestDifficultyDoesNotMatchABoardWithLayers() {
+	config := &configs.Configs{
+		SizeOptions: map[string]configs.SizeOption{
+			"Easy": {NumRows: 3, NumCols: 3, NumMines: 1},
+		},
+	}
+	suite.sutArgs.NumLayers = 2
+
+	_, ok := leaderboard.Difficulty(config, suite.sutArgs)
+
+	require.Equal(suite.T(), false, ok)
+}
+
+func TestLeaderboardSuite(t *testing.T) {
+	suite.Run(t, new(leaderboardTestSuite))
+}
@@ -0,0 +1,179 @@
+/*
+Package mask reads the shapes of boards that are not rectangles, from a text or
+PNG file, as the cells of a grid that are not tiles of the board
+*/
+package mask
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"image/color"
+	"image/png"
+	"strings"
+)
+
+// The first bytes of every PNG file
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// The character of a text mask for a cell that is a tile of the board
+const tileChar rune = '#'
+
+// The character of a text mask for a cell that is not a tile of the board
+const holeChar rune = '.'
+
+// Error: A line of a text mask has a character that is not a tile or a hole
+type invalidCharacterError struct {
+	LineNumber int
+	Char       string
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e invalidCharacterError) Error() string {
+	return fmt.Sprintf("The line '%v' has the character '%v', which is not '#' or '.'", e.LineNumber, e.Char)
+}
+
+// Error: A line of a text mask does not have as many cells as the first line
+type invalidRowLengthError struct {
+	LineNumber int
+	NumCols    int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e invalidRowLengthError) Error() string {
+	return fmt.Sprintf("The line '%v' does not have '%v' cells, like the first line", e.LineNumber, e.NumCols)
+}
+
+// Error: The mask has no cell that is a tile of the board
+type emptyMaskError struct{}
+
+/*
+Error prints the message for this error.
+*/
+func (e emptyMaskError) Error() string {
+	return "The mask does not have any tile"
+}
+
+// Mask contains the size of a board's grid and the cells that are not tiles
+type Mask struct {
+	NumRows int
+	NumCols int
+	// The indexes of the cells that are not tiles, row by row, or nil if every
+	// cell is a tile
+	MaskedTiles []int
+}
+
+/*
+Parse reads a mask from a PNG image or, if the data is not a PNG, from text.
+A text mask has one line per row, with '#' for the tiles and '.' for the cells
+that are not tiles, and the empty lines are ignored.
+On a PNG image each pixel is a cell, which is a tile if the pixel is dark and
+not transparent.
+Returns an error if the mask can not be read or does not have any tile.
+*/
+func Parse(data []byte) (Mask, error) {
+	var mask Mask
+	var error error
+	if bytes.HasPrefix(data, pngSignature) {
+		mask, error = parsePNG(data)
+	} else {
+		mask, error = parseText(data)
+	}
+	if error != nil {
+		return Mask{}, error
+	}
+
+	if len(mask.MaskedTiles) == mask.NumRows*mask.NumCols {
+		return Mask{}, emptyMaskError{}
+	}
+	if len(mask.MaskedTiles) == 0 {
+		mask.MaskedTiles = nil
+	}
+
+	return mask, nil
+}
+
+/*
+parseText reads a mask with one line per row.
+*/
+func parseText(data []byte) (Mask, error) {
+	mask := Mask{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		cells := []rune(line)
+		if mask.NumRows == 0 {
+			mask.NumCols = len(cells)
+		} else if len(cells) != mask.NumCols {
+			return Mask{}, invalidRowLengthError{
+				LineNumber: lineNumber,
+				NumCols:    mask.NumCols,
+			}
+		}
+
+		for colIndex, cell := range cells {
+			switch cell {
+			case tileChar:
+			case holeChar:
+				mask.MaskedTiles = append(mask.MaskedTiles, mask.NumRows*mask.NumCols+colIndex)
+			default:
+				return Mask{}, invalidCharacterError{
+					LineNumber: lineNumber,
+					Char:       string(cell),
+				}
+			}
+		}
+		mask.NumRows++
+	}
+
+	return mask, scanner.Err()
+}
+
+/*
+parsePNG reads a mask with one cell per pixel.
+*/
+func parsePNG(data []byte) (Mask, error) {
+	image, error := png.Decode(bytes.NewReader(data))
+	if error != nil {
+		return Mask{}, error
+	}
+
+	bounds := image.Bounds()
+	mask := Mask{
+		NumRows: bounds.Dy(),
+		NumCols: bounds.Dx(),
+	}
+
+	for rowIndex := 0; rowIndex < mask.NumRows; rowIndex++ {
+		for colIndex := 0; colIndex < mask.NumCols; colIndex++ {
+			pixel := image.At(bounds.Min.X+colIndex, bounds.Min.Y+rowIndex)
+			if !darkPixel(pixel) {
+				mask.MaskedTiles = append(mask.MaskedTiles, rowIndex*mask.NumCols+colIndex)
+			}
+		}
+	}
+
+	return mask, nil
+}
+
+/*
+darkPixel returns true if the pixel is more dark than light and more opaque
+than transparent.
+*/
+func darkPixel(pixel color.Color) bool {
+	_, _, _, alpha := pixel.RGBA()
+	gray := color.Gray16Model.Convert(pixel).(color.Gray16)
+
+	return alpha >= 0x8000 && gray.Y < 0x8000
+}
@@ -0,0 +1,118 @@
+package mask_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/mask"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type maskTestSuite struct {
+	suite.Suite
+}
+
+/*
+encodePNG creates a PNG image where each row is a string with one character per
+pixel.
+# = black | . = white | ' ' = transparent
+*/
+func encodePNG(test *testing.T, rows ...string) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, len(rows[0]), len(rows)))
+	for rowIndex, row := range rows {
+		for colIndex, char := range row {
+			switch char {
+			case '#':
+				img.Set(colIndex, rowIndex, color.Black)
+			case '.':
+				img.Set(colIndex, rowIndex, color.White)
+			}
+		}
+	}
+
+	var buffer bytes.Buffer
+	require.Nil(test, png.Encode(&buffer, img))
+	return buffer.Bytes()
+}
+
+func (suite *maskTestSuite) TestParseReadsTheSizeAndTheHolesOfATextMask() {
+	expected := mask.Mask{
+		NumRows:     3,
+		NumCols:     4,
+		MaskedTiles: []int{0, 3, 5, 6},
+	}
+
+	actual, err := mask.Parse([]byte(".##.\n#..#\n####\n"))
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), expected, actual)
+}
+
+func (suite *maskTestSuite) TestParseIgnoresTheEmptyLinesAndTheSpacesAroundTheRowsOfATextMask() {
+	expected := mask.Mask{
+		NumRows:     2,
+		NumCols:     2,
+		MaskedTiles: []int{1},
+	}
+
+	actual, err := mask.Parse([]byte("\n  #.\r\n\n##  \n\n"))
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), expected, actual)
+}
+
+func (suite *maskTestSuite) TestParseReturnsNilMaskedTilesIfEveryCellIsATile() {
+	actual, err := mask.Parse([]byte("##\n##"))
+
+	require.Nil(suite.T(), err)
+	require.Nil(suite.T(), actual.MaskedTiles)
+}
+
+func (suite *maskTestSuite) TestParseReturnsAnErrorIfATextMaskHasAnUnknownCharacter() {
+	_, err := mask.Parse([]byte("##\n#x"))
+
+	require.EqualError(suite.T(), err, "The line '2' has the character 'x', which is not '#' or '.'")
+}
+
+func (suite *maskTestSuite) TestParseReturnsAnErrorIfTheRowsOfATextMaskDoNotHaveTheSameLength() {
+	_, err := mask.Parse([]byte("###\n##"))
+
+	require.EqualError(suite.T(), err, "The line '2' does not have '3' cells, like the first line")
+}
+
+func (suite *maskTestSuite) TestParseReturnsAnErrorIfTheMaskDoesNotHaveAnyTile() {
+	for _, data := range [][]byte{[]byte(""), []byte("..\n.."), encodePNG(suite.T(), ". ")} {
+		_, err := mask.Parse(data)
+
+		require.EqualError(suite.T(), err, "The mask does not have any tile")
+	}
+}
+
+func (suite *maskTestSuite) TestParseReadsTheDarkOpaquePixelsOfAPNGMaskAsTiles() {
+	expected := mask.Mask{
+		NumRows:     2,
+		NumCols:     3,
+		MaskedTiles: []int{1, 2, 3},
+	}
+
+	actual, err := mask.Parse(encodePNG(suite.T(), "#. ", " ##"))
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), expected, actual)
+}
+
+func (suite *maskTestSuite) TestParseReturnsAnErrorIfAPNGMaskCanNotBeDecoded() {
+	data := encodePNG(suite.T(), "##")
+
+	_, err := mask.Parse(data[:len(data)/2])
+
+	require.NotNil(suite.T(), err)
+}
+
+func TestMaskSuite(t *testing.T) {
+	suite.Run(t, new(maskTestSuite))
+}
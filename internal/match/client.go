@@ -0,0 +1,269 @@
+package match
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+)
+
+// The time after which a request to the server fails
+const clientTimeout time.Duration = 10 * time.Second
+
+// The number of actions that can be waiting to be sent to the server
+const clientQueueSize int = 256
+
+// Error: The server answered a request with an error
+type serverResponseError struct {
+	StatusCode int
+	Message    string
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e serverResponseError) Error() string {
+	return fmt.Sprintf("The server answered with the status '%v': '%v'", e.StatusCode, e.Message)
+}
+
+// Error: The queue of the actions waiting to be sent to the server is full
+type queueFullError struct {
+	QueueSize int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e queueFullError) Error() string {
+	return fmt.Sprintf("The '%v' actions waiting to be sent to the server have not been sent yet", e.QueueSize)
+}
+
+// ClientAction is an action waiting to be sent to the server
+type clientAction struct {
+	// One of "reveal", "flag" or "chord"
+	action   string
+	rowIndex int
+	colIndex int
+}
+
+// Client plays a player's game of a match hosted by the server package
+type client struct {
+	serverURL  string
+	gameId     string
+	matchId    string
+	player     int
+	gameConfig game.GameConfig
+	// The player's game, whose board is kept by the server
+	remoteGame game.IRemoteGame
+	httpClient *http.Client
+	// Held while an action is queued or the actions are closed
+	mutex   sync.Mutex
+	actions chan clientAction
+	// True once the client is closed, after which the actions are not sent
+	closed bool
+}
+
+/*
+Join connects to the game, with the provided ID, of a match hosted by the
+server at the provided URL.
+The player's game shows the server's board, which is not sent to the player,
+and its actions are sent to the server with SendAction.
+*/
+func Join(serverURL string, gameId string) (IClient, error) {
+	newClient := &client{
+		serverURL:  strings.TrimRight(serverURL, "/"),
+		gameId:     gameId,
+		httpClient: &http.Client{Timeout: clientTimeout},
+		actions:    make(chan clientAction, clientQueueSize),
+	}
+
+	joinResponse := struct {
+		MatchID string
+		Player  int
+		Config  game.GameConfig
+	}{}
+	error := newClient.request(http.MethodGet, "/games/"+gameId+"/match", nil, &joinResponse)
+	if error != nil {
+		return nil, error
+	}
+
+	newClient.matchId = joinResponse.MatchID
+	newClient.player = joinResponse.Player
+	newClient.gameConfig = joinResponse.Config
+
+	newClient.remoteGame, error = game.NewRemote(joinResponse.Config, newClient.SendAction)
+	if error != nil {
+		return nil, error
+	}
+
+	boardResponse := struct {
+		State int
+		Tiles []game.RemoteTile
+	}{}
+	error = newClient.request(http.MethodGet, "/games/"+gameId+"/board", nil, &boardResponse)
+	if error != nil {
+		return nil, error
+	}
+	for tileIndex := range boardResponse.Tiles {
+		boardResponse.Tiles[tileIndex].TileIndex = tileIndex
+	}
+	newClient.remoteGame.Update(boardResponse.Tiles, boardResponse.State)
+
+	go newClient.sendActions()
+
+	return newClient, nil
+}
+
+/*
+GameConfig returns the configuration of the player's game, without the match's
+seed and mine tiles.
+*/
+func (client *client) GameConfig() game.GameConfig {
+	return client.gameConfig
+}
+
+/*
+Game returns the player's game, which shows the server's board and sends its
+actions to the server.
+*/
+func (client *client) Game() game.IGame {
+	return client.remoteGame
+}
+
+/*
+Player returns the zero-indexed number of the player in the match.
+*/
+func (client *client) Player() int {
+	return client.player
+}
+
+/*
+SendAction queues an action to be sent to the server, which applies it to the
+player's game.
+The actions are sent in order, the tiles they changed are given to the player's
+game and the failures are logged.
+Once the client is closed the actions are ignored.
+Returns an error, without waiting, if the queue is full because the server
+stopped answering.
+*/
+func (client *client) SendAction(action string, rowIndex int, colIndex int) error {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if client.closed {
+		return nil
+	}
+
+	select {
+	case client.actions <- clientAction{
+		action:   action,
+		rowIndex: rowIndex,
+		colIndex: colIndex,
+	}:
+		return nil
+	default:
+		return queueFullError{QueueSize: clientQueueSize}
+	}
+}
+
+/*
+Status returns the progress of every player and the outcome of the match.
+*/
+func (client *client) Status() (status, error) {
+	matchStatus := status{}
+	error := client.request(http.MethodGet, "/matches/"+client.matchId, nil, &matchStatus)
+
+	return matchStatus, error
+}
+
+/*
+Close stops sending actions to the server, after the queued actions are sent.
+Closing the client again does nothing.
+*/
+func (client *client) Close() {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if client.closed {
+		return
+	}
+
+	client.closed = true
+	close(client.actions)
+}
+
+/*
+sendActions sends the queued actions until the client is closed, and updates the
+player's game with the server's answers.
+*/
+func (client *client) sendActions() {
+	for action := range client.actions {
+		body := map[string]int{
+			"RowIndex": action.rowIndex,
+			"ColIndex": action.colIndex,
+		}
+
+		actionResponse := struct {
+			Tiles []game.RemoteTile
+			State int
+		}{}
+		error := client.request(http.MethodPost, "/games/"+client.gameId+"/"+action.action, body, &actionResponse)
+		if error != nil {
+			log.Println(error)
+			continue
+		}
+
+		client.remoteGame.Update(actionResponse.Tiles, actionResponse.State)
+	}
+}
+
+/*
+request sends a request to the server with the body as JSON, if not nil, and
+decodes the JSON of the response into the provided value, if not nil.
+*/
+func (client *client) request(method string, path string, body interface{}, response interface{}) error {
+	requestBody := []byte{}
+	if body != nil {
+		var error error
+		requestBody, error = json.Marshal(body)
+		if error != nil {
+			return error
+		}
+	}
+
+	request, error := http.NewRequest(method, client.serverURL+path, bytes.NewReader(requestBody))
+	if error != nil {
+		return error
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	httpResponse, error := client.httpClient.Do(request)
+	if error != nil {
+		return error
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode >= http.StatusBadRequest {
+		errorBody := struct {
+			Error string
+		}{}
+		json.NewDecoder(httpResponse.Body).Decode(&errorBody)
+
+		return serverResponseError{
+			StatusCode: httpResponse.StatusCode,
+			Message:    errorBody.Error,
+		}
+	}
+
+	if response == nil {
+		return nil
+	}
+
+	return json.NewDecoder(httpResponse.Body).Decode(response)
+}
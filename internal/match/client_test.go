@@ -0,0 +1,143 @@
+package match_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/match"
+	"github.com/pedrohenriques/go-minesweeper/internal/server"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type clientTestSuite struct {
+	suite.Suite
+	httpServer *httptest.Server
+	gameIds    []string
+}
+
+func (suite *clientTestSuite) SetupTest() {
+	suite.httpServer = httptest.NewServer(server.NewHandler(time.Minute))
+
+	response, err := http.Post(suite.httpServer.URL+"/matches", "application/json",
+		strings.NewReader(`{"NumPlayers": 2, "Config": {"NumRows": 3, "NumCols": 3, "NumMines": 1, "Lives": 1, "FlagsEnabled": true, "MineTiles": [0]}}`))
+	require.Nil(suite.T(), err)
+	defer response.Body.Close()
+
+	body := struct {
+		GameIDs []string
+	}{}
+	json.NewDecoder(response.Body).Decode(&body)
+	suite.gameIds = body.GameIDs
+}
+
+func (suite *clientTestSuite) TearDownTest() {
+	suite.httpServer.Close()
+}
+
+func (suite *clientTestSuite) TestJoinReturnsThePlayerAndTheConfigOfTheMatch() {
+	sut, err := match.Join(suite.httpServer.URL, suite.gameIds[1])
+	require.Nil(suite.T(), err)
+	defer sut.Close()
+
+	require.Equal(suite.T(), 1, sut.Player())
+	require.Equal(suite.T(), 3, sut.GameConfig().NumRows)
+}
+
+func (suite *clientTestSuite) TestJoinDoesNotGiveThePlayerTheSeedNorTheMinesOfTheBoard() {
+	sut, _ := match.Join(suite.httpServer.URL, suite.gameIds[1])
+	defer sut.Close()
+
+	require.Empty(suite.T(), sut.GameConfig().Seed)
+	require.Nil(suite.T(), sut.GameConfig().MineTiles)
+	tile, err := sut.Game().Tile(0, 0)
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), false, tile.HasMine())
+}
+
+func (suite *clientTestSuite) TestJoinReturnsAnErrorIfTheGameDoesNotExist() {
+	_, err := match.Join(suite.httpServer.URL, "unknown")
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *clientTestSuite) TestSendActionAppliesTheActionsToThePlayersGameOnTheServer() {
+	sut, _ := match.Join(suite.httpServer.URL, suite.gameIds[1])
+	defer sut.Close()
+
+	sut.SendAction("reveal", 2, 2)
+
+	require.Eventually(suite.T(), func() bool {
+		status, err := sut.Status()
+		return err == nil && status.Result.Done
+	}, 5*time.Second, 10*time.Millisecond)
+
+	actual, _ := sut.Status()
+	require.Equal(suite.T(), 1, actual.Result.Winner)
+	require.Equal(suite.T(), 100.0, actual.Progress[1].Percentage)
+	require.Equal(suite.T(), 0.0, actual.Progress[0].Percentage)
+}
+
+func (suite *clientTestSuite) TestTheActionsOfThePlayersGameShowTheTilesChangedOnTheServer() {
+	sut, _ := match.Join(suite.httpServer.URL, suite.gameIds[1])
+	defer sut.Close()
+
+	sut.Game().RevealTile(1, 1)
+
+	require.Eventually(suite.T(), func() bool {
+		tile, _ := sut.Game().Tile(1, 1)
+		return tile.Revealed()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	tile, _ := sut.Game().Tile(1, 1)
+	require.Equal(suite.T(), 1, tile.AdjacentMines())
+	require.Equal(suite.T(), configs.StateOnGoing, sut.Game().State())
+}
+
+func (suite *clientTestSuite) TestSendActionDoesNothingOnceTheClientIsClosed() {
+	sut, _ := match.Join(suite.httpServer.URL, suite.gameIds[1])
+	sut.Close()
+
+	require.NotPanics(suite.T(), func() {
+		sut.SendAction("reveal", 2, 2)
+		sut.Close()
+	})
+
+	actual, _ := sut.Status()
+	require.Equal(suite.T(), 0.0, actual.Progress[1].Percentage)
+}
+
+func (suite *clientTestSuite) TestSendActionReturnsAnErrorInsteadOfWaitingIfTheServerStopsAnswering() {
+	// A server that does not answer the actions until the test ends
+	serverURL, _ := url.Parse(suite.httpServer.URL)
+	proxy := httputil.NewSingleHostReverseProxy(serverURL)
+	release := make(chan struct{})
+	stalledServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method == http.MethodPost {
+			<-release
+		}
+		proxy.ServeHTTP(writer, request)
+	}))
+	defer stalledServer.Close()
+	defer close(release)
+	sut, _ := match.Join(stalledServer.URL, suite.gameIds[1])
+
+	var err error
+	for numActions := 0; err == nil && numActions < 1000; numActions++ {
+		err = sut.SendAction("flag", 1, 1)
+	}
+	sut.Close()
+
+	require.EqualError(suite.T(), err, "The '256' actions waiting to be sent to the server have not been sent yet")
+}
+
+func TestClientSuite(t *testing.T) {
+	suite.Run(t, new(clientTestSuite))
+}
@@ -0,0 +1,78 @@
+package match
+
+import (
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+)
+
+type IMatch interface {
+	/*
+		GameConfig returns the configuration shared by the players' games,
+		including the seed.
+	*/
+	GameConfig() game.GameConfig
+	/*
+		NumPlayers returns the number of players in the match.
+	*/
+	NumPlayers() int
+	/*
+		Game returns the game of the player with the provided zero-indexed
+		number.
+	*/
+	Game(player int) (game.IGame, error)
+	/*
+		Progress returns how far each player is in clearing the board, ordered
+		by player.
+	*/
+	Progress() []progress
+	/*
+		Result returns the outcome of the match.
+		The first player to win is the winner. If every player lost, the player
+		that revealed the most safe tiles wins and, on a tie, the one that took
+		the least time.
+	*/
+	Result() result
+	/*
+		Status returns the progress of every player and the outcome of the
+		match.
+	*/
+	Status() status
+}
+
+type IClient interface {
+	/*
+		GameConfig returns the configuration of the player's game, without the
+		match's seed and mine tiles.
+	*/
+	GameConfig() game.GameConfig
+	/*
+		Game returns the player's game, which shows the server's board and sends
+		its actions to the server.
+	*/
+	Game() game.IGame
+	/*
+		Player returns the zero-indexed number of the player in the match.
+	*/
+	Player() int
+	/*
+		SendAction queues an action to be sent to the server, which applies it
+		to the player's game.
+		The action is one of "reveal", "flag" or "chord".
+		The actions are sent in order, the tiles they changed are given to the
+		player's game and the failures are logged.
+		Once the client is closed the actions are ignored.
+		Returns an error, without waiting, if the queue is full because the
+		server stopped answering.
+	*/
+	SendAction(action string, rowIndex int, colIndex int) error
+	/*
+		Status returns the progress of every player and the outcome of the
+		match.
+	*/
+	Status() (status, error)
+	/*
+		Close stops sending actions to the server, after the queued actions are
+		sent.
+		Closing the client again does nothing.
+	*/
+	Close()
+}
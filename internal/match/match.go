@@ -0,0 +1,245 @@
+/*
+Package match coordinates races between players on identical boards
+*/
+package match
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
+)
+
+// The minimum number of players in a match
+const minPlayers int = 2
+
+// Error: The match does not have enough players
+type notEnoughPlayersError struct {
+	NumPlayers int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e notEnoughPlayersError) Error() string {
+	return fmt.Sprintf("A match with '%v' players is not valid, at least '%v' are needed", e.NumPlayers, minPlayers)
+}
+
+// Error: The player is not in the match
+type playerNotFoundError struct {
+	Player int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e playerNotFoundError) Error() string {
+	return fmt.Sprintf("The player '%v' is not in the match", e.Player)
+}
+
+// Progress contains how far a player is in clearing the board
+type progress struct {
+	Player int
+	// One of the configs.State* constants
+	State int
+	// The number of revealed tiles that do not have a mine
+	RevealedSafeTiles int
+	// The number of tiles that do not have a mine
+	SafeTiles int
+	// The percentage of the safe tiles that are revealed, from 0 to 100
+	Percentage float64
+	StartTime  time.Time
+	EndTime    time.Time
+}
+
+// Result describes the outcome of a match
+type result struct {
+	// True once a player won or every player lost
+	Done bool
+	// The index of the player that won the match, -1 if it is not done
+	Winner int
+}
+
+// Status contains the progress of every player and the outcome of a match
+type status struct {
+	Progress []progress
+	Result   result
+}
+
+// Match contains the games of the players racing on the same board
+type match struct {
+	gameConfig game.GameConfig
+	games      []game.IGame
+}
+
+/*
+New creates a match where every player gets a game generated from the same
+configuration and seed.
+If a seed is not provided one is created and shared by every player.
+Deferred safe starts are not used, since the mines would depend on each
+player's first reveal.
+The match is not safe for concurrent use.
+*/
+func New(gameConfig game.GameConfig, numPlayers int) (IMatch, error) {
+	if numPlayers < minPlayers {
+		return nil, notEnoughPlayersError{
+			NumPlayers: numPlayers,
+		}
+	}
+
+	if gameConfig.Seed == "" {
+		gameConfig.Seed = minefield.NewSeed()
+	}
+	gameConfig.SafeStart = configs.SafeStartNone
+
+	games := make([]game.IGame, numPlayers)
+	for player := range games {
+		gameInstance, error := game.Generate(gameConfig)
+		if error != nil {
+			return nil, error
+		}
+		games[player] = gameInstance
+	}
+
+	return &match{
+		gameConfig: gameConfig,
+		games:      games,
+	}, nil
+}
+
+/*
+GameConfig returns the configuration shared by the players' games, including
+the seed.
+*/
+func (match *match) GameConfig() game.GameConfig {
+	return match.gameConfig
+}
+
+/*
+NumPlayers returns the number of players in the match.
+*/
+func (match *match) NumPlayers() int {
+	return len(match.games)
+}
+
+/*
+Game returns the game of the player with the provided zero-indexed number.
+*/
+func (match *match) Game(player int) (game.IGame, error) {
+	if player < 0 || player >= len(match.games) {
+		return nil, playerNotFoundError{
+			Player: player,
+		}
+	}
+
+	return match.games[player], nil
+}
+
+/*
+Progress returns how far each player is in clearing the board, ordered by
+player.
+*/
+func (match *match) Progress() []progress {
+	output := make([]progress, len(match.games))
+
+	for player, gameInstance := range match.games {
+		gameConfig := gameInstance.Config()
+		gameStats := gameInstance.Stats()
+
+		revealedSafeTiles := 0
+		numTiles := 0
+		for rowIndex := 0; rowIndex < gameConfig.NumRows; rowIndex++ {
+			for colIndex := 0; colIndex < gameConfig.NumCols; colIndex++ {
+				tile, error := gameInstance.Tile(rowIndex, colIndex)
+				if error != nil {
+					continue
+				}
+				numTiles++
+				if tile.Revealed() && !tile.HasMine() {
+					revealedSafeTiles++
+				}
+			}
+		}
+
+		safeTiles := numTiles - gameConfig.NumMines
+		output[player] = progress{
+			Player:            player,
+			State:             gameInstance.State(),
+			RevealedSafeTiles: revealedSafeTiles,
+			SafeTiles:         safeTiles,
+			Percentage:        float64(revealedSafeTiles) * 100 / float64(safeTiles),
+			StartTime:         gameStats.StartTime,
+			EndTime:           gameStats.EndTime,
+		}
+	}
+
+	return output
+}
+
+/*
+Result returns the outcome of the match.
+The first player to win is the winner. If every player lost, the player that
+revealed the most safe tiles wins and, on a tie, the one that took the least
+time.
+*/
+func (match *match) Result() result {
+	allProgress := match.Progress()
+
+	winner := -1
+	for _, playerProgress := range allProgress {
+		if playerProgress.State != configs.StateWin {
+			continue
+		}
+		if winner == -1 || playerProgress.EndTime.Before(allProgress[winner].EndTime) {
+			winner = playerProgress.Player
+		}
+	}
+	if winner != -1 {
+		return result{
+			Done:   true,
+			Winner: winner,
+		}
+	}
+
+	for _, playerProgress := range allProgress {
+		if playerProgress.State == configs.StateOnGoing {
+			return result{
+				Done:   false,
+				Winner: -1,
+			}
+		}
+	}
+
+	for _, playerProgress := range allProgress {
+		if winner == -1 ||
+			playerProgress.RevealedSafeTiles > allProgress[winner].RevealedSafeTiles ||
+			(playerProgress.RevealedSafeTiles == allProgress[winner].RevealedSafeTiles &&
+				playingTime(playerProgress) < playingTime(allProgress[winner])) {
+			winner = playerProgress.Player
+		}
+	}
+
+	return result{
+		Done:   true,
+		Winner: winner,
+	}
+}
+
+/*
+Status returns the progress of every player and the outcome of the match.
+*/
+func (match *match) Status() status {
+	return status{
+		Progress: match.Progress(),
+		Result:   match.Result(),
+	}
+}
+
+/*
+playingTime returns the time between the start and the end of a player's game.
+*/
+func playingTime(playerProgress progress) time.Duration {
+	return playerProgress.EndTime.Sub(playerProgress.StartTime)
+}
@@ -0,0 +1,145 @@
+package match_test
+
+import (
+	"testing"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/pedrohenriques/go-minesweeper/internal/match"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type matchTestSuite struct {
+	suite.Suite
+	sutArgs game.GameConfig
+}
+
+func (suite *matchTestSuite) SetupTest() {
+	suite.sutArgs = game.GameConfig{
+		NumRows:      3,
+		NumCols:      3,
+		NumMines:     1,
+		FlagsEnabled: true,
+		Lives:        1,
+		MineTiles:    []int{0},
+	}
+}
+
+/*
+playerGame returns the game of the player.
+*/
+func (suite *matchTestSuite) playerGame(sut match.IMatch, player int) game.IGame {
+	gameInstance, err := sut.Game(player)
+	require.Nil(suite.T(), err)
+
+	return gameInstance
+}
+
+func (suite *matchTestSuite) TestNewGivesEveryPlayerTheSameBoard() {
+	sut, err := match.New(game.GameConfig{
+		NumRows:  10,
+		NumCols:  11,
+		NumMines: 20,
+		Lives:    1,
+		Seed:     "hello",
+	}, 3)
+	require.Nil(suite.T(), err)
+
+	for player := 1; player < 3; player++ {
+		for rIndex := 0; rIndex < 10; rIndex++ {
+			for cIndex := 0; cIndex < 11; cIndex++ {
+				expected, _ := suite.playerGame(sut, 0).Tile(rIndex, cIndex)
+				actual, _ := suite.playerGame(sut, player).Tile(rIndex, cIndex)
+
+				require.Equal(suite.T(), expected.HasMine(), actual.HasMine())
+				require.Equal(suite.T(), expected.Revealed(), actual.Revealed())
+			}
+		}
+	}
+}
+
+func (suite *matchTestSuite) TestNewSharesAGeneratedSeedAndTurnsOffDeferredSafeStarts() {
+	suite.sutArgs.SafeStart = configs.SafeStartArea
+	sut, _ := match.New(suite.sutArgs, 2)
+
+	require.NotEmpty(suite.T(), sut.GameConfig().Seed)
+	require.Equal(suite.T(), configs.SafeStartNone, sut.GameConfig().SafeStart)
+	require.Equal(suite.T(), sut.GameConfig().Seed, suite.playerGame(sut, 1).GameConfig().Seed)
+}
+
+func (suite *matchTestSuite) TestNewReturnsAnErrorIfThereAreLessThanTwoPlayers() {
+	_, err := match.New(suite.sutArgs, 1)
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *matchTestSuite) TestGameReturnsAnErrorIfThePlayerIsNotInTheMatch() {
+	sut, _ := match.New(suite.sutArgs, 2)
+
+	_, err := sut.Game(2)
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *matchTestSuite) TestProgressReturnsThePercentageOfSafeTilesRevealedByEachPlayer() {
+	sut, _ := match.New(suite.sutArgs, 2)
+	suite.playerGame(sut, 1).RevealTile(1, 1)
+	suite.playerGame(sut, 1).RevealTile(0, 1)
+
+	actual := sut.Progress()
+
+	require.Equal(suite.T(), 0, actual[0].RevealedSafeTiles)
+	require.Equal(suite.T(), 0.0, actual[0].Percentage)
+	require.Equal(suite.T(), 2, actual[1].RevealedSafeTiles)
+	require.Equal(suite.T(), 8, actual[1].SafeTiles)
+	require.Equal(suite.T(), 25.0, actual[1].Percentage)
+	require.Equal(suite.T(), configs.StateOnGoing, actual[1].State)
+}
+
+func (suite *matchTestSuite) TestResultIsNotDoneWhileNoPlayerWonAndSomeArePlaying() {
+	sut, _ := match.New(suite.sutArgs, 2)
+	suite.playerGame(sut, 0).RevealTile(0, 0)
+
+	actual := sut.Result()
+
+	require.Equal(suite.T(), false, actual.Done)
+	require.Equal(suite.T(), -1, actual.Winner)
+}
+
+func (suite *matchTestSuite) TestResultDeclaresTheFirstPlayerToWinAsTheWinner() {
+	sut, _ := match.New(suite.sutArgs, 3)
+	suite.playerGame(sut, 2).RevealTile(2, 2)
+	suite.playerGame(sut, 1).RevealTile(2, 2)
+
+	actual := sut.Result()
+
+	require.Equal(suite.T(), true, actual.Done)
+	require.Equal(suite.T(), 2, actual.Winner)
+}
+
+func (suite *matchTestSuite) TestResultDeclaresThePlayerWithTheBestProgressAsTheWinnerIfEveryoneLost() {
+	sut, _ := match.New(suite.sutArgs, 2)
+	suite.playerGame(sut, 0).RevealTile(0, 0)
+	suite.playerGame(sut, 1).RevealTile(1, 1)
+	suite.playerGame(sut, 1).RevealTile(0, 0)
+
+	actual := sut.Result()
+
+	require.Equal(suite.T(), true, actual.Done)
+	require.Equal(suite.T(), 1, actual.Winner)
+}
+
+func (suite *matchTestSuite) TestStatusReturnsTheProgressAndTheResult() {
+	sut, _ := match.New(suite.sutArgs, 2)
+	suite.playerGame(sut, 1).RevealTile(2, 2)
+
+	actual := sut.Status()
+
+	require.Equal(suite.T(), sut.Progress(), actual.Progress)
+	require.Equal(suite.T(), sut.Result(), actual.Result)
+}
+
+func TestMatchSuite(t *testing.T) {
+	suite.Run(t, new(matchTestSuite))
+}
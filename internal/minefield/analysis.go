@@ -0,0 +1,61 @@
+package minefield
+
+/*
+ThreeBV returns the Bechtel's Board Benchmark Value of the minefield, the
+minimum number of clicks needed to reveal every tile without a mine.
+Each opening, a patch of tiles without adjacent mines, counts once and each
+tile with adjacent mines that does not border an opening counts once.
+If the mines of a deferred safe start are not placed yet 0 is returned.
+*/
+func (minefield *minefield) ThreeBV() int {
+	minefield.mutex.RLock()
+	defer minefield.mutex.RUnlock()
+
+	if !minefield.generated {
+		return 0
+	}
+
+	return threeBV(minefield)
+}
+
+/*
+threeBV counts the openings and the tiles with adjacent mines outside of the
+openings.
+*/
+func threeBV(minefield *minefield) int {
+	covered := make([]bool, len(minefield.tiles))
+	count := 0
+
+	for tileIndex, tile := range minefield.tiles {
+		if covered[tileIndex] || tile.hasMine || tile.adjacentMines != 0 || !minefield.hasTile(tileIndex) {
+			continue
+		}
+
+		count++
+		covered[tileIndex] = true
+		tilesToCheck := []int{tileIndex}
+		for len(tilesToCheck) > 0 {
+			checkIndex := tilesToCheck[len(tilesToCheck)-1]
+			tilesToCheck = tilesToCheck[:len(tilesToCheck)-1]
+
+			for _, adjacentIndex := range minefield.topology.AdjacentTiles(checkIndex) {
+				if covered[adjacentIndex] {
+					continue
+				}
+
+				covered[adjacentIndex] = true
+				if minefield.tiles[adjacentIndex].adjacentMines == 0 {
+					tilesToCheck = append(tilesToCheck, adjacentIndex)
+				}
+			}
+		}
+	}
+
+	for tileIndex, tile := range minefield.tiles {
+		if !covered[tileIndex] && !tile.hasMine && minefield.hasTile(tileIndex) {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,80 @@
+package minefield_test
+
+import (
+	"testing"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type analysisTestSuite struct {
+	suite.Suite
+}
+
+func (suite *analysisTestSuite) TestThreeBVCountsEachTileWithAdjacentMinesWhenThereAreNoOpenings() {
+	/*
+		1  1  2  1  1
+		1  X  2  X  1
+		1  1  2  1  1
+	*/
+	sut, _ := minefield.Generate(minefield.MinefieldConfig{
+		NumRows:   3,
+		NumCols:   5,
+		NumMines:  2,
+		MineTiles: []int{6, 8},
+	})
+
+	require.Equal(suite.T(), 13, sut.ThreeBV())
+}
+
+func (suite *analysisTestSuite) TestThreeBVCountsAnOpeningOnceIncludingTheTilesThatBorderIt() {
+	/*
+		X  1  0
+		1  1  0
+		0  0  0
+	*/
+	sut, _ := minefield.Generate(minefield.MinefieldConfig{
+		NumRows:   3,
+		NumCols:   3,
+		NumMines:  1,
+		MineTiles: []int{0},
+	})
+
+	require.Equal(suite.T(), 1, sut.ThreeBV())
+}
+
+func (suite *analysisTestSuite) TestThreeBVCountsEachOpeningAndTheTilesOutsideOfThem() {
+	/*
+		X  1  X  1  0  1  X  X  1  0
+	*/
+	sut, _ := minefield.Generate(minefield.MinefieldConfig{
+		NumRows:   1,
+		NumCols:   10,
+		NumMines:  4,
+		MineTiles: []int{0, 2, 6, 7},
+	})
+
+	require.Equal(suite.T(), 2+1, sut.ThreeBV())
+}
+
+func (suite *analysisTestSuite) TestThreeBVIsZeroUntilTheMinesOfADeferredSafeStartArePlaced() {
+	sut, _ := minefield.Generate(minefield.MinefieldConfig{
+		NumRows:   10,
+		NumCols:   11,
+		NumMines:  20,
+		Seed:      "hello",
+		SafeStart: configs.SafeStartArea,
+	})
+
+	require.Equal(suite.T(), 0, sut.ThreeBV())
+
+	sut.RevealTile(4, 6)
+	require.Greater(suite.T(), sut.ThreeBV(), 0)
+}
+
+func TestAnalysisSuite(t *testing.T) {
+	suite.Run(t, new(analysisTestSuite))
+}
@@ -11,3 +11,9 @@ The maximum number of patches that will be checked, during the minefield
 generation, before aborting trying to reveal an initial patch
 */
 const initialPatchMaxIterations int = 10
+
+/*
+The default maximum number of boards that will be generated, while searching
+for a board that can be cleared without guessing
+*/
+const noGuessDefaultMaxAttempts int = 1000
@@ -0,0 +1,9 @@
+package minefield
+
+/*
+SolvableWithoutGuessing exposes solvableWithoutGuessing to the tests, for a
+minefield created by Generate.
+*/
+func SolvableWithoutGuessing(instance IMinefield, startTileIndex int) bool {
+	return solvableWithoutGuessing(instance.(*minefield), startTileIndex)
+}
@@ -5,102 +5,208 @@ package minefield
 
 import (
 	"math/rand"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
 )
 
+// The start of the seeds created by NewSeed, followed by a time in nanoseconds
+const timeSeedPrefix string = "t"
+
 type MinefieldConfig struct {
 	NumCols  int
 	NumRows  int
 	NumMines int
 	Seed     string
+	// One of the configs.SafeStart* constants
+	SafeStart int
+	// One of the configs.Topology* constants
+	Topology int
+	// If not nil, used instead of the topology of the Topology constant. Its tile
+	// indexes must be row-major, as rowIndex*NumCols+colIndex
+	CustomTopology ITopology
+	// If not nil, the indexes of the cells of the grid that are not tiles of the
+	// board. They never have a mine and are not adjacent to any tile
+	MaskedTiles []int
+	// If true, only boards that can be cleared without guessing are accepted
+	NoGuess bool
+	// The maximum number of boards generated while searching for a board that
+	// can be cleared without guessing. If zero a default value is used
+	NoGuessMaxAttempts int
+	// If not nil, the indexes of the tiles that have a mine. The mines are placed
+	// exactly on these tiles, no tiles are revealed and the seed, safe start and
+	// no guess options are ignored
+	MineTiles []int
+	// The number of layers of NumRows x NumCols tiles of a minefield created by
+	// GenerateLayered, which ignores the Topology and CustomTopology
+	NumLayers int
 }
 
 /*
 Generate creates a minefield, using the provided configuration, and returns
 a Minefield
+The tile count and the tiles adjacent to each tile are defined by the topology.
+*/
+func Generate(args MinefieldConfig) (IMinefield, error) {
+	minefield, error := generate(args)
+	if error != nil {
+		return nil, error
+	}
+
+	return minefield, nil
+}
+
+/*
+generate creates the minefield returned by Generate.
 */
-func Generate(args MinefieldConfig) IMinefield {
+func generate(args MinefieldConfig) (*minefield, error) {
+	topology := args.CustomTopology
+	if topology == nil {
+		gridTopology, error := NewTopology(args.Topology, args.NumRows, args.NumCols)
+		if error != nil {
+			return nil, error
+		}
+		topology = gridTopology
+	}
+	if args.MaskedTiles != nil {
+		topology = NewMaskedTopology(topology, args.MaskedTiles)
+	}
+
 	minefield := &minefield{
-		cols:  args.NumCols,
-		rows:  args.NumRows,
-		mines: args.NumMines,
-		tiles: make([]tile, args.NumRows*args.NumCols),
+		cols:               args.NumCols,
+		rows:               args.NumRows,
+		mines:              args.NumMines,
+		tiles:              make([]tile, topology.NumTiles()),
+		rng:                seedRng(args.Seed),
+		safeStart:          args.SafeStart,
+		topology:           topology,
+		noGuess:            args.NoGuess,
+		noGuessMaxAttempts: args.NoGuessMaxAttempts,
+		startTileIndex:     -1,
 	}
 
-	seedRng(args.Seed)
+	for tileIndex := range minefield.tiles {
+		if minefield.hasTile(tileIndex) {
+			minefield.numTiles++
+		}
+	}
 
-	populateMines(minefield, &args)
+	if minefield.noGuessMaxAttempts <= 0 {
+		minefield.noGuessMaxAttempts = noGuessDefaultMaxAttempts
+	}
 
-	revealInitialPatch(minefield)
+	if args.MineTiles != nil {
+		error := placeMineLayout(minefield, args.MineTiles)
+		if error != nil {
+			return nil, error
+		}
+		return minefield, nil
+	}
 
-	return minefield
+	if args.SafeStart == configs.SafeStartNone {
+		error := placeMines(minefield, -1)
+		if error != nil {
+			return nil, error
+		}
+	}
+
+	return minefield, nil
+}
+
+/*
+NewSeed creates a seed from the current time, in nanoseconds, which keeps every
+bit of the time when it is given back to Generate.
+*/
+func NewSeed() string {
+	return timeSeedPrefix + strconv.FormatInt(time.Now().UnixNano(), 10)
 }
 
 /*
-Configures the RNG with the provided seed
+Creates an RNG configured with the provided seed
+A seed created by NewSeed is converted back to its time, while the bytes of any
+other seed are added up.
 If a seed is not provided then the current unix timestamp will be used as seed
 */
-func seedRng(seed string) {
-	var convertedSeed int64 = time.Now().Unix()
-	if seed != "" {
+func seedRng(seed string) *rand.Rand {
+	var convertedSeed int64 = time.Now().UnixNano()
+	if timeSeed, ok := parseTimeSeed(seed); ok {
+		convertedSeed = timeSeed
+	} else if seed != "" {
 		var sumBytes int
 		for _, v := range []byte(seed) {
 			sumBytes += int(v)
 		}
 		convertedSeed = int64(sumBytes)
 	}
-	rand.Seed(convertedSeed)
+	return rand.New(rand.NewSource(convertedSeed))
+}
+
+/*
+parseTimeSeed returns the time of a seed created by NewSeed.
+Returns false if the seed was not created by NewSeed.
+*/
+func parseTimeSeed(seed string) (int64, bool) {
+	if !strings.HasPrefix(seed, timeSeedPrefix) {
+		return 0, false
+	}
+
+	timeSeed, error := strconv.ParseInt(seed[len(timeSeedPrefix):], 10, 64)
+	return timeSeed, error == nil
 }
 
 /*
 Populated the minefield with mines and adds the numbers to adjacent tiles
+The tiles with the indexes in safeTileIndexes will not receive a mine
 */
-func populateMines(minefield *minefield, config *MinefieldConfig) {
+func populateMines(minefield *minefield, safeTileIndexes []int) {
+	safeTiles := make(map[int]bool, len(safeTileIndexes))
+	for _, tileIndex := range safeTileIndexes {
+		safeTiles[tileIndex] = true
+	}
+
 	var numMineTiles int
-	for numMineTiles < config.NumMines {
-		tileIndex := rand.Intn(config.NumCols * config.NumRows)
-		rowIndex := tileIndex / config.NumCols
-		colIndex := tileIndex % config.NumCols
+	for numMineTiles < minefield.mines {
+		tileIndex := minefield.rng.Intn(len(minefield.tiles))
 
-		if minefield.tiles[tileIndex].hasMine {
+		if minefield.tiles[tileIndex].hasMine || safeTiles[tileIndex] || !minefield.hasTile(tileIndex) {
 			continue
 		}
 
-		minefield.tiles[tileIndex].hasMine = true
+		addMine(minefield, tileIndex)
 		numMineTiles++
+	}
+}
 
-		for rowOffset := -1; rowOffset <= 1; rowOffset++ {
-			rIndex := rowIndex + rowOffset
-			if rIndex < 0 || rIndex > config.NumRows-1 {
-				continue
-			}
-
-			for colOffset := -1; colOffset <= 1; colOffset++ {
-				if rowOffset == 0 && colOffset == 0 {
-					continue
-				}
-
-				cIndex := colIndex + colOffset
-				if cIndex < 0 || cIndex > config.NumCols-1 {
-					continue
-				}
+/*
+Places a mine on the tile with the provided index and updates the numbers of the
+adjacent tiles
+*/
+func addMine(minefield *minefield, tileIndex int) {
+	minefield.tiles[tileIndex].hasMine = true
 
-				minefield.tiles[rIndex*minefield.cols+cIndex].adjacentMines++
-			}
-		}
+	for _, adjacentIndex := range minefield.topology.AdjacentTiles(tileIndex) {
+		minefield.tiles[adjacentIndex].adjacentMines++
 	}
 }
 
 /*
 Reveals a patch of tiles, complying with the application configuration, to
 facilitate the start of the game
+The patch starts on a tile without a mine nor adjacent mines, so no mine is
+revealed, and nothing is revealed if the minefield has no such tile
 */
 func revealInitialPatch(minefield *minefield) {
+	if !hasPatchFocalTile(minefield) {
+		return
+	}
+
 	iterations := 0
 	for iterations <= initialPatchMaxIterations {
-		focalTileIndex := rand.Intn(minefield.cols * minefield.rows)
+		focalTileIndex := minefield.rng.Intn(len(minefield.tiles))
 
-		if minefield.tiles[focalTileIndex].adjacentMines != 0 && !minefield.tiles[focalTileIndex].hasMine {
+		if !isPatchFocalTile(minefield, focalTileIndex) {
 			continue
 		}
 		iterations++
@@ -118,3 +224,111 @@ func revealInitialPatch(minefield *minefield) {
 		break
 	}
 }
+
+/*
+isPatchFocalTile returns true if the initial patch can start on the tile with the
+provided index, which needs no mine nor adjacent mines.
+*/
+func isPatchFocalTile(minefield *minefield, tileIndex int) bool {
+	tile := minefield.tiles[tileIndex]
+
+	return minefield.hasTile(tileIndex) && !tile.hasMine && tile.adjacentMines == 0
+}
+
+/*
+hasPatchFocalTile returns true if the initial patch can start on any tile of the
+minefield.
+*/
+func hasPatchFocalTile(minefield *minefield) bool {
+	for tileIndex := range minefield.tiles {
+		if isPatchFocalTile(minefield, tileIndex) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+Places the mines in the minefield, complying with its configuration.
+If startTileIndex is negative a random initial patch is revealed, otherwise the
+mines are kept away from the tile with that index, and its adjacent tiles if
+requested by the configuration.
+For a no guess minefield, boards are generated until one can be cleared without
+guessing, starting from the initial patch or the start tile, or the maximum
+number of attempts is reached.
+*/
+func placeMines(minefield *minefield, startTileIndex int) error {
+	safeTileIndexes := []int{}
+	if startTileIndex >= 0 {
+		safeTileIndexes = append(safeTileIndexes, startTileIndex)
+
+		if minefield.safeStart == configs.SafeStartArea {
+			areaTileIndexes := minefield.topology.AdjacentTiles(startTileIndex)
+			if len(areaTileIndexes)+1 <= minefield.numTiles-minefield.mines {
+				safeTileIndexes = append(safeTileIndexes, areaTileIndexes...)
+			}
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		populateMines(minefield, safeTileIndexes)
+		if startTileIndex < 0 {
+			revealInitialPatch(minefield)
+		}
+
+		if !minefield.noGuess || solvableWithoutGuessing(minefield, startTileIndex) {
+			minefield.generated = true
+			return nil
+		}
+
+		clearMines(minefield)
+
+		if attempt >= minefield.noGuessMaxAttempts {
+			return noGuessBoardNotFoundError{
+				Attempts: attempt,
+			}
+		}
+	}
+}
+
+/*
+Places the mines exactly on the tiles with the provided indexes
+*/
+func placeMineLayout(minefield *minefield, mineTileIndexes []int) error {
+	uniqueIndexes := uniqueTileIndexes(mineTileIndexes)
+	if len(uniqueIndexes) != minefield.mines {
+		return mineLayoutCountError{
+			NumMines:     minefield.mines,
+			NumMineTiles: len(uniqueIndexes),
+		}
+	}
+
+	for _, tileIndex := range uniqueIndexes {
+		if tileIndex < 0 || tileIndex > len(minefield.tiles)-1 || !minefield.hasTile(tileIndex) {
+			return tileNotFoundError{
+				RowIndex: tileIndex / minefield.cols,
+				ColIndex: tileIndex % minefield.cols,
+			}
+		}
+	}
+
+	for _, tileIndex := range uniqueIndexes {
+		addMine(minefield, tileIndex)
+	}
+	minefield.generated = true
+
+	return nil
+}
+
+/*
+Removes all the mines, numbers and revealed tiles from the minefield, keeping
+any flags in place
+*/
+func clearMines(minefield *minefield) {
+	for tileIndex := range minefield.tiles {
+		minefield.tiles[tileIndex] = tile{
+			hasFlag: minefield.tiles[tileIndex].hasFlag,
+		}
+	}
+}
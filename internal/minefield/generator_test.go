@@ -1,8 +1,10 @@
 package minefield_test
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
 	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
 
 	"github.com/stretchr/testify/require"
@@ -19,7 +21,10 @@ func (suite *generatorTestSuite) TestItReturnsAMinefieldInstanceWithTheExpectedC
 		NumRows: 1,
 	}
 
-	require.Equal(suite.T(), 5, minefield.Generate(*args).Cols())
+	sut, err := minefield.Generate(*args)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), 5, sut.Cols())
 }
 
 func (suite *generatorTestSuite) TestItReturnsAMinefieldInstanceWithTheExpectedRowsValue() {
@@ -28,7 +33,10 @@ func (suite *generatorTestSuite) TestItReturnsAMinefieldInstanceWithTheExpectedR
 		NumCols: 1,
 	}
 
-	require.Equal(suite.T(), 3, minefield.Generate(*args).Rows())
+	sut, err := minefield.Generate(*args)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), 3, sut.Rows())
 }
 
 func (suite *generatorTestSuite) TestItReturnsAMinefieldInstanceWithTheExpectedMinesValue() {
@@ -38,7 +46,10 @@ func (suite *generatorTestSuite) TestItReturnsAMinefieldInstanceWithTheExpectedM
 		NumRows:  10,
 	}
 
-	require.Equal(suite.T(), 9, minefield.Generate(*args).Mines())
+	sut, err := minefield.Generate(*args)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), 9, sut.Mines())
 }
 
 func (suite *generatorTestSuite) TestItReturnsAMinefieldInstanceWithTheExpectedTilesValue() {
@@ -49,7 +60,7 @@ func (suite *generatorTestSuite) TestItReturnsAMinefieldInstanceWithTheExpectedT
 		Seed:     "hello",
 	}
 
-	minefield := minefield.Generate(*args)
+	minefield, _ := minefield.Generate(*args)
 
 	type tile struct {
 		revealed      bool
@@ -194,7 +205,7 @@ func (suite *generatorTestSuite) TestIfTheFirstPatchToRevealIsTooSmallItSearches
 		Seed:     "hellos",
 	}
 
-	minefield := minefield.Generate(*args)
+	minefield, _ := minefield.Generate(*args)
 
 	type tile struct {
 		revealed      bool
@@ -331,6 +342,326 @@ func (suite *generatorTestSuite) TestIfTheFirstPatchToRevealIsTooSmallItSearches
 	}
 }
 
+func (suite *generatorTestSuite) TestTheInitialPatchDoesNotRevealAMineOnASmallCrowdedBoard() {
+	for numMines := 5; numMines <= 8; numMines++ {
+		for seed := 0; seed < 100; seed++ {
+			args := &minefield.MinefieldConfig{
+				NumCols:  3,
+				NumRows:  3,
+				NumMines: numMines,
+				Seed:     fmt.Sprint(seed),
+			}
+
+			sut, err := minefield.Generate(*args)
+			require.Nil(suite.T(), err)
+
+			for tileIndex := 0; tileIndex < 9; tileIndex++ {
+				tile, _ := sut.Tile(tileIndex/3, tileIndex%3)
+				require.Equalf(suite.T(), false, tile.Revealed() && tile.HasMine(), "mines: %v | seed: %v | tile index: %v", numMines, seed, tileIndex)
+			}
+		}
+	}
+}
+
+func (suite *generatorTestSuite) TestItDoesNotPlaceMinesOrRevealTilesBeforeTheFirstRevealIfASafeStartIsRequested() {
+	args := &minefield.MinefieldConfig{
+		NumCols:   9,
+		NumRows:   9,
+		NumMines:  10,
+		Seed:      "hello",
+		SafeStart: configs.SafeStartTile,
+	}
+
+	sut, _ := minefield.Generate(*args)
+
+	for rIndex := 0; rIndex < args.NumRows; rIndex++ {
+		for cIndex := 0; cIndex < args.NumCols; cIndex++ {
+			tile, err := sut.Tile(rIndex, cIndex)
+
+			require.Nil(suite.T(), err)
+			require.Equalf(suite.T(), false, tile.HasMine(), "row index: %v | col index: %v", rIndex, cIndex)
+			require.Equalf(suite.T(), false, tile.Revealed(), "row index: %v | col index: %v", rIndex, cIndex)
+		}
+	}
+}
+
+func (suite *generatorTestSuite) TestItPlacesAllTheMinesOnTheFirstRevealIfASafeStartIsRequested() {
+	args := &minefield.MinefieldConfig{
+		NumCols:   9,
+		NumRows:   9,
+		NumMines:  10,
+		Seed:      "hello",
+		SafeStart: configs.SafeStartTile,
+	}
+
+	sut, _ := minefield.Generate(*args)
+	sut.RevealTile(0, 0)
+
+	numMines := 0
+	for rIndex := 0; rIndex < args.NumRows; rIndex++ {
+		for cIndex := 0; cIndex < args.NumCols; cIndex++ {
+			tile, _ := sut.Tile(rIndex, cIndex)
+			if tile.HasMine() {
+				numMines++
+			}
+		}
+	}
+
+	require.Equal(suite.T(), 10, numMines)
+}
+
+func (suite *generatorTestSuite) TestItKeepsTheFirstRevealedTileFreeOfMinesIfASafeStartTileIsRequested() {
+	args := &minefield.MinefieldConfig{
+		NumCols:   3,
+		NumRows:   3,
+		NumMines:  8,
+		Seed:      "hello",
+		SafeStart: configs.SafeStartTile,
+	}
+
+	sut, _ := minefield.Generate(*args)
+	revealedIndexes, err := sut.RevealTile(1, 1)
+
+	tile, _ := sut.Tile(1, 1)
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{4}, revealedIndexes)
+	require.Equal(suite.T(), false, tile.HasMine())
+	require.Equal(suite.T(), 8, tile.AdjacentMines())
+}
+
+func (suite *generatorTestSuite) TestItKeepsTheFirstRevealedTileAndItsAdjacentTilesFreeOfMinesIfASafeStartAreaIsRequested() {
+	args := &minefield.MinefieldConfig{
+		NumCols:   9,
+		NumRows:   9,
+		NumMines:  72,
+		Seed:      "hello",
+		SafeStart: configs.SafeStartArea,
+	}
+
+	sut, _ := minefield.Generate(*args)
+	revealedIndexes, _ := sut.RevealTile(4, 4)
+
+	for rIndex := 3; rIndex <= 5; rIndex++ {
+		for cIndex := 3; cIndex <= 5; cIndex++ {
+			tile, _ := sut.Tile(rIndex, cIndex)
+			require.Equalf(suite.T(), false, tile.HasMine(), "row index: %v | col index: %v", rIndex, cIndex)
+		}
+	}
+	require.Equal(suite.T(), 9, len(revealedIndexes))
+}
+
+func (suite *generatorTestSuite) TestItOnlyKeepsTheFirstRevealedTileFreeOfMinesIfTheSafeStartAreaDoesNotFitTheMines() {
+	args := &minefield.MinefieldConfig{
+		NumCols:   3,
+		NumRows:   3,
+		NumMines:  8,
+		Seed:      "hello",
+		SafeStart: configs.SafeStartArea,
+	}
+
+	sut, _ := minefield.Generate(*args)
+	sut.RevealTile(1, 1)
+
+	tile, _ := sut.Tile(1, 1)
+	require.Equal(suite.T(), false, tile.HasMine())
+	require.Equal(suite.T(), 8, tile.AdjacentMines())
+}
+
+func (suite *generatorTestSuite) TestItPlacesTheSameMinesForTheSameSeedAndFirstRevealIfASafeStartIsRequested() {
+	args := &minefield.MinefieldConfig{
+		NumCols:   16,
+		NumRows:   16,
+		NumMines:  40,
+		Seed:      "hello",
+		SafeStart: configs.SafeStartArea,
+	}
+
+	first, _ := minefield.Generate(*args)
+	first.RevealTile(7, 3)
+	second, _ := minefield.Generate(*args)
+	second.RevealTile(7, 3)
+
+	for rIndex := 0; rIndex < args.NumRows; rIndex++ {
+		for cIndex := 0; cIndex < args.NumCols; cIndex++ {
+			firstTile, _ := first.Tile(rIndex, cIndex)
+			secondTile, _ := second.Tile(rIndex, cIndex)
+			require.Equalf(suite.T(), firstTile.HasMine(), secondTile.HasMine(), "row index: %v | col index: %v", rIndex, cIndex)
+		}
+	}
+}
+
+/*
+mineLayout returns the indexes of the tiles with a mine of a minefield generated
+with the seed.
+*/
+func mineLayout(test *testing.T, seed string) []int {
+	sut, err := minefield.Generate(minefield.MinefieldConfig{
+		NumCols:  30,
+		NumRows:  16,
+		NumMines: 99,
+		Seed:     seed,
+	})
+	require.Nil(test, err)
+
+	tileIndexes := []int{}
+	for tileIndex := 0; tileIndex < 30*16; tileIndex++ {
+		tile, _ := sut.Tile(tileIndex/30, tileIndex%30)
+		if tile.HasMine() {
+			tileIndexes = append(tileIndexes, tileIndex)
+		}
+	}
+	return tileIndexes
+}
+
+func (suite *generatorTestSuite) TestItPlacesDifferentMinesForDifferentDefaultSeeds() {
+	// The seeds have the same bytes, in another order
+	first := mineLayout(suite.T(), "t1760000000000000001")
+	second := mineLayout(suite.T(), "t1760000000000000010")
+
+	require.NotEqual(suite.T(), first, second)
+}
+
+func (suite *generatorTestSuite) TestItPlacesTheSameMinesForTheSameDefaultSeed() {
+	seed := minefield.NewSeed()
+
+	require.Equal(suite.T(), mineLayout(suite.T(), seed), mineLayout(suite.T(), seed))
+}
+
+func (suite *generatorTestSuite) TestItReturnsANoGuessMinefieldWithTheExpectedMines() {
+	args := &minefield.MinefieldConfig{
+		NumCols:  30,
+		NumRows:  16,
+		NumMines: 99,
+		Seed:     "hello",
+		NoGuess:  true,
+	}
+
+	sut, err := minefield.Generate(*args)
+
+	require.Nil(suite.T(), err)
+	numMines := 0
+	for rIndex := 0; rIndex < args.NumRows; rIndex++ {
+		for cIndex := 0; cIndex < args.NumCols; cIndex++ {
+			tile, _ := sut.Tile(rIndex, cIndex)
+			if tile.HasMine() {
+				numMines++
+			}
+		}
+	}
+	require.Equal(suite.T(), 99, numMines)
+}
+
+func (suite *generatorTestSuite) TestItReturnsTheSameNoGuessMinefieldForTheSameSeed() {
+	args := &minefield.MinefieldConfig{
+		NumCols:   30,
+		NumRows:   16,
+		NumMines:  99,
+		Seed:      "hello",
+		SafeStart: configs.SafeStartArea,
+		NoGuess:   true,
+	}
+
+	first, _ := minefield.Generate(*args)
+	_, firstErr := first.RevealTile(8, 15)
+	second, _ := minefield.Generate(*args)
+	_, secondErr := second.RevealTile(8, 15)
+
+	require.Nil(suite.T(), firstErr)
+	require.Nil(suite.T(), secondErr)
+	for rIndex := 0; rIndex < args.NumRows; rIndex++ {
+		for cIndex := 0; cIndex < args.NumCols; cIndex++ {
+			firstTile, _ := first.Tile(rIndex, cIndex)
+			secondTile, _ := second.Tile(rIndex, cIndex)
+			require.Equalf(suite.T(), firstTile.HasMine(), secondTile.HasMine(), "row index: %v | col index: %v", rIndex, cIndex)
+			require.Equalf(suite.T(), firstTile.Revealed(), secondTile.Revealed(), "row index: %v | col index: %v", rIndex, cIndex)
+		}
+	}
+}
+
+func (suite *generatorTestSuite) TestItReturnsAnErrorIfNoNoGuessMinefieldIsFoundWithinTheMaxAttempts() {
+	args := &minefield.MinefieldConfig{
+		NumCols:            2,
+		NumRows:            2,
+		NumMines:           2,
+		Seed:               "hello",
+		SafeStart:          configs.SafeStartTile,
+		NoGuess:            true,
+		NoGuessMaxAttempts: 5,
+	}
+
+	sut, _ := minefield.Generate(*args)
+	revealedIndexes, err := sut.RevealTile(0, 0)
+
+	require.NotNil(suite.T(), err)
+	require.Nil(suite.T(), revealedIndexes)
+	require.Equal(suite.T(), 0, sut.Stats().NumTilesRevealed)
+}
+
+func (suite *generatorTestSuite) TestItPlacesTheMinesOnTheTilesOfTheProvidedMineLayout() {
+	args := minefield.MinefieldConfig{
+		NumCols:   3,
+		NumRows:   3,
+		NumMines:  2,
+		Seed:      "hello",
+		SafeStart: configs.SafeStartArea,
+		MineTiles: []int{0, 5},
+	}
+	expectedMines := []bool{true, false, false, false, false, true, false, false, false}
+	expectedNumbers := []int{0, 2, 1, 1, 2, 0, 0, 1, 1}
+
+	sut, err := minefield.Generate(args)
+
+	require.Nil(suite.T(), err)
+	for tileIndex := 0; tileIndex < 9; tileIndex++ {
+		tile, _ := sut.Tile(tileIndex/3, tileIndex%3)
+		require.Equalf(suite.T(), expectedMines[tileIndex], tile.HasMine(), "tile index: %v", tileIndex)
+		require.Equalf(suite.T(), expectedNumbers[tileIndex], tile.AdjacentMines(), "tile index: %v", tileIndex)
+		require.Equalf(suite.T(), false, tile.Revealed(), "tile index: %v", tileIndex)
+	}
+}
+
+func (suite *generatorTestSuite) TestItKeepsTheMinesOfTheProvidedMineLayoutOnTheFirstReveal() {
+	args := minefield.MinefieldConfig{
+		NumCols:   3,
+		NumRows:   3,
+		NumMines:  1,
+		SafeStart: configs.SafeStartTile,
+		MineTiles: []int{4},
+	}
+	sut, _ := minefield.Generate(args)
+
+	sut.RevealTile(1, 1)
+
+	tile, _ := sut.Tile(1, 1)
+	require.Equal(suite.T(), true, tile.HasMine())
+}
+
+func (suite *generatorTestSuite) TestItReturnsAnErrorIfTheMineLayoutDoesNotMatchTheNumberOfMines() {
+	args := minefield.MinefieldConfig{
+		NumCols:   3,
+		NumRows:   3,
+		NumMines:  2,
+		MineTiles: []int{4, 4},
+	}
+
+	_, err := minefield.Generate(args)
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *generatorTestSuite) TestItReturnsAnErrorIfTheMineLayoutHasATileOutsideTheMinefield() {
+	args := minefield.MinefieldConfig{
+		NumCols:   3,
+		NumRows:   3,
+		NumMines:  1,
+		MineTiles: []int{9},
+	}
+
+	_, err := minefield.Generate(args)
+
+	require.NotNil(suite.T(), err)
+}
+
 func TestGeneratorSuite(t *testing.T) {
 	suite.Run(t, new(generatorTestSuite))
 }
@@ -13,11 +13,27 @@ type IMinefield interface {
 		Mines returns the number of mines in the minefield.
 	*/
 	Mines() int
+	/*
+		NumTiles returns the number of tiles in the minefield, without the cells
+		removed by a mask.
+	*/
+	NumTiles() int
+	/*
+		HasTile returns true if the minefield has a tile in the requested row and
+		column, and false if it is outside the minefield or removed by a mask.
+	*/
+	HasTile(rowIndex int, colIndex int) bool
 	/*
 		Tile searches for the tile in the requested row and column.
 		The row and column coordinates are zero-indexed.
+		The returned tile is a copy, which is not updated by later actions.
 	*/
 	Tile(rowIndex int, colIndex int) (ITile, error)
+	/*
+		AdjacentTiles returns the indexes of the tiles adjacent to the tile in the
+		requested row and column, according to the minefield's topology.
+	*/
+	AdjacentTiles(rowIndex int, colIndex int) ([]int, error)
 	/*
 		RevealTile reveals the requested tile.
 		If the tile is empty the patch it belongs to will be revealed.
@@ -41,6 +57,73 @@ type IMinefield interface {
 		Stats returns statistics about a minefield.
 	*/
 	Stats() stats
+
+	/*
+		ThreeBV returns the Bechtel's Board Benchmark Value of the minefield, the
+		minimum number of clicks needed to reveal every tile without a mine.
+		Each opening, a patch of tiles without adjacent mines, counts once and
+		each tile with adjacent mines that does not border an opening counts once.
+		If the mines of a deferred safe start are not placed yet 0 is returned.
+	*/
+	ThreeBV() int
+
+	/*
+		Snapshot returns the state of the minefield's tiles, which can be used to
+		restore a minefield generated with the same configuration.
+	*/
+	Snapshot() Snapshot
+
+	/*
+		Restore sets the state of the minefield's tiles to match the snapshot.
+		If the mines of a deferred safe start were placed in the snapshot, but not
+		yet in the minefield, they are placed around the same tile.
+		Only the tiles in the snapshot will be revealed or flagged.
+	*/
+	Restore(snapshot Snapshot) error
+}
+
+type ILayeredMinefield interface {
+	/*
+		The flat minefield, with the layers one after the other in its rows.
+		The row index layerIndex*LayerRows()+rowIndex is the row of the layer.
+	*/
+	IMinefield
+	/*
+		Layers returns the number of layers in the minefield.
+	*/
+	Layers() int
+	/*
+		LayerRows returns the number of rows in each layer of the minefield.
+	*/
+	LayerRows() int
+	/*
+		LayerPosition returns the layer, row and col indexes of the tile with the
+		provided index.
+	*/
+	LayerPosition(tileIndex int) (int, int, int)
+	/*
+		LayerTile searches for the tile in the requested layer, row and column.
+		The returned tile is a copy, which is not updated by later actions.
+	*/
+	LayerTile(layerIndex int, rowIndex int, colIndex int) (ITile, error)
+	/*
+		RevealLayerTile reveals the requested tile.
+		If the tile is empty the patch it belongs to will be revealed, across the
+		layers.
+	*/
+	RevealLayerTile(layerIndex int, rowIndex int, colIndex int) ([]int, error)
+	/*
+		ToggleLayerFlag flips the flag state for the requested tile.
+	*/
+	ToggleLayerFlag(layerIndex int, rowIndex int, colIndex int) error
+	/*
+		ProcessLayerAdjacentTiles applies to a revealed tile and will check the
+		tiles adjacent to it, on its own layer and on the layers above and below,
+		for flags.
+		If the number of adjacent flags is >= the number on the tile it will reveal
+		all adjacent tiles without a flag.
+	*/
+	ProcessLayerAdjacentTiles(layerIndex int, rowIndex int, colIndex int) ([]int, error)
 }
 
 type ITile interface {
@@ -61,3 +144,23 @@ type ITile interface {
 	*/
 	AdjacentMines() int
 }
+
+type ITopology interface {
+	/*
+		NumTiles returns the number of tile indexes of the board, including the
+		ones that are not tiles, like the cells removed by a mask.
+	*/
+	NumTiles() int
+	/*
+		TileIndex returns the index of the tile in the requested row and column.
+		Returns false if the board has no such tile, like the cells removed by a
+		mask.
+	*/
+	TileIndex(rowIndex int, colIndex int) (int, bool)
+	/*
+		AdjacentTiles returns the indexes of the tiles adjacent to the tile with
+		the provided index.
+		The returned slice must not be modified.
+	*/
+	AdjacentTiles(tileIndex int) []int
+}
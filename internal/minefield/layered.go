@@ -0,0 +1,165 @@
+package minefield
+
+import "fmt"
+
+// Error: The requested tile of a layered minefield does not exist
+type layerTileNotFoundError struct {
+	LayerIndex int
+	RowIndex   int
+	ColIndex   int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e layerTileNotFoundError) Error() string {
+	return fmt.Sprintf(
+		"Tile not found for layer index '%v', row index '%v' and col index '%v'",
+		e.LayerIndex, e.RowIndex, e.ColIndex)
+}
+
+// Error: A layered minefield needs at least one layer
+type invalidNumLayersError struct {
+	NumLayers int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e invalidNumLayersError) Error() string {
+	return fmt.Sprintf("The number of layers '%v' is not valid, it must be at least '1'", e.NumLayers)
+}
+
+// LayeredMinefield is a minefield with layers of tiles stacked on top of each
+// other, where each tile is adjacent to the up to 26 tiles around it.
+// The embedded minefield holds the layers one after the other in its rows, so
+// it can also be used as a flat minefield with layers*layerRows rows.
+type layeredMinefield struct {
+	*minefield
+	layers    int
+	layerRows int
+}
+
+/*
+GenerateLayered creates a minefield with NumLayers layers of NumRows x NumCols
+square tiles, where each tile is adjacent to the up to 26 tiles around it in
+three dimensions.
+The masked and mine tile indexes are indexes of the flat minefield.
+*/
+func GenerateLayered(args MinefieldConfig) (ILayeredMinefield, error) {
+	if args.NumLayers < 1 {
+		return nil, invalidNumLayersError{
+			NumLayers: args.NumLayers,
+		}
+	}
+
+	flatArgs := args
+	flatArgs.NumRows = args.NumLayers * args.NumRows
+	flatArgs.CustomTopology = NewLayeredTopology(args.NumLayers, args.NumRows, args.NumCols)
+
+	minefield, error := generate(flatArgs)
+	if error != nil {
+		return nil, error
+	}
+
+	return &layeredMinefield{
+		minefield: minefield,
+		layers:    args.NumLayers,
+		layerRows: args.NumRows,
+	}, nil
+}
+
+/*
+Layers returns the number of layers in the minefield.
+*/
+func (minefield *layeredMinefield) Layers() int {
+	return minefield.layers
+}
+
+/*
+LayerRows returns the number of rows in each layer of the minefield.
+*/
+func (minefield *layeredMinefield) LayerRows() int {
+	return minefield.layerRows
+}
+
+/*
+LayerPosition returns the layer, row and col indexes of the tile with the
+provided index.
+*/
+func (minefield *layeredMinefield) LayerPosition(tileIndex int) (int, int, int) {
+	flatRowIndex := tileIndex / minefield.cols
+	return flatRowIndex / minefield.layerRows, flatRowIndex % minefield.layerRows, tileIndex % minefield.cols
+}
+
+/*
+LayerTile searches for the tile in the requested layer, row and column.
+The returned tile is a copy, which is not updated by later actions.
+*/
+func (minefield *layeredMinefield) LayerTile(layerIndex int, rowIndex int, colIndex int) (ITile, error) {
+	flatRowIndex, error := minefield.flatRowIndex(layerIndex, rowIndex, colIndex)
+	if error != nil {
+		return nil, error
+	}
+
+	return minefield.Tile(flatRowIndex, colIndex)
+}
+
+/*
+RevealLayerTile reveals the requested tile.
+If the tile is empty the patch it belongs to will be revealed, across the
+layers.
+*/
+func (minefield *layeredMinefield) RevealLayerTile(layerIndex int, rowIndex int, colIndex int) ([]int, error) {
+	flatRowIndex, error := minefield.flatRowIndex(layerIndex, rowIndex, colIndex)
+	if error != nil {
+		return nil, error
+	}
+
+	return minefield.RevealTile(flatRowIndex, colIndex)
+}
+
+/*
+ToggleLayerFlag flips the flag state for the requested tile.
+*/
+func (minefield *layeredMinefield) ToggleLayerFlag(layerIndex int, rowIndex int, colIndex int) error {
+	flatRowIndex, error := minefield.flatRowIndex(layerIndex, rowIndex, colIndex)
+	if error != nil {
+		return error
+	}
+
+	return minefield.ToggleFlag(flatRowIndex, colIndex)
+}
+
+/*
+ProcessLayerAdjacentTiles applies to a revealed tile and will check the tiles
+adjacent to it, on its own layer and on the layers above and below, for flags.
+If the number of adjacent flags is >= the number on the tile it will reveal all
+adjacent tiles without a flag.
+*/
+func (minefield *layeredMinefield) ProcessLayerAdjacentTiles(layerIndex int, rowIndex int, colIndex int) ([]int, error) {
+	flatRowIndex, error := minefield.flatRowIndex(layerIndex, rowIndex, colIndex)
+	if error != nil {
+		return nil, error
+	}
+
+	return minefield.ProcessAdjacentTiles(flatRowIndex, colIndex)
+}
+
+/*
+flatRowIndex returns the row index of the flat minefield for the row of the
+layer.
+Returns an error if the layer, row or col index is outside the minefield.
+*/
+func (minefield *layeredMinefield) flatRowIndex(layerIndex int, rowIndex int, colIndex int) (int, error) {
+	if layerIndex < 0 || layerIndex > minefield.layers-1 || rowIndex < 0 || rowIndex > minefield.layerRows-1 ||
+		colIndex < 0 || colIndex > minefield.cols-1 {
+		return 0, layerTileNotFoundError{
+			LayerIndex: layerIndex,
+			RowIndex:   rowIndex,
+			ColIndex:   colIndex,
+		}
+	}
+
+	return layerIndex*minefield.layerRows + rowIndex, nil
+}
@@ -0,0 +1,104 @@
+package minefield_test
+
+import (
+	"testing"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type layeredTestSuite struct {
+	suite.Suite
+	sut minefield.ILayeredMinefield
+}
+
+func (suite *layeredTestSuite) SetupTest() {
+	// 2 layers of 3x3 tiles, with a mine on the last tile of the second layer
+	suite.sut, _ = minefield.GenerateLayered(minefield.MinefieldConfig{
+		NumLayers: 2,
+		NumRows:   3,
+		NumCols:   3,
+		NumMines:  1,
+		MineTiles: []int{17},
+	})
+}
+
+func (suite *layeredTestSuite) TestLayeredTopologyHasTwentySixAdjacentTilesForTheCenterTile() {
+	sut := minefield.NewLayeredTopology(3, 3, 3)
+
+	require.Equal(suite.T(), 27, sut.NumTiles())
+	require.Equal(suite.T(), 26, len(sut.AdjacentTiles(13)))
+	require.ElementsMatch(suite.T(), []int{1, 3, 4, 9, 10, 12, 13}, sut.AdjacentTiles(0))
+}
+
+func (suite *layeredTestSuite) TestLayeredTopologyDoesNotJoinTheLastRowOfALayerWithTheFirstRowOfTheNext() {
+	sut := minefield.NewLayeredTopology(2, 2, 1)
+
+	require.ElementsMatch(suite.T(), []int{0, 2, 3}, sut.AdjacentTiles(1))
+}
+
+func (suite *layeredTestSuite) TestGenerateLayeredReturnsTheSizeOfTheLayers() {
+	require.Equal(suite.T(), 2, suite.sut.Layers())
+	require.Equal(suite.T(), 3, suite.sut.LayerRows())
+	require.Equal(suite.T(), 6, suite.sut.Rows())
+	require.Equal(suite.T(), 3, suite.sut.Cols())
+	require.Equal(suite.T(), 18, suite.sut.NumTiles())
+}
+
+func (suite *layeredTestSuite) TestGenerateLayeredCountsTheMinesOfTheAdjacentLayers() {
+	for _, position := range [][3]int{{0, 1, 1}, {0, 2, 2}, {1, 1, 2}} {
+		tile, err := suite.sut.LayerTile(position[0], position[1], position[2])
+		require.Nil(suite.T(), err)
+		require.Equalf(suite.T(), 1, tile.AdjacentMines(), "position: %v", position)
+	}
+
+	tile, _ := suite.sut.LayerTile(0, 0, 2)
+	require.Equal(suite.T(), 0, tile.AdjacentMines())
+}
+
+func (suite *layeredTestSuite) TestGenerateLayeredReturnsAnErrorIfThereAreNoLayers() {
+	_, err := minefield.GenerateLayered(minefield.MinefieldConfig{
+		NumRows:  3,
+		NumCols:  3,
+		NumMines: 1,
+	})
+
+	require.EqualError(suite.T(), err, "The number of layers '0' is not valid, it must be at least '1'")
+}
+
+func (suite *layeredTestSuite) TestRevealLayerTileRevealsThePatchAcrossTheLayers() {
+	tileIndexes, err := suite.sut.RevealLayerTile(0, 0, 0)
+
+	require.Nil(suite.T(), err)
+	require.ElementsMatch(suite.T(), []int{0, 1, 2, 3, 4, 5, 6, 7, 9, 10, 11, 12, 13, 14, 15, 16}, tileIndexes)
+	tile, _ := suite.sut.LayerTile(0, 2, 2)
+	require.Equal(suite.T(), false, tile.Revealed())
+}
+
+func (suite *layeredTestSuite) TestProcessLayerAdjacentTilesRevealsTheAdjacentTilesOfTheOtherLayers() {
+	suite.sut.RevealLayerTile(0, 2, 2)
+	suite.sut.ToggleLayerFlag(1, 2, 2)
+
+	tileIndexes, err := suite.sut.ProcessLayerAdjacentTiles(0, 2, 2)
+
+	require.Nil(suite.T(), err)
+	require.ElementsMatch(suite.T(), []int{4, 5, 7, 13, 14, 16}, tileIndexes)
+}
+
+func (suite *layeredTestSuite) TestLayerTileReturnsAnErrorIfTheRequestedTileDoesNotExist() {
+	_, err := suite.sut.LayerTile(2, 0, 0)
+
+	require.EqualError(suite.T(), err, "Tile not found for layer index '2', row index '0' and col index '0'")
+}
+
+func (suite *layeredTestSuite) TestLayerPositionReturnsTheLayerRowAndColOfATileIndex() {
+	layerIndex, rowIndex, colIndex := suite.sut.LayerPosition(16)
+
+	require.Equal(suite.T(), []int{1, 2, 1}, []int{layerIndex, rowIndex, colIndex})
+}
+
+func TestLayeredSuite(t *testing.T) {
+	suite.Run(t, new(layeredTestSuite))
+}
@@ -1,6 +1,10 @@
 package minefield
 
-import "fmt"
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+)
 
 // Error: The requested tile does not exist
 type tileNotFoundError struct {
@@ -17,12 +21,58 @@ func (e tileNotFoundError) Error() string {
 		e.RowIndex, e.ColIndex)
 }
 
-// Minefield describes the content and layout of a Minefield board
+// Error: No board that can be cleared without guessing was found
+type noGuessBoardNotFoundError struct {
+	Attempts int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e noGuessBoardNotFoundError) Error() string {
+	return fmt.Sprintf(
+		"No board that can be cleared without guessing was found after '%v' attempts",
+		e.Attempts)
+}
+
+// Error: The number of tiles in a mine layout does not match the number of mines
+type mineLayoutCountError struct {
+	NumMines     int
+	NumMineTiles int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e mineLayoutCountError) Error() string {
+	return fmt.Sprintf(
+		"The mine layout has '%v' tiles but the minefield has '%v' mines",
+		e.NumMineTiles, e.NumMines)
+}
+
+// Minefield describes the content and layout of a Minefield board.
+// The exported methods that read or change the tiles hold the mutex, so a
+// minefield can be used by several goroutines, and the unexported methods and
+// functions expect it to be held. The size, the mines and the topology are not
+// changed after the minefield is generated, so they are read without the mutex.
 type minefield struct {
-	cols  int
-	rows  int
-	mines int
-	tiles []tile
+	mutex     sync.RWMutex
+	cols      int
+	rows      int
+	mines     int
+	tiles     []tile
+	rng       *rand.Rand
+	safeStart int
+	// Defines the tile count and the tiles adjacent to each tile
+	topology ITopology
+	// The number of tiles, without the cells removed by a mask
+	numTiles int
+	// False while the mines of a deferred safe start are not yet placed
+	generated          bool
+	noGuess            bool
+	noGuessMaxAttempts int
+	// The index of the tile the mines were placed around, -1 if not deferred
+	startTileIndex int
 }
 
 // Cols returns the number of columns in the minefield
@@ -40,18 +90,62 @@ func (minefield *minefield) Mines() int {
 	return minefield.mines
 }
 
-// Tile returns the tile in the minefield, on the provided row and col index
+/*
+NumTiles returns the number of tiles in the minefield, without the cells removed
+by a mask.
+*/
+func (minefield *minefield) NumTiles() int {
+	return minefield.numTiles
+}
+
+/*
+HasTile returns true if the minefield has a tile on the provided row and col
+index, and false if it is outside the minefield or removed by a mask.
+*/
+func (minefield *minefield) HasTile(rowIndex int, colIndex int) bool {
+	_, ok := minefield.topology.TileIndex(rowIndex, colIndex)
+	return ok
+}
+
+/*
+hasTile returns true if the tile index is a tile of the minefield, and not a
+cell removed by a mask.
+*/
+func (minefield *minefield) hasTile(tileIndex int) bool {
+	_, ok := minefield.topology.TileIndex(tileIndex/minefield.cols, tileIndex%minefield.cols)
+	return ok
+}
+
+/*
+Tile returns a copy of the tile in the minefield, on the provided row and col
+index.
+The copy is not updated by later changes to the minefield.
+*/
 func (minefield *minefield) Tile(rowIndex int, colIndex int) (ITile, error) {
-	tileIndex := calcTileIndex(rowIndex, colIndex, minefield.cols)
+	minefield.mutex.RLock()
+	defer minefield.mutex.RUnlock()
 
-	if tileIndex < 0 || tileIndex > len(minefield.tiles)-1 {
-		return &tile{}, tileNotFoundError{
-			RowIndex: rowIndex,
-			ColIndex: colIndex,
-		}
+	tilePointer, error := minefield.tile(rowIndex, colIndex)
+	tileCopy := *tilePointer
+
+	return &tileCopy, error
+}
+
+/*
+AdjacentTiles returns the indexes of the tiles adjacent to the tile on the
+provided row and col index, according to the minefield's topology.
+*/
+func (minefield *minefield) AdjacentTiles(rowIndex int, colIndex int) ([]int, error) {
+	minefield.mutex.RLock()
+	defer minefield.mutex.RUnlock()
+
+	_, error := minefield.tile(rowIndex, colIndex)
+	if error != nil {
+		return nil, error
 	}
 
-	return &minefield.tiles[tileIndex], nil
+	tileIndex, _ := minefield.topology.TileIndex(rowIndex, colIndex)
+	return append([]int{}, minefield.topology.AdjacentTiles(tileIndex)...), nil
 }
 
 /*
@@ -59,12 +153,32 @@ RevealTile reveals the requested tile.
 If the tile is empty the patch it belongs to will be revealed.
 */
 func (minefield *minefield) RevealTile(rowIndex int, colIndex int) ([]int, error) {
-	_, error := minefield.Tile(rowIndex, colIndex)
+	minefield.mutex.Lock()
+	defer minefield.mutex.Unlock()
+
+	return minefield.revealTile(rowIndex, colIndex)
+}
+
+/*
+revealTile reveals the requested tile, and the patch it belongs to if it is
+empty.
+*/
+func (minefield *minefield) revealTile(rowIndex int, colIndex int) ([]int, error) {
+	_, error := minefield.tile(rowIndex, colIndex)
 	if error != nil {
 		return nil, error
 	}
 
-	tilesToReveal := findTilePatch(minefield, calcTileIndex(rowIndex, colIndex, minefield.cols))
+	requestedIndex, _ := minefield.topology.TileIndex(rowIndex, colIndex)
+	if !minefield.generated {
+		error = placeMines(minefield, requestedIndex)
+		if error != nil {
+			return nil, error
+		}
+		minefield.startTileIndex = requestedIndex
+	}
+
+	tilesToReveal := findTilePatch(minefield, requestedIndex)
 	revealedTiles := []int{}
 
 	for _, tileIndex := range tilesToReveal {
@@ -86,16 +200,19 @@ func (minefield *minefield) RevealTile(rowIndex int, colIndex int) ([]int, error
 ToggleFlag flips the flag state for the requested tile.
 */
 func (minefield *minefield) ToggleFlag(rowIndex int, colIndex int) error {
-	tile, error := minefield.Tile(rowIndex, colIndex)
+	minefield.mutex.Lock()
+	defer minefield.mutex.Unlock()
+
+	tile, error := minefield.tile(rowIndex, colIndex)
 	if error != nil {
 		return error
 	}
 
-	if tile.Revealed() {
+	if tile.revealed {
 		return nil
 	}
 
-	minefield.tiles[calcTileIndex(rowIndex, colIndex, minefield.cols)].hasFlag = !tile.HasFlag()
+	tile.hasFlag = !tile.hasFlag
 	return nil
 }
 
@@ -106,7 +223,10 @@ If the number of adjacent flags is >= the number on the tile it will reveal
 all adjacent tiles without a flag.
 */
 func (minefield *minefield) ProcessAdjacentTiles(rowIndex int, colIndex int) ([]int, error) {
-	reqTile, error := minefield.Tile(rowIndex, colIndex)
+	minefield.mutex.Lock()
+	defer minefield.mutex.Unlock()
+
+	reqTile, error := minefield.tile(rowIndex, colIndex)
 
 	if error != nil {
 		return nil, error
@@ -117,35 +237,21 @@ func (minefield *minefield) ProcessAdjacentTiles(rowIndex int, colIndex int) ([]
 	}
 
 	adjacentFlags := 0
-	tilesToReveal := []int{calcTileIndex(rowIndex, colIndex, minefield.cols)}
+	tileIndex, _ := minefield.topology.TileIndex(rowIndex, colIndex)
+	tilesToReveal := []int{tileIndex}
 	revealedTiles := []int{}
 
-	for rOffset := -1; rOffset <= 1; rOffset++ {
-		rIndex := rowIndex + rOffset
-		if rIndex < 0 || rIndex > minefield.rows-1 {
+	for _, adjacentIndex := range minefield.topology.AdjacentTiles(tileIndex) {
+		tile := minefield.tiles[adjacentIndex]
+		if tile.Revealed() {
 			continue
 		}
-
-		for cOffset := -1; cOffset <= 1; cOffset++ {
-			cIndex := colIndex + cOffset
-			if cIndex < 0 || cIndex > minefield.cols-1 {
-				continue
-			}
-
-			tile, error := minefield.Tile(rIndex, cIndex)
-			if error != nil {
-				continue
-			}
-			if tile.Revealed() {
-				continue
-			}
-			if tile.HasFlag() {
-				adjacentFlags++
-				continue
-			}
-
-			tilesToReveal = append(tilesToReveal, rIndex*minefield.cols+cIndex)
+		if tile.HasFlag() {
+			adjacentFlags++
+			continue
 		}
+
+		tilesToReveal = append(tilesToReveal, adjacentIndex)
 	}
 
 	if reqTile.AdjacentMines() > adjacentFlags {
@@ -156,7 +262,7 @@ func (minefield *minefield) ProcessAdjacentTiles(rowIndex int, colIndex int) ([]
 		tileRowIndex := tileIndex / minefield.cols
 		tileColIndex := tileIndex % minefield.cols
 
-		tileIndexes, _ := minefield.RevealTile(tileRowIndex, tileColIndex)
+		tileIndexes, _ := minefield.revealTile(tileRowIndex, tileColIndex)
 		revealedTiles = append(revealedTiles, tileIndexes...)
 	}
 
@@ -167,6 +273,9 @@ func (minefield *minefield) ProcessAdjacentTiles(rowIndex int, colIndex int) ([]
 Stats returns statistics about a minefield.
 */
 func (minefield *minefield) Stats() stats {
+	minefield.mutex.RLock()
+	defer minefield.mutex.RUnlock()
+
 	stats := new(stats)
 
 	for _, tile := range minefield.tiles {
@@ -185,6 +294,110 @@ func (minefield *minefield) Stats() stats {
 	return *stats
 }
 
+/*
+Snapshot returns the state of the minefield's tiles, which can be used to
+restore a minefield generated with the same configuration.
+*/
+func (minefield *minefield) Snapshot() Snapshot {
+	minefield.mutex.RLock()
+	defer minefield.mutex.RUnlock()
+
+	snapshot := Snapshot{
+		StartTileIndex: minefield.startTileIndex,
+		RevealedTiles:  []int{},
+		FlaggedTiles:   []int{},
+	}
+
+	for tileIndex, tile := range minefield.tiles {
+		if tile.revealed {
+			snapshot.RevealedTiles = append(snapshot.RevealedTiles, tileIndex)
+		}
+		if tile.hasFlag {
+			snapshot.FlaggedTiles = append(snapshot.FlaggedTiles, tileIndex)
+		}
+	}
+
+	return snapshot
+}
+
+/*
+Restore sets the state of the minefield's tiles to match the snapshot.
+If the mines of a deferred safe start were placed in the snapshot, but not yet
+in the minefield, they are placed around the same tile.
+Only the tiles in the snapshot will be revealed or flagged.
+*/
+func (minefield *minefield) Restore(snapshot Snapshot) error {
+	minefield.mutex.Lock()
+	defer minefield.mutex.Unlock()
+
+	for _, tileIndexes := range [][]int{snapshot.RevealedTiles, snapshot.FlaggedTiles} {
+		for _, tileIndex := range tileIndexes {
+			if tileIndex < 0 || tileIndex > len(minefield.tiles)-1 {
+				return tileNotFoundError{
+					RowIndex: tileIndex / minefield.cols,
+					ColIndex: tileIndex % minefield.cols,
+				}
+			}
+		}
+	}
+
+	if !minefield.generated && snapshot.StartTileIndex >= 0 {
+		if snapshot.StartTileIndex > len(minefield.tiles)-1 {
+			return tileNotFoundError{
+				RowIndex: snapshot.StartTileIndex / minefield.cols,
+				ColIndex: snapshot.StartTileIndex % minefield.cols,
+			}
+		}
+
+		error := placeMines(minefield, snapshot.StartTileIndex)
+		if error != nil {
+			return error
+		}
+		minefield.startTileIndex = snapshot.StartTileIndex
+	}
+
+	for tileIndex := range minefield.tiles {
+		minefield.tiles[tileIndex].revealed = false
+		minefield.tiles[tileIndex].hasFlag = false
+	}
+	for _, tileIndex := range snapshot.RevealedTiles {
+		minefield.tiles[tileIndex].revealed = true
+	}
+	for _, tileIndex := range snapshot.FlaggedTiles {
+		minefield.tiles[tileIndex].hasFlag = true
+	}
+
+	return nil
+}
+
+/*
+tile returns a pointer to the tile in the minefield, on the provided row and
+col index.
+If the tile does not exist a pointer to an empty tile is returned with the
+error.
+*/
+func (minefield *minefield) tile(rowIndex int, colIndex int) (*tile, error) {
+	tileIndex, ok := minefield.topology.TileIndex(rowIndex, colIndex)
+
+	if !ok {
+		return &tile{}, tileNotFoundError{
+			RowIndex: rowIndex,
+			ColIndex: colIndex,
+		}
+	}
+
+	return &minefield.tiles[tileIndex], nil
+}
+
+// Snapshot contains the state of a minefield's tiles
+type Snapshot struct {
+	// The index of the first revealed tile, for minefields with a deferred safe
+	// start, otherwise -1
+	StartTileIndex int
+	RevealedTiles  []int
+	FlaggedTiles   []int
+}
+
 // Tile describes the information of a specific tile on the board
 type tile struct {
 	revealed      bool
@@ -2,8 +2,10 @@ package minefield_test
 
 import (
 	"sort"
+	"sync"
 	"testing"
 
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
 	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
 
 	"github.com/stretchr/testify/require"
@@ -197,7 +199,7 @@ func (suite *minefieldTestSuite) SetupTest() {
 		{adjacentMines: 0, hasMine: true},
 	}
 
-	suite.sut = minefield.Generate(*suite.sutArgs)
+	suite.sut, _ = minefield.Generate(*suite.sutArgs)
 }
 
 func (suite *minefieldTestSuite) TestColsReturnsTheNumberOfColumnsInTheMinefield() {
@@ -473,6 +475,199 @@ func (suite *minefieldTestSuite) TestStatsReturnsTheExpectedObject() {
 	require.Equal(suite.T(), expected.NumTilesRevealed, actual.NumTilesRevealed)
 }
 
+func (suite *minefieldTestSuite) TestSnapshotReturnsTheRevealedAndFlaggedTiles() {
+	suite.sut.ToggleFlag(0, 8)
+	suite.sut.ToggleFlag(9, 10)
+
+	expectedRevealed := []int{}
+	for tIndex, tile := range suite.expectedMinefield {
+		if tile.revealed {
+			expectedRevealed = append(expectedRevealed, tIndex)
+		}
+	}
+
+	actual := suite.sut.Snapshot()
+
+	require.Equal(suite.T(), -1, actual.StartTileIndex)
+	require.Equal(suite.T(), expectedRevealed, actual.RevealedTiles)
+	require.Equal(suite.T(), []int{8, 109}, actual.FlaggedTiles)
+}
+
+func (suite *minefieldTestSuite) TestRestoreSetsTheTilesToMatchTheSnapshot() {
+	for tIndex := range suite.expectedMinefield {
+		suite.expectedMinefield[tIndex].revealed = false
+	}
+	suite.expectedMinefield[7].revealed = true
+	suite.expectedMinefield[22].revealed = true
+	suite.expectedMinefield[8].hasFlag = true
+
+	err := suite.sut.Restore(minefield.Snapshot{
+		StartTileIndex: -1,
+		RevealedTiles:  []int{7, 22},
+		FlaggedTiles:   []int{8},
+	})
+
+	require.Nil(suite.T(), err)
+	suite.validateMinefield()
+}
+
+func (suite *minefieldTestSuite) TestRestoreReturnsAnErrorIfATileDoesNotExist() {
+	err := suite.sut.Restore(minefield.Snapshot{
+		StartTileIndex: -1,
+		RevealedTiles:  []int{7, 110},
+		FlaggedTiles:   []int{},
+	})
+
+	require.NotNil(suite.T(), err)
+	suite.validateMinefield()
+}
+
+func (suite *minefieldTestSuite) TestRestorePlacesTheMinesAroundTheSameStartTileOfADeferredSafeStart() {
+	suite.sutArgs.SafeStart = configs.SafeStartArea
+	original, _ := minefield.Generate(*suite.sutArgs)
+	original.RevealTile(4, 6)
+	original.ToggleFlag(0, 0)
+
+	sut, _ := minefield.Generate(*suite.sutArgs)
+	err := sut.Restore(original.Snapshot())
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), original.Snapshot(), sut.Snapshot())
+	for rIndex := 0; rIndex < suite.sutArgs.NumRows; rIndex++ {
+		for cIndex := 0; cIndex < suite.sutArgs.NumCols; cIndex++ {
+			expected, _ := original.Tile(rIndex, cIndex)
+			actual, _ := sut.Tile(rIndex, cIndex)
+			require.Equalf(suite.T(), expected, actual, "row index: %v | col index: %v", rIndex, cIndex)
+		}
+	}
+}
+
+func (suite *minefieldTestSuite) TestTileReturnsACopyThatIsNotUpdatedByLaterChanges() {
+	tile, _ := suite.sut.Tile(2, 0)
+
+	suite.sut.ToggleFlag(2, 0)
+
+	require.Equal(suite.T(), false, tile.HasFlag())
+	tile, _ = suite.sut.Tile(2, 0)
+	require.Equal(suite.T(), true, tile.HasFlag())
+}
+
+func (suite *minefieldTestSuite) TestConcurrentReadsAndWritesDoNotCorruptTheMinefield() {
+	expected := suite.sut.Snapshot()
+
+	waitGroup := sync.WaitGroup{}
+	for rIndex := 0; rIndex < suite.sutArgs.NumRows; rIndex++ {
+		waitGroup.Add(2)
+		go func(rIndex int) {
+			defer waitGroup.Done()
+			for cIndex := 0; cIndex < suite.sutArgs.NumCols; cIndex++ {
+				suite.sut.ToggleFlag(rIndex, cIndex)
+				suite.sut.ToggleFlag(rIndex, cIndex)
+			}
+		}(rIndex)
+		go func(rIndex int) {
+			defer waitGroup.Done()
+			for cIndex := 0; cIndex < suite.sutArgs.NumCols; cIndex++ {
+				suite.sut.Tile(rIndex, cIndex)
+				suite.sut.Stats()
+				suite.sut.Snapshot()
+			}
+		}(rIndex)
+	}
+	waitGroup.Wait()
+
+	require.Equal(suite.T(), expected, suite.sut.Snapshot())
+	require.Equal(suite.T(), 0, suite.sut.Stats().NumFlags)
+}
+
+func (suite *minefieldTestSuite) TestAdjacentTilesReturnsTheSixNeighboursOfAHexagonalTile() {
+	sut, err := minefield.Generate(minefield.MinefieldConfig{
+		NumRows:   3,
+		NumCols:   3,
+		NumMines:  1,
+		Topology:  configs.TopologyHex,
+		MineTiles: []int{4},
+	})
+	require.Nil(suite.T(), err)
+
+	oddRowTiles, err := sut.AdjacentTiles(1, 1)
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{1, 2, 3, 5, 7, 8}, oddRowTiles)
+
+	evenRowTiles, err := sut.AdjacentTiles(2, 2)
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{4, 5, 7}, evenRowTiles)
+
+	cornerTiles, err := sut.AdjacentTiles(0, 0)
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{1, 3}, cornerTiles)
+}
+
+func (suite *minefieldTestSuite) TestAdjacentTilesReturnsAnErrorIfTheTileDoesNotExist() {
+	sut, _ := minefield.Generate(minefield.MinefieldConfig{
+		NumRows:   3,
+		NumCols:   3,
+		NumMines:  1,
+		MineTiles: []int{4},
+	})
+
+	_, err := sut.AdjacentTiles(3, 0)
+
+	require.EqualError(suite.T(), err, "Tile not found for row index '3' and col index '0'")
+}
+
+func (suite *minefieldTestSuite) TestItCountsTheMinesOfTheSixNeighboursOfAHexagonalTile() {
+	sut, _ := minefield.Generate(minefield.MinefieldConfig{
+		NumRows:   3,
+		NumCols:   3,
+		NumMines:  1,
+		Topology:  configs.TopologyHex,
+		MineTiles: []int{4},
+	})
+
+	expectedAdjacentMines := []int{0, 1, 1, 1, 0, 1, 0, 1, 1}
+	for tileIndex, expected := range expectedAdjacentMines {
+		tile, _ := sut.Tile(tileIndex/3, tileIndex%3)
+		require.Equalf(suite.T(), expected, tile.AdjacentMines(), "tile index: %v", tileIndex)
+	}
+}
+
+func (suite *minefieldTestSuite) TestRevealTileRevealsThePatchOfAHexagonalTile() {
+	sut, _ := minefield.Generate(minefield.MinefieldConfig{
+		NumRows:   3,
+		NumCols:   3,
+		NumMines:  1,
+		Topology:  configs.TopologyHex,
+		MineTiles: []int{4},
+	})
+
+	tileIndexes, err := sut.RevealTile(0, 0)
+	sort.Ints(tileIndexes)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{0, 1, 3}, tileIndexes)
+}
+
+func (suite *minefieldTestSuite) TestProcessAdjacentTilesRevealsTheNeighboursOfAHexagonalTile() {
+	sut, _ := minefield.Generate(minefield.MinefieldConfig{
+		NumRows:   3,
+		NumCols:   3,
+		NumMines:  1,
+		Topology:  configs.TopologyHex,
+		MineTiles: []int{4},
+	})
+	sut.RevealTile(0, 1)
+	sut.ToggleFlag(1, 1)
+
+	tileIndexes, err := sut.ProcessAdjacentTiles(0, 1)
+	sort.Ints(tileIndexes)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{0, 2, 3}, tileIndexes)
+	tile, _ := sut.Tile(2, 0)
+	require.Equal(suite.T(), false, tile.Revealed())
+}
+
 func TestMinefieldFunctionalitySuite(t *testing.T) {
 	suite.Run(t, new(minefieldTestSuite))
 }
@@ -0,0 +1,68 @@
+package minefield
+
+import "github.com/pedrohenriques/go-minesweeper/internal/deduction"
+
+/*
+solvableWithoutGuessing checks if the minefield, starting from its currently
+revealed tiles and the patch of the tile with index startTileIndex, if not
+negative, can be fully cleared using only logical deductions.
+The minefield is not changed.
+The deductions only use the information a player would have, i.e. the numbers
+on the revealed tiles and the total number of mines, and are the same the
+solver uses for the hints.
+*/
+func solvableWithoutGuessing(minefield *minefield, startTileIndex int) bool {
+	adjacent := make([][]int, len(minefield.tiles))
+	for tileIndex := range minefield.tiles {
+		if minefield.hasTile(tileIndex) {
+			adjacent[tileIndex] = minefield.topology.AdjacentTiles(tileIndex)
+		}
+	}
+
+	board := deduction.NewBoard(minefield.cols, minefield.mines, adjacent)
+	revealed := make([]bool, len(minefield.tiles))
+	numSafeTiles := minefield.numTiles - minefield.mines
+	numRevealed := 0
+
+	reveal := func(tileIndex int) {
+		for _, patchIndex := range findTilePatch(minefield, tileIndex) {
+			if !revealed[patchIndex] {
+				revealed[patchIndex] = true
+				numRevealed++
+				board.Reveal(patchIndex, minefield.tiles[patchIndex].adjacentMines)
+			}
+		}
+	}
+
+	for tileIndex, tile := range minefield.tiles {
+		switch {
+		case !minefield.hasTile(tileIndex):
+			board.Mask(tileIndex)
+		case tile.revealed && tile.hasMine:
+			board.MarkMine(tileIndex)
+		case tile.revealed && !revealed[tileIndex]:
+			revealed[tileIndex] = true
+			numRevealed++
+			board.Reveal(tileIndex, tile.adjacentMines)
+		}
+	}
+	if startTileIndex >= 0 {
+		reveal(startTileIndex)
+	}
+
+	for numRevealed < numSafeTiles {
+		result, error := board.Solve()
+		if error != nil || len(result.SafeTiles) == 0 {
+			return false
+		}
+
+		for _, tileIndex := range result.SafeTiles {
+			if minefield.tiles[tileIndex].hasMine {
+				return false
+			}
+			reveal(tileIndex)
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,58 @@
+package minefield_test
+
+import (
+	"testing"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type solverTestSuite struct {
+	suite.Suite
+}
+
+func (suite *solverTestSuite) TestSolvableWithoutGuessingClearsABoardFromTheNumbersAndTheMineCount() {
+	// The opening reveals the number next to the mine, and the mine count then
+	// shows the tiles after the mine are safe
+	sut, _ := minefield.Generate(minefield.MinefieldConfig{
+		NumRows:   1,
+		NumCols:   5,
+		NumMines:  1,
+		MineTiles: []int{2},
+	})
+
+	require.Equal(suite.T(), true, minefield.SolvableWithoutGuessing(sut, 0))
+}
+
+func (suite *solverTestSuite) TestSolvableWithoutGuessingFailsOnABoardThatNeedsAGuess() {
+	// The 1 of the first tile is next to 3 hidden tiles, any of which could be
+	// the mine
+	sut, _ := minefield.Generate(minefield.MinefieldConfig{
+		NumRows:   2,
+		NumCols:   2,
+		NumMines:  1,
+		MineTiles: []int{3},
+	})
+
+	require.Equal(suite.T(), false, minefield.SolvableWithoutGuessing(sut, 0))
+}
+
+func (suite *solverTestSuite) TestSolvableWithoutGuessingDoesNotChangeTheMinefield() {
+	sut, _ := minefield.Generate(minefield.MinefieldConfig{
+		NumRows:   1,
+		NumCols:   5,
+		NumMines:  2,
+		MineTiles: []int{2, 4},
+	})
+
+	minefield.SolvableWithoutGuessing(sut, 0)
+
+	tile, _ := sut.Tile(0, 0)
+	require.Equal(suite.T(), false, tile.Revealed())
+}
+
+func TestSolverSuite(t *testing.T) {
+	suite.Run(t, new(solverTestSuite))
+}
@@ -0,0 +1,330 @@
+package minefield
+
+import (
+	"fmt"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+)
+
+// Error: The topology is not one of the configs.Topology* constants
+type unknownTopologyError struct {
+	Topology int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e unknownTopologyError) Error() string {
+	return fmt.Sprintf("The topology '%v' is not known", e.Topology)
+}
+
+// The row and col offsets of the tiles adjacent to a square tile
+var squareOffsets = [][2]int{
+	{-1, -1}, {-1, 0}, {-1, 1},
+	{0, -1}, {0, 1},
+	{1, -1}, {1, 0}, {1, 1},
+}
+
+// The row and col offsets of the tiles adjacent to a hexagonal tile on an even
+// row, which is not shifted
+var hexEvenRowOffsets = [][2]int{
+	{-1, -1}, {-1, 0},
+	{0, -1}, {0, 1},
+	{1, -1}, {1, 0},
+}
+
+// The row and col offsets of the tiles adjacent to a hexagonal tile on an odd
+// row, which is shifted right by half a tile
+var hexOddRowOffsets = [][2]int{
+	{-1, 0}, {-1, 1},
+	{0, -1}, {0, 1},
+	{1, 0}, {1, 1},
+}
+
+// The row and col offsets of the tiles a knight's move away from a tile
+var knightOffsets = [][2]int{
+	{-2, -1}, {-2, 1},
+	{-1, -2}, {-1, 2},
+	{1, -2}, {1, 2},
+	{2, -1}, {2, 1},
+}
+
+// The row and col offsets of the tiles up to two rows and two cols away from a
+// tile
+var radiusTwoOffsets = [][2]int{
+	{-2, -2}, {-2, -1}, {-2, 0}, {-2, 1}, {-2, 2},
+	{-1, -2}, {-1, -1}, {-1, 0}, {-1, 1}, {-1, 2},
+	{0, -2}, {0, -1}, {0, 1}, {0, 2},
+	{1, -2}, {1, -1}, {1, 0}, {1, 1}, {1, 2},
+	{2, -2}, {2, -1}, {2, 0}, {2, 1}, {2, 2},
+}
+
+// GridTopology places the tiles on a grid of rows and cols, where the tiles
+// adjacent to a tile are found by adding offsets to its row and col indexes
+type gridTopology struct {
+	rows int
+	cols int
+	// The indexes of the tiles adjacent to each tile
+	adjacent [][]int
+}
+
+/*
+newGridTopology creates a grid topology, with the offsets of the tiles adjacent
+to a tile on each row.
+If wrap is true the offsets that go past an edge of the grid continue from the
+opposite edge, otherwise they are skipped.
+*/
+func newGridTopology(rows int, cols int, wrap bool, offsets func(rowIndex int) [][2]int) *gridTopology {
+	topology := &gridTopology{
+		rows:     rows,
+		cols:     cols,
+		adjacent: make([][]int, rows*cols),
+	}
+
+	for rowIndex := 0; rowIndex < rows; rowIndex++ {
+		for colIndex := 0; colIndex < cols; colIndex++ {
+			tileIndex := rowIndex*cols + colIndex
+			// On small wrapped grids several offsets can reach the same tile
+			found := map[int]bool{tileIndex: true}
+			tileIndexes := []int{}
+
+			for _, offset := range offsets(rowIndex) {
+				rIndex := rowIndex + offset[0]
+				cIndex := colIndex + offset[1]
+				if wrap {
+					rIndex = ((rIndex % rows) + rows) % rows
+					cIndex = ((cIndex % cols) + cols) % cols
+				}
+
+				adjacentIndex, ok := topology.TileIndex(rIndex, cIndex)
+				if !ok || found[adjacentIndex] {
+					continue
+				}
+
+				found[adjacentIndex] = true
+				tileIndexes = append(tileIndexes, adjacentIndex)
+			}
+
+			topology.adjacent[tileIndex] = tileIndexes
+		}
+	}
+
+	return topology
+}
+
+/*
+NewSquareTopology creates the topology of a grid of square tiles, each adjacent
+to the 8 tiles around it.
+*/
+func NewSquareTopology(rows int, cols int) ITopology {
+	return newGridTopology(rows, cols, false, func(int) [][2]int {
+		return squareOffsets
+	})
+}
+
+/*
+NewHexTopology creates the topology of a grid of hexagonal tiles, each adjacent
+to 6 tiles, with the odd rows shifted right by half a tile.
+*/
+func NewHexTopology(rows int, cols int) ITopology {
+	return newGridTopology(rows, cols, false, func(rowIndex int) [][2]int {
+		if rowIndex%2 == 0 {
+			return hexEvenRowOffsets
+		}
+		return hexOddRowOffsets
+	})
+}
+
+/*
+NewTorusTopology creates the topology of a grid of square tiles whose edges wrap
+around, so every tile is adjacent to the 8 tiles around it.
+*/
+func NewTorusTopology(rows int, cols int) ITopology {
+	return newGridTopology(rows, cols, true, func(int) [][2]int {
+		return squareOffsets
+	})
+}
+
+/*
+NewKnightTopology creates the topology of a grid of square tiles, each adjacent
+to the tiles a knight's move away from it.
+*/
+func NewKnightTopology(rows int, cols int) ITopology {
+	return newGridTopology(rows, cols, false, func(int) [][2]int {
+		return knightOffsets
+	})
+}
+
+/*
+NewRadiusTwoTopology creates the topology of a grid of square tiles, each
+adjacent to the 24 tiles up to two rows and two cols away from it.
+*/
+func NewRadiusTwoTopology(rows int, cols int) ITopology {
+	return newGridTopology(rows, cols, false, func(int) [][2]int {
+		return radiusTwoOffsets
+	})
+}
+
+/*
+NewLayeredTopology creates the topology of layers of rows x cols square tiles,
+stacked on top of each other, where each tile is adjacent to the up to 26 tiles
+around it in three dimensions.
+The layers are placed one after the other on a grid of layers*rows rows, so the
+tile on a layer's row and col has the index
+(layerIndex*rows+rowIndex)*cols+colIndex.
+*/
+func NewLayeredTopology(layers int, rows int, cols int) ITopology {
+	topology := &gridTopology{
+		rows:     layers * rows,
+		cols:     cols,
+		adjacent: make([][]int, layers*rows*cols),
+	}
+
+	for layerIndex := 0; layerIndex < layers; layerIndex++ {
+		for rowIndex := 0; rowIndex < rows; rowIndex++ {
+			for colIndex := 0; colIndex < cols; colIndex++ {
+				tileIndexes := []int{}
+
+				for lIndex := layerIndex - 1; lIndex <= layerIndex+1; lIndex++ {
+					for rIndex := rowIndex - 1; rIndex <= rowIndex+1; rIndex++ {
+						for cIndex := colIndex - 1; cIndex <= colIndex+1; cIndex++ {
+							if lIndex < 0 || lIndex > layers-1 || rIndex < 0 || rIndex > rows-1 ||
+								cIndex < 0 || cIndex > cols-1 {
+								continue
+							}
+							if lIndex == layerIndex && rIndex == rowIndex && cIndex == colIndex {
+								continue
+							}
+
+							tileIndexes = append(tileIndexes, (lIndex*rows+rIndex)*cols+cIndex)
+						}
+					}
+				}
+
+				topology.adjacent[(layerIndex*rows+rowIndex)*cols+colIndex] = tileIndexes
+			}
+		}
+	}
+
+	return topology
+}
+
+/*
+NewTopology creates the topology of a grid with the provided rows and cols, for
+one of the configs.Topology* constants.
+*/
+func NewTopology(topology int, rows int, cols int) (ITopology, error) {
+	switch topology {
+	case configs.TopologySquare:
+		return NewSquareTopology(rows, cols), nil
+	case configs.TopologyHex:
+		return NewHexTopology(rows, cols), nil
+	case configs.TopologyTorus:
+		return NewTorusTopology(rows, cols), nil
+	case configs.TopologyKnight:
+		return NewKnightTopology(rows, cols), nil
+	case configs.TopologyRadiusTwo:
+		return NewRadiusTwoTopology(rows, cols), nil
+	}
+
+	return nil, unknownTopologyError{
+		Topology: topology,
+	}
+}
+
+/*
+NumTiles returns the number of tiles in the grid.
+*/
+func (topology *gridTopology) NumTiles() int {
+	return topology.rows * topology.cols
+}
+
+/*
+TileIndex returns the index of the tile on the provided row and col index.
+Returns false if the grid has no such tile.
+*/
+func (topology *gridTopology) TileIndex(rowIndex int, colIndex int) (int, bool) {
+	if rowIndex < 0 || rowIndex > topology.rows-1 || colIndex < 0 || colIndex > topology.cols-1 {
+		return 0, false
+	}
+
+	return rowIndex*topology.cols + colIndex, true
+}
+
+/*
+AdjacentTiles returns the indexes of the tiles adjacent to the tile with the
+provided index.
+*/
+func (topology *gridTopology) AdjacentTiles(tileIndex int) []int {
+	return topology.adjacent[tileIndex]
+}
+
+// MaskedTopology removes the cells of a mask from a topology, so they are not
+// tiles of the board and are not adjacent to any tile
+type maskedTopology struct {
+	base   ITopology
+	masked []bool
+	// The indexes of the tiles adjacent to each tile, without the masked cells
+	adjacent [][]int
+}
+
+/*
+NewMaskedTopology creates a topology with the tiles of the base topology,
+except the ones with the masked indexes.
+*/
+func NewMaskedTopology(base ITopology, maskedTiles []int) ITopology {
+	topology := &maskedTopology{
+		base:     base,
+		masked:   make([]bool, base.NumTiles()),
+		adjacent: make([][]int, base.NumTiles()),
+	}
+
+	for _, tileIndex := range maskedTiles {
+		if tileIndex >= 0 && tileIndex < len(topology.masked) {
+			topology.masked[tileIndex] = true
+		}
+	}
+
+	for tileIndex := range topology.adjacent {
+		tileIndexes := []int{}
+		if !topology.masked[tileIndex] {
+			for _, adjacentIndex := range base.AdjacentTiles(tileIndex) {
+				if !topology.masked[adjacentIndex] {
+					tileIndexes = append(tileIndexes, adjacentIndex)
+				}
+			}
+		}
+		topology.adjacent[tileIndex] = tileIndexes
+	}
+
+	return topology
+}
+
+/*
+NumTiles returns the number of tile indexes of the base topology, including
+the masked ones.
+*/
+func (topology *maskedTopology) NumTiles() int {
+	return topology.base.NumTiles()
+}
+
+/*
+TileIndex returns the index of the tile on the provided row and col index.
+Returns false if the base topology has no such tile or it is masked.
+*/
+func (topology *maskedTopology) TileIndex(rowIndex int, colIndex int) (int, bool) {
+	tileIndex, ok := topology.base.TileIndex(rowIndex, colIndex)
+	if !ok || topology.masked[tileIndex] {
+		return 0, false
+	}
+
+	return tileIndex, true
+}
+
+/*
+AdjacentTiles returns the indexes of the tiles adjacent to the tile with the
+provided index, without the masked ones.
+*/
+func (topology *maskedTopology) AdjacentTiles(tileIndex int) []int {
+	return topology.adjacent[tileIndex]
+}
@@ -0,0 +1,212 @@
+package minefield_test
+
+import (
+	"testing"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+/*
+lineTopology is a topology where each tile is only adjacent to the tiles on its
+left and right.
+*/
+type lineTopology struct {
+	cols int
+}
+
+func (topology *lineTopology) NumTiles() int {
+	return topology.cols
+}
+
+func (topology *lineTopology) TileIndex(rowIndex int, colIndex int) (int, bool) {
+	return colIndex, rowIndex == 0 && colIndex >= 0 && colIndex < topology.cols
+}
+
+func (topology *lineTopology) AdjacentTiles(tileIndex int) []int {
+	tileIndexes := []int{}
+	if tileIndex > 0 {
+		tileIndexes = append(tileIndexes, tileIndex-1)
+	}
+	if tileIndex < topology.cols-1 {
+		tileIndexes = append(tileIndexes, tileIndex+1)
+	}
+	return tileIndexes
+}
+
+type topologyTestSuite struct {
+	suite.Suite
+}
+
+func (suite *topologyTestSuite) TestSquareTopologySkipsTheTilesPastTheEdges() {
+	sut := minefield.NewSquareTopology(3, 3)
+
+	require.Equal(suite.T(), 9, sut.NumTiles())
+	require.Equal(suite.T(), []int{0, 1, 2, 3, 5, 6, 7, 8}, sut.AdjacentTiles(4))
+	require.Equal(suite.T(), []int{1, 3, 4}, sut.AdjacentTiles(0))
+}
+
+func (suite *topologyTestSuite) TestTileIndexReturnsFalseForATileOutsideTheGrid() {
+	sut := minefield.NewSquareTopology(3, 4)
+
+	tileIndex, ok := sut.TileIndex(2, 3)
+	require.Equal(suite.T(), true, ok)
+	require.Equal(suite.T(), 11, tileIndex)
+
+	_, ok = sut.TileIndex(0, 4)
+	require.Equal(suite.T(), false, ok)
+	_, ok = sut.TileIndex(-1, 0)
+	require.Equal(suite.T(), false, ok)
+}
+
+func (suite *topologyTestSuite) TestTorusTopologyWrapsTheEdgesSoACornerHasEightAdjacentTiles() {
+	sut := minefield.NewTorusTopology(4, 4)
+
+	require.ElementsMatch(suite.T(), []int{15, 12, 13, 3, 1, 7, 4, 5}, sut.AdjacentTiles(0))
+}
+
+func (suite *topologyTestSuite) TestTorusTopologyCountsATileOnceOnASmallGrid() {
+	sut := minefield.NewTorusTopology(2, 2)
+
+	require.ElementsMatch(suite.T(), []int{1, 2, 3}, sut.AdjacentTiles(0))
+}
+
+func (suite *topologyTestSuite) TestKnightTopologyReturnsTheTilesAKnightsMoveAway() {
+	sut := minefield.NewKnightTopology(3, 3)
+
+	require.Equal(suite.T(), []int{5, 7}, sut.AdjacentTiles(0))
+	require.Equal(suite.T(), []int{}, sut.AdjacentTiles(4))
+}
+
+func (suite *topologyTestSuite) TestRadiusTwoTopologyReturnsTheTilesUpToTwoRowsAndColsAway() {
+	sut := minefield.NewRadiusTwoTopology(5, 5)
+
+	require.Equal(suite.T(), 24, len(sut.AdjacentTiles(12)))
+	require.Equal(suite.T(), []int{1, 2, 5, 6, 7, 10, 11, 12}, sut.AdjacentTiles(0))
+}
+
+func (suite *topologyTestSuite) TestNewTopologyReturnsTheTopologyOfTheConstant() {
+	sut, err := minefield.NewTopology(configs.TopologyHex, 3, 3)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{1, 2, 3, 5, 7, 8}, sut.AdjacentTiles(4))
+}
+
+func (suite *topologyTestSuite) TestNewTopologyReturnsAnErrorIfTheTopologyIsNotKnown() {
+	_, err := minefield.NewTopology(7, 3, 3)
+
+	require.EqualError(suite.T(), err, "The topology '7' is not known")
+}
+
+func (suite *topologyTestSuite) TestGenerateUsesTheCustomTopology() {
+	sut, err := minefield.Generate(minefield.MinefieldConfig{
+		NumRows:        1,
+		NumCols:        5,
+		NumMines:       1,
+		CustomTopology: &lineTopology{cols: 5},
+		MineTiles:      []int{2},
+	})
+	require.Nil(suite.T(), err)
+
+	tileIndexes, err := sut.RevealTile(0, 0)
+	require.Nil(suite.T(), err)
+	require.ElementsMatch(suite.T(), []int{0, 1}, tileIndexes)
+
+	tile, _ := sut.Tile(0, 3)
+	require.Equal(suite.T(), 1, tile.AdjacentMines())
+}
+
+func (suite *topologyTestSuite) TestGenerateReturnsAnErrorIfTheTopologyIsNotKnown() {
+	_, err := minefield.Generate(minefield.MinefieldConfig{
+		NumRows:  3,
+		NumCols:  3,
+		NumMines: 1,
+		Topology: 7,
+	})
+
+	require.EqualError(suite.T(), err, "The topology '7' is not known")
+}
+
+func (suite *topologyTestSuite) TestATorusMinefieldCountsAndChordsAcrossTheEdges() {
+	sut, err := minefield.Generate(minefield.MinefieldConfig{
+		NumRows:   4,
+		NumCols:   4,
+		NumMines:  1,
+		Topology:  configs.TopologyTorus,
+		MineTiles: []int{0},
+	})
+	require.Nil(suite.T(), err)
+
+	tile, _ := sut.Tile(3, 3)
+	require.Equal(suite.T(), 1, tile.AdjacentMines())
+
+	tileIndexes, _ := sut.RevealTile(3, 3)
+	require.Equal(suite.T(), []int{15}, tileIndexes)
+	sut.ToggleFlag(0, 0)
+	tileIndexes, err = sut.ProcessAdjacentTiles(3, 3)
+
+	require.Nil(suite.T(), err)
+	require.ElementsMatch(suite.T(), []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}, tileIndexes)
+}
+
+func (suite *topologyTestSuite) TestMaskedTopologyRemovesTheMaskedTilesFromTheTilesAndTheirAdjacentTiles() {
+	sut := minefield.NewMaskedTopology(minefield.NewSquareTopology(3, 3), []int{1, 4})
+
+	_, ok := sut.TileIndex(1, 1)
+	require.Equal(suite.T(), false, ok)
+	tileIndex, ok := sut.TileIndex(1, 0)
+	require.Equal(suite.T(), true, ok)
+	require.Equal(suite.T(), 3, tileIndex)
+	require.Equal(suite.T(), []int{3}, sut.AdjacentTiles(0))
+	require.Equal(suite.T(), []int{}, sut.AdjacentTiles(4))
+}
+
+func (suite *topologyTestSuite) TestAMaskedMinefieldOnlyPlacesMinesOnItsTiles() {
+	sut, err := minefield.Generate(minefield.MinefieldConfig{
+		NumRows:     3,
+		NumCols:     5,
+		NumMines:    3,
+		Seed:        "mask",
+		SafeStart:   configs.SafeStartArea,
+		MaskedTiles: []int{2, 7, 12},
+	})
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), 12, sut.NumTiles())
+	require.Equal(suite.T(), false, sut.HasTile(1, 2))
+	require.Equal(suite.T(), true, sut.HasTile(1, 3))
+
+	tileIndexes, err := sut.RevealTile(1, 0)
+	require.Nil(suite.T(), err)
+	require.ElementsMatch(suite.T(), []int{0, 1, 5, 6, 10, 11}, tileIndexes)
+
+	numMines := 0
+	for _, tileIndex := range []int{3, 4, 8, 9, 13, 14} {
+		tile, _ := sut.Tile(tileIndex/5, tileIndex%5)
+		if tile.HasMine() {
+			numMines++
+		}
+	}
+	require.Equal(suite.T(), 3, numMines)
+
+	_, err = sut.Tile(1, 2)
+	require.EqualError(suite.T(), err, "Tile not found for row index '1' and col index '2'")
+}
+
+func (suite *topologyTestSuite) TestGenerateReturnsAnErrorIfAMineIsOnAMaskedTile() {
+	_, err := minefield.Generate(minefield.MinefieldConfig{
+		NumRows:     3,
+		NumCols:     3,
+		NumMines:    1,
+		MineTiles:   []int{4},
+		MaskedTiles: []int{4},
+	})
+
+	require.NotNil(suite.T(), err)
+}
+
+func TestTopologySuite(t *testing.T) {
+	suite.Run(t, new(topologyTestSuite))
+}
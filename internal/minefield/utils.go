@@ -1,6 +1,9 @@
 package minefield
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+)
 
 /*
 findTilePatch finds all the tile indexes that belong to the patch of the provded
@@ -27,23 +30,12 @@ func findTilePatch(minefield *minefield, initialTileIndex int) []int {
 			continue
 		}
 
-		for rOffset := -1; rOffset <= 1; rOffset++ {
-			rIndex := checkRowIndex + rOffset
-			if rIndex < 0 || rIndex > minefield.rows-1 {
+		for _, adjacentIndex := range minefield.topology.AdjacentTiles(indexToCheck) {
+			if _, ok := tilesToReveal[fmt.Sprintf("%v:%v", adjacentIndex/minefield.cols, adjacentIndex%minefield.cols)]; ok {
 				continue
 			}
 
-			for cOffset := -1; cOffset <= 1; cOffset++ {
-				cIndex := checkColIndex + cOffset
-				if cIndex < 0 || cIndex > minefield.cols-1 {
-					continue
-				}
-				if _, ok := tilesToReveal[fmt.Sprintf("%v:%v", rIndex, cIndex)]; ok {
-					continue
-				}
-
-				tilesToCheck = append(tilesToCheck, rIndex*minefield.cols+cIndex)
-			}
+			tilesToCheck = append(tilesToCheck, adjacentIndex)
 		}
 	}
 
@@ -75,9 +67,14 @@ func uniqueTileIndexes(source []int) []int {
 }
 
 /*
-calcTileIndex calulates the tile index based on the tile's row and col
-indexes.
+sortedTileIndexes converts a set of tile indexes into a sorted slice.
 */
-func calcTileIndex(rowIndex int, colIndex int, numCols int) int {
-	return rowIndex*numCols + colIndex
+func sortedTileIndexes(source map[int]bool) []int {
+	output := make([]int, 0, len(source))
+	for tIndex := range source {
+		output = append(output, tIndex)
+	}
+	sort.Ints(output)
+
+	return output
 }
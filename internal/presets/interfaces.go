@@ -0,0 +1,22 @@
+package presets
+
+import (
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+)
+
+type IPresets interface {
+	/*
+		Save adds the size option with the name, replacing any preset with the same
+		name, and writes the presets to the user's configuration directory.
+	*/
+	Save(name string, option configs.SizeOption) error
+	/*
+		Remove deletes the preset with the name and writes the presets to the
+		user's configuration directory.
+	*/
+	Remove(name string) error
+	/*
+		Options returns a copy of the presets, by name.
+	*/
+	Options() map[string]configs.SizeOption
+}
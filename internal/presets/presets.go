@@ -0,0 +1,190 @@
+/*
+Package presets keeps the custom difficulties created by the player, in a file
+of the user's configuration directory
+*/
+package presets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sync"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/pedrohenriques/go-minesweeper/internal/storage"
+)
+
+// The name of the file where the presets are kept
+const fileName string = "presets.json"
+
+// The version of the presets file format
+const presetsVersion int = 1
+
+// Error: The presets file was written by an incompatible version
+type unsupportedPresetsVersionError struct {
+	Version int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e unsupportedPresetsVersionError) Error() string {
+	return fmt.Sprintf("The presets version '%v' is not supported", e.Version)
+}
+
+// Error: A preset must have a name
+type emptyPresetNameError struct{}
+
+/*
+Error prints the message for this error.
+*/
+func (e emptyPresetNameError) Error() string {
+	return "The preset must have a name"
+}
+
+// Error: There is no preset with the name
+type presetNotFoundError struct {
+	Name string
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e presetNotFoundError) Error() string {
+	return fmt.Sprintf("The preset '%v' does not exist", e.Name)
+}
+
+// PresetsDocument contains everything written to the presets file
+type presetsDocument struct {
+	Version int
+	Presets map[string]configs.SizeOption
+}
+
+// Presets holds the custom difficulties, by name
+type presets struct {
+	mutex   sync.Mutex
+	options map[string]configs.SizeOption
+}
+
+/*
+Load reads the presets from the user's configuration directory.
+If no presets were written yet an empty set is returned.
+*/
+func Load() (IPresets, error) {
+	output := &presets{
+		options: map[string]configs.SizeOption{},
+	}
+
+	data, error := storage.Read(fileName)
+	if errors.Is(error, fs.ErrNotExist) {
+		return output, nil
+	}
+	if error != nil {
+		return nil, error
+	}
+
+	document := presetsDocument{}
+	error = json.Unmarshal(data, &document)
+	if error != nil {
+		return nil, error
+	}
+	if document.Version != presetsVersion {
+		return nil, unsupportedPresetsVersionError{
+			Version: document.Version,
+		}
+	}
+
+	for name, option := range document.Presets {
+		output.options[name] = option
+	}
+
+	return output, nil
+}
+
+/*
+Validate returns an error if the size option can not be played with every first
+click option.
+*/
+func Validate(option configs.SizeOption) error {
+	return game.Validate(game.GameConfig{
+		NumRows:   option.NumRows,
+		NumCols:   option.NumCols,
+		NumMines:  option.NumMines,
+		Lives:     1,
+		SafeStart: configs.SafeStartArea,
+	})
+}
+
+/*
+Save adds the size option with the name, replacing any preset with the same
+name, and writes the presets to the user's configuration directory.
+*/
+func (presets *presets) Save(name string, option configs.SizeOption) error {
+	if name == "" {
+		return emptyPresetNameError{}
+	}
+
+	error := Validate(option)
+	if error != nil {
+		return error
+	}
+
+	presets.mutex.Lock()
+	defer presets.mutex.Unlock()
+
+	presets.options[name] = option
+
+	return presets.write()
+}
+
+/*
+Remove deletes the preset with the name and writes the presets to the user's
+configuration directory.
+*/
+func (presets *presets) Remove(name string) error {
+	presets.mutex.Lock()
+	defer presets.mutex.Unlock()
+
+	if _, ok := presets.options[name]; !ok {
+		return presetNotFoundError{
+			Name: name,
+		}
+	}
+
+	delete(presets.options, name)
+
+	return presets.write()
+}
+
+/*
+Options returns a copy of the presets, by name.
+*/
+func (presets *presets) Options() map[string]configs.SizeOption {
+	presets.mutex.Lock()
+	defer presets.mutex.Unlock()
+
+	output := make(map[string]configs.SizeOption, len(presets.options))
+	for name, option := range presets.options {
+		output[name] = option
+	}
+
+	return output
+}
+
+/*
+write writes the presets to the user's configuration directory.
+The mutex must be held.
+*/
+func (presets *presets) write() error {
+	data, error := json.Marshal(presetsDocument{
+		Version: presetsVersion,
+		Presets: presets.options,
+	})
+	if error != nil {
+		return error
+	}
+
+	return storage.Write(fileName, data)
+}
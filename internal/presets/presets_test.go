@@ -0,0 +1,111 @@
+package presets_test
+
+import (
+	"testing"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/presets"
+	"github.com/pedrohenriques/go-minesweeper/internal/storage"
+	"github.com/pedrohenriques/go-minesweeper/internal/storage/storagetest"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type presetsTestSuite struct {
+	suite.Suite
+}
+
+func (suite *presetsTestSuite) SetupTest() {
+	storagetest.UseTempDir(suite.T())
+}
+
+func (suite *presetsTestSuite) TestLoadReturnsNoPresetsIfNoneWereWritten() {
+	sut, err := presets.Load()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), map[string]configs.SizeOption{}, sut.Options())
+}
+
+func (suite *presetsTestSuite) TestLoadReturnsThePresetsThatWereSaved() {
+	sut, _ := presets.Load()
+	option := configs.SizeOption{NumRows: 20, NumCols: 40, NumMines: 150}
+	require.Nil(suite.T(), sut.Save("wide", option))
+
+	loaded, err := presets.Load()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), map[string]configs.SizeOption{"wide": option}, loaded.Options())
+}
+
+func (suite *presetsTestSuite) TestLoadReturnsAnErrorIfTheVersionIsNotSupported() {
+	require.Nil(suite.T(), storage.Write("presets.json", []byte(`{"Version": 99}`)))
+
+	_, err := presets.Load()
+
+	require.EqualError(suite.T(), err, "The presets version '99' is not supported")
+}
+
+func (suite *presetsTestSuite) TestSaveReplacesThePresetWithTheSameName() {
+	sut, _ := presets.Load()
+	sut.Save("mine", configs.SizeOption{NumRows: 10, NumCols: 10, NumMines: 10})
+
+	err := sut.Save("mine", configs.SizeOption{NumRows: 12, NumCols: 12, NumMines: 20})
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), map[string]configs.SizeOption{
+		"mine": {NumRows: 12, NumCols: 12, NumMines: 20},
+	}, sut.Options())
+}
+
+func (suite *presetsTestSuite) TestSaveReturnsAnErrorIfTheNameIsEmpty() {
+	sut, _ := presets.Load()
+
+	err := sut.Save("", configs.SizeOption{NumRows: 10, NumCols: 10, NumMines: 10})
+
+	require.EqualError(suite.T(), err, "The preset must have a name")
+	require.Equal(suite.T(), 0, len(sut.Options()))
+}
+
+func (suite *presetsTestSuite) TestSaveReturnsAnErrorIfTheFirstClickAreaDoesNotFitWithoutMines() {
+	sut, _ := presets.Load()
+
+	err := sut.Save("full", configs.SizeOption{NumRows: 10, NumCols: 10, NumMines: 92})
+
+	require.EqualError(suite.T(), err, "The number of mines '92' is not valid, it must be between '0' and '91'")
+	require.Equal(suite.T(), 0, len(sut.Options()))
+}
+
+func (suite *presetsTestSuite) TestRemoveDeletesThePresetFromTheFile() {
+	sut, _ := presets.Load()
+	sut.Save("a", configs.SizeOption{NumRows: 10, NumCols: 10, NumMines: 10})
+	sut.Save("b", configs.SizeOption{NumRows: 11, NumCols: 11, NumMines: 11})
+
+	err := sut.Remove("a")
+
+	require.Nil(suite.T(), err)
+	loaded, _ := presets.Load()
+	require.Equal(suite.T(), map[string]configs.SizeOption{
+		"b": {NumRows: 11, NumCols: 11, NumMines: 11},
+	}, loaded.Options())
+}
+
+func (suite *presetsTestSuite) TestRemoveReturnsAnErrorIfThePresetDoesNotExist() {
+	sut, _ := presets.Load()
+
+	err := sut.Remove("missing")
+
+	require.EqualError(suite.T(), err, "The preset 'missing' does not exist")
+}
+
+func (suite *presetsTestSuite) TestOptionsReturnsACopyOfThePresets() {
+	sut, _ := presets.Load()
+	sut.Save("a", configs.SizeOption{NumRows: 10, NumCols: 10, NumMines: 10})
+
+	sut.Options()["b"] = configs.SizeOption{}
+
+	require.Equal(suite.T(), 1, len(sut.Options()))
+}
+
+func TestPresetsSuite(t *testing.T) {
+	suite.Run(t, new(presetsTestSuite))
+}
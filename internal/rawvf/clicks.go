@@ -0,0 +1,104 @@
+package rawvf
+
+import (
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+)
+
+// Click is a click on a square, made of one or more mouse events
+type Click struct {
+	Time time.Duration
+	// One of configs.PrimaryClick, configs.SecondaryClick or configs.BothClick
+	Type     int
+	RowIndex int
+	ColIndex int
+}
+
+/*
+Clicks converts the mouse events of a video into clicks, the way a classic
+minesweeper program handles them.
+A left release reveals a square and a right press flags it. Releasing a button
+while the other is held, or releasing the middle button, is a chord, after which
+releasing the other button does nothing.
+Events on squares outside the board do not create clicks.
+*/
+func Clicks(video Video) []Click {
+	clicks := []Click{}
+	leftDown := false
+	rightDown := false
+	chorded := false
+
+	addClick := func(event Event, clickType int) {
+		if event.RowIndex < 0 || event.RowIndex >= video.Height || event.ColIndex < 0 || event.ColIndex >= video.Width {
+			return
+		}
+
+		clicks = append(clicks, Click{
+			Time:     event.Time,
+			Type:     clickType,
+			RowIndex: event.RowIndex,
+			ColIndex: event.ColIndex,
+		})
+	}
+
+	for _, event := range video.Events {
+		switch event.Name {
+		case "lc":
+			leftDown = true
+		case "rc":
+			rightDown = true
+			if !leftDown {
+				addClick(event, configs.SecondaryClick)
+			}
+		case "lr":
+			leftDown = false
+			if rightDown {
+				addClick(event, configs.BothClick)
+				chorded = true
+			} else if !chorded {
+				addClick(event, configs.PrimaryClick)
+			}
+		case "rr":
+			rightDown = false
+			if leftDown {
+				addClick(event, configs.BothClick)
+				chorded = true
+			}
+		case "mr":
+			addClick(event, configs.BothClick)
+		}
+
+		if !leftDown && !rightDown {
+			chorded = false
+		}
+	}
+
+	return clicks
+}
+
+/*
+ClickEvents converts a click into the mouse events Clicks converts back into the
+same click.
+*/
+func ClickEvents(click Click) []Event {
+	event := func(name string) Event {
+		return Event{
+			Time:     click.Time,
+			Name:     name,
+			RowIndex: click.RowIndex,
+			ColIndex: click.ColIndex,
+		}
+	}
+
+	switch click.Type {
+	case configs.PrimaryClick:
+		return []Event{event("lc"), event("lr")}
+	case configs.SecondaryClick:
+		return []Event{event("rc"), event("rr")}
+	case configs.BothClick:
+		return []Event{event("lc"), event("rc"), event("lr"), event("rr")}
+	}
+
+	return []Event{}
+}
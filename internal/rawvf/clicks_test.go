@@ -0,0 +1,102 @@
+package rawvf_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/rawvf"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type clicksTestSuite struct {
+	suite.Suite
+}
+
+/*
+newVideo creates a 4x4 video with the provided events, all on the square at row
+index 1 and col index 2.
+*/
+func newVideo(names ...string) rawvf.Video {
+	video := rawvf.Video{Width: 4, Height: 4}
+	for eventIndex, name := range names {
+		video.Events = append(video.Events, rawvf.Event{
+			Time:     time.Duration(eventIndex) * time.Second,
+			Name:     name,
+			RowIndex: 1,
+			ColIndex: 2,
+		})
+	}
+
+	return video
+}
+
+func (suite *clicksTestSuite) TestClicksConvertsALeftReleaseIntoAPrimaryClick() {
+	expected := []rawvf.Click{
+		{Time: time.Second, Type: configs.PrimaryClick, RowIndex: 1, ColIndex: 2},
+	}
+
+	actual := rawvf.Clicks(newVideo("lc", "lr"))
+
+	require.Equal(suite.T(), expected, actual)
+}
+
+func (suite *clicksTestSuite) TestClicksConvertsARightPressIntoASecondaryClick() {
+	expected := []rawvf.Click{
+		{Time: 0, Type: configs.SecondaryClick, RowIndex: 1, ColIndex: 2},
+	}
+
+	actual := rawvf.Clicks(newVideo("rc", "rr"))
+
+	require.Equal(suite.T(), expected, actual)
+}
+
+func (suite *clicksTestSuite) TestClicksConvertsAReleaseWhileTheOtherButtonIsHeldIntoOneBothClick() {
+	for _, names := range [][]string{
+		{"lc", "rc", "lr", "rr"},
+		{"lc", "rc", "rr", "lr"},
+	} {
+		expected := []rawvf.Click{
+			{Time: 2 * time.Second, Type: configs.BothClick, RowIndex: 1, ColIndex: 2},
+		}
+
+		actual := rawvf.Clicks(newVideo(names...))
+
+		require.Equalf(suite.T(), expected, actual, "events: %v", names)
+	}
+}
+
+func (suite *clicksTestSuite) TestClicksConvertsAMiddleReleaseIntoABothClick() {
+	expected := []rawvf.Click{
+		{Time: time.Second, Type: configs.BothClick, RowIndex: 1, ColIndex: 2},
+	}
+
+	actual := rawvf.Clicks(newVideo("mc", "mr"))
+
+	require.Equal(suite.T(), expected, actual)
+}
+
+func (suite *clicksTestSuite) TestClicksIgnoresTheEventsOutsideTheBoard() {
+	video := newVideo("lc", "lr")
+	video.Events[1].ColIndex = 4
+
+	actual := rawvf.Clicks(video)
+
+	require.Equal(suite.T(), []rawvf.Click{}, actual)
+}
+
+func (suite *clicksTestSuite) TestClickEventsReturnsTheEventsThatConvertBackIntoTheClick() {
+	for _, clickType := range []int{configs.PrimaryClick, configs.SecondaryClick, configs.BothClick} {
+		click := rawvf.Click{Time: time.Second, Type: clickType, RowIndex: 1, ColIndex: 2}
+		video := rawvf.Video{Width: 4, Height: 4, Events: rawvf.ClickEvents(click)}
+
+		actual := rawvf.Clicks(video)
+
+		require.Equalf(suite.T(), []rawvf.Click{click}, actual, "click type: %v", clickType)
+	}
+}
+
+func TestClicksSuite(t *testing.T) {
+	suite.Run(t, new(clicksTestSuite))
+}
@@ -0,0 +1,252 @@
+/*
+Package rawvf reads and writes minesweeper videos in the RAWVF text format used
+by the minesweeper community, and converts their mouse events into clicks
+*/
+package rawvf
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The version written in the header of the exported videos
+const formatVersion string = "Rev5"
+
+// The size, in pixels, of a square when converting its coordinates to pixels
+const squareSizePx int = 16
+
+// An event line: time, event name and optionally the square and pixel coordinates
+var eventRegex = regexp.MustCompile(`^(-?\d+(?:\.\d+)?)\s+([a-zA-Z]+)(?:\s+(\d+)\s+(\d+)(?:\s+\((-?\d+)\s+(-?\d+)\))?)?`)
+
+// Error: A line of the video could not be read
+type invalidLineError struct {
+	LineNumber int
+	Line       string
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e invalidLineError) Error() string {
+	return fmt.Sprintf("The line '%v' with content '%v' is not valid RAWVF", e.LineNumber, e.Line)
+}
+
+// Error: A required header field is missing or is not a valid number
+type invalidHeaderError struct {
+	Field string
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e invalidHeaderError) Error() string {
+	return fmt.Sprintf("The header field '%v' is missing or is not a valid number", e.Field)
+}
+
+// Error: The board does not match the width and height in the header
+type invalidBoardError struct {
+	Width  int
+	Height int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e invalidBoardError) Error() string {
+	return fmt.Sprintf("The board does not have '%v' rows of '%v' squares", e.Height, e.Width)
+}
+
+// Video contains the board and the mouse events of a RAWVF video
+type Video struct {
+	Width  int
+	Height int
+	Mines  int
+	// The other header fields, such as the player and the program, by name
+	Properties map[string]string
+	// The indexes of the squares with a mine, row by row
+	MineTiles []int
+	Events    []Event
+}
+
+// Event describes a mouse event of a RAWVF video
+type Event struct {
+	// The time since the start of the video
+	Time time.Duration
+	// The RAWVF event name, such as "lc" (left click) or "rr" (right release)
+	Name string
+	// Zero-indexed coordinates of the square, -1 if the event has none
+	RowIndex int
+	ColIndex int
+}
+
+/*
+Parse reads a RAWVF video.
+The board uses '*' for squares with a mine and any other character for safe
+squares.
+Event squares are written as "col row", one-indexed.
+*/
+func Parse(data []byte) (Video, error) {
+	video := Video{
+		Properties: map[string]string{},
+		MineTiles:  []int{},
+		Events:     []Event{},
+	}
+	boardRows := []string{}
+	section := "header"
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		switch {
+		case line == "Board:":
+			section = "board"
+		case line == "Events:":
+			section = "events"
+		case section == "header":
+			name, value, found := strings.Cut(line, ":")
+			if !found {
+				return Video{}, invalidLineError{LineNumber: lineNumber, Line: line}
+			}
+			video.Properties[strings.TrimSpace(name)] = strings.TrimSpace(value)
+		case section == "board":
+			boardRows = append(boardRows, line)
+		case section == "events":
+			event, ok := parseEvent(line)
+			if !ok {
+				return Video{}, invalidLineError{LineNumber: lineNumber, Line: line}
+			}
+			video.Events = append(video.Events, event)
+		}
+	}
+	if error := scanner.Err(); error != nil {
+		return Video{}, error
+	}
+
+	for _, field := range []string{"Width", "Height", "Mines"} {
+		value, error := strconv.Atoi(video.Properties[field])
+		if error != nil {
+			return Video{}, invalidHeaderError{Field: field}
+		}
+		delete(video.Properties, field)
+
+		switch field {
+		case "Width":
+			video.Width = value
+		case "Height":
+			video.Height = value
+		case "Mines":
+			video.Mines = value
+		}
+	}
+
+	if len(boardRows) != video.Height {
+		return Video{}, invalidBoardError{Width: video.Width, Height: video.Height}
+	}
+	for rowIndex, row := range boardRows {
+		if len(row) != video.Width {
+			return Video{}, invalidBoardError{Width: video.Width, Height: video.Height}
+		}
+		for colIndex, char := range row {
+			if char == '*' {
+				video.MineTiles = append(video.MineTiles, rowIndex*video.Width+colIndex)
+			}
+		}
+	}
+
+	return video, nil
+}
+
+/*
+parseEvent reads an event line.
+Returns false if the line is not an event.
+*/
+func parseEvent(line string) (Event, bool) {
+	matches := eventRegex.FindStringSubmatch(line)
+	if matches == nil {
+		return Event{}, false
+	}
+
+	seconds, error := strconv.ParseFloat(matches[1], 64)
+	if error != nil {
+		return Event{}, false
+	}
+
+	event := Event{
+		Time:     time.Duration(seconds * float64(time.Second)),
+		Name:     matches[2],
+		RowIndex: -1,
+		ColIndex: -1,
+	}
+	if matches[3] != "" {
+		col, _ := strconv.Atoi(matches[3])
+		row, _ := strconv.Atoi(matches[4])
+		event.ColIndex = col - 1
+		event.RowIndex = row - 1
+	}
+
+	return event, true
+}
+
+/*
+Format writes a RAWVF video.
+The other header fields are written in alphabetical order.
+*/
+func Format(video Video) []byte {
+	var buffer bytes.Buffer
+
+	fmt.Fprintf(&buffer, "RawVF_Version: %v\n", formatVersion)
+
+	names := []string{}
+	for name := range video.Properties {
+		if name != "RawVF_Version" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&buffer, "%v: %v\n", name, video.Properties[name])
+	}
+
+	fmt.Fprintf(&buffer, "Width: %v\nHeight: %v\nMines: %v\n", video.Width, video.Height, video.Mines)
+
+	mines := make(map[int]bool, len(video.MineTiles))
+	for _, tileIndex := range video.MineTiles {
+		mines[tileIndex] = true
+	}
+	buffer.WriteString("Board:\n")
+	for rowIndex := 0; rowIndex < video.Height; rowIndex++ {
+		for colIndex := 0; colIndex < video.Width; colIndex++ {
+			if mines[rowIndex*video.Width+colIndex] {
+				buffer.WriteByte('*')
+			} else {
+				buffer.WriteByte('0')
+			}
+		}
+		buffer.WriteByte('\n')
+	}
+
+	buffer.WriteString("Events:\n")
+	for _, event := range video.Events {
+		fmt.Fprintf(&buffer, "%.3f %v", event.Time.Seconds(), event.Name)
+		if event.RowIndex >= 0 && event.ColIndex >= 0 {
+			fmt.Fprintf(&buffer, " %v %v (%v %v)",
+				event.ColIndex+1, event.RowIndex+1,
+				event.ColIndex*squareSizePx+squareSizePx/2, event.RowIndex*squareSizePx+squareSizePx/2)
+		}
+		buffer.WriteByte('\n')
+	}
+
+	return buffer.Bytes()
+}
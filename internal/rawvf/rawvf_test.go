@@ -0,0 +1,115 @@
+package rawvf_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/rawvf"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type rawvfTestSuite struct {
+	suite.Suite
+}
+
+const sampleVideo = `RawVF_Version: Rev5
+Program: Minesweeper Arbiter
+Player: Someone
+Width: 4
+Height: 3
+Mines: 2
+Marks: Off
+Board:
+*000
+000*
+0000
+Events:
+0.00 start
+0.00 lc 3 2 (40 24)
+0.08 lr 3 2 (40 24)
+1.25 mv 1 1 (5 5)
+2.50 rc 1 1 (5 5)
+`
+
+func (suite *rawvfTestSuite) TestParseReadsTheBoard() {
+	actual, err := rawvf.Parse([]byte(sampleVideo))
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), 4, actual.Width)
+	require.Equal(suite.T(), 3, actual.Height)
+	require.Equal(suite.T(), 2, actual.Mines)
+	require.Equal(suite.T(), []int{0, 7}, actual.MineTiles)
+}
+
+func (suite *rawvfTestSuite) TestParseKeepsTheOtherHeaderFields() {
+	actual, err := rawvf.Parse([]byte(sampleVideo))
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), "Someone", actual.Properties["Player"])
+	require.Equal(suite.T(), "Off", actual.Properties["Marks"])
+	require.NotContains(suite.T(), actual.Properties, "Width")
+}
+
+func (suite *rawvfTestSuite) TestParseReadsTheEventsWithZeroIndexedSquares() {
+	expected := []rawvf.Event{
+		{Time: 0, Name: "start", RowIndex: -1, ColIndex: -1},
+		{Time: 0, Name: "lc", RowIndex: 1, ColIndex: 2},
+		{Time: 80 * time.Millisecond, Name: "lr", RowIndex: 1, ColIndex: 2},
+		{Time: 1250 * time.Millisecond, Name: "mv", RowIndex: 0, ColIndex: 0},
+		{Time: 2500 * time.Millisecond, Name: "rc", RowIndex: 0, ColIndex: 0},
+	}
+
+	actual, err := rawvf.Parse([]byte(sampleVideo))
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), expected, actual.Events)
+}
+
+func (suite *rawvfTestSuite) TestParseReturnsAnErrorIfTheBoardDoesNotMatchTheHeader() {
+	_, err := rawvf.Parse([]byte("Width: 2\nHeight: 2\nMines: 1\nBoard:\n*0\nEvents:\n"))
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *rawvfTestSuite) TestParseReturnsAnErrorIfAHeaderFieldIsMissing() {
+	_, err := rawvf.Parse([]byte("Width: 2\nMines: 1\nBoard:\n*0\n00\nEvents:\n"))
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *rawvfTestSuite) TestParseReturnsAnErrorIfAnEventIsNotValid() {
+	_, err := rawvf.Parse([]byte("Width: 2\nHeight: 1\nMines: 1\nBoard:\n*0\nEvents:\nnot an event\n"))
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *rawvfTestSuite) TestFormatWritesAVideoThatParsesBackToTheSameVideo() {
+	expected, _ := rawvf.Parse([]byte(sampleVideo))
+
+	actual, err := rawvf.Parse(rawvf.Format(expected))
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), expected, actual)
+}
+
+func (suite *rawvfTestSuite) TestFormatWritesTheSquaresOneIndexedWithTheirPixelCoordinates() {
+	video := rawvf.Video{
+		Width:     2,
+		Height:    1,
+		Mines:     1,
+		MineTiles: []int{1},
+		Events: []rawvf.Event{
+			{Time: 1500 * time.Millisecond, Name: "lc", RowIndex: 0, ColIndex: 0},
+		},
+	}
+	expected := "RawVF_Version: Rev5\nWidth: 2\nHeight: 1\nMines: 1\nBoard:\n0*\nEvents:\n1.500 lc 1 1 (8 8)\n"
+
+	actual := rawvf.Format(video)
+
+	require.Equal(suite.T(), expected, string(actual))
+}
+
+func TestRawvfSuite(t *testing.T) {
+	suite.Run(t, new(rawvfTestSuite))
+}
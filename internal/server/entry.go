@@ -0,0 +1,215 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+)
+
+// The types of the events sent over the sockets of a game
+const (
+	// The visible state of every tile, sent when a socket connects
+	eventBoard = "board"
+	// The visible state of the tiles changed by an action
+	eventTiles = "tiles"
+	// The time elapsed since the game started, sent every second
+	eventTick = "tick"
+	// The game ended, with its final stats
+	eventEnd = "end"
+	// A command sent over the socket failed
+	eventError = "error"
+)
+
+// Error: The game has ended and does not accept actions
+type gameEndedError struct {
+	State int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e gameEndedError) Error() string {
+	return fmt.Sprintf("The game has ended with the state '%v'", e.State)
+}
+
+// Event is sent over the sockets of a game when it changes
+type event struct {
+	// One of the event* constants
+	Type  string
+	State int
+	Board *boardResponse `json:",omitempty"`
+	Tiles []changedTile  `json:",omitempty"`
+	// The time elapsed since the game started, in seconds
+	ElapsedSeconds int         `json:",omitempty"`
+	Stats          interface{} `json:",omitempty"`
+	Error          string      `json:",omitempty"`
+}
+
+// ChangedTile is the visible state of a tile changed by an action
+type changedTile struct {
+	TileIndex int
+	tileResponse
+}
+
+// Entry holds a game of the registry
+type entry struct {
+	// Held while the game is used, so the actions of a match are not
+	// interleaved, and while the sockets are counted.
+	// The games of a match share the mutex of the match
+	mutex       *sync.Mutex
+	game        game.IGame
+	spectatorId string
+	lastUsed    time.Time
+	// The ID of the match the game belongs to, empty if it is not in a match
+	matchId string
+	// The player of the match the game belongs to
+	player int
+	// The number of sockets streaming the game's events
+	numSockets int
+	// Closed when the game is removed from the registry, to close its sockets
+	removed chan struct{}
+	// True if the game was removed from the registry
+	closed bool
+}
+
+/*
+newEntry creates the entry of a game that was last used at the provided time.
+*/
+func newEntry(game game.IGame, spectatorId string, lastUsed time.Time, mutex *sync.Mutex) *entry {
+	return &entry{
+		mutex:       mutex,
+		game:        game,
+		spectatorId: spectatorId,
+		lastUsed:    lastUsed,
+		removed:     make(chan struct{}),
+	}
+}
+
+/*
+act applies a click of the provided type on a tile and returns the visible
+state of the changed tiles.
+The game sends the changes to its subscribers, such as the sockets.
+The entry's mutex must be held.
+
+clickType: 0 = primary | 1 = secondary | 2 = both
+*/
+func (gameEntry *entry) act(clickType int, rowIndex int, colIndex int) (actionResponse, error) {
+	gameInstance := gameEntry.game
+
+	if gameInstance.State() != configs.StateOnGoing {
+		return actionResponse{}, gameEndedError{
+			State: gameInstance.State(),
+		}
+	}
+
+	var tileIndexes []int
+	var error error
+
+	switch clickType {
+	case configs.PrimaryClick:
+		tileIndexes, error = gameInstance.RevealTile(rowIndex, colIndex)
+	case configs.SecondaryClick:
+		error = gameInstance.ToggleFlag(rowIndex, colIndex)
+		tileIndexes = []int{rowIndex*gameInstance.Config().NumCols + colIndex}
+	case configs.BothClick:
+		tileIndexes, error = gameInstance.ProcessAdjacentTiles(rowIndex, colIndex)
+	}
+	if error != nil {
+		return actionResponse{}, error
+	}
+
+	return actionResponse{
+		TileIndexes: tileIndexes,
+		Tiles:       changedTiles(gameInstance, tileIndexes),
+		State:       gameInstance.State(),
+	}, nil
+}
+
+/*
+changedTiles returns the visible state of the tiles with the provided indexes.
+*/
+func changedTiles(gameInstance game.IGame, tileIndexes []int) []changedTile {
+	numCols := gameInstance.Config().NumCols
+
+	tiles := make([]changedTile, len(tileIndexes))
+	for index, tileIndex := range tileIndexes {
+		tile, _ := gameInstance.Tile(tileIndex/numCols, tileIndex%numCols)
+		tiles[index] = changedTile{
+			TileIndex:    tileIndex,
+			tileResponse: visibleTile(tile),
+		}
+	}
+
+	return tiles
+}
+
+/*
+gameEvent returns the event sent over the sockets for an event of the game.
+Returns false if the game's event is not sent.
+*/
+func gameEvent(gameInstance game.IGame, kind int, tileIndexes []int) (event, bool) {
+	switch kind {
+	case configs.EventTileRevealed, configs.EventTileHidden, configs.EventFlagToggled:
+		return event{
+			Type:  eventTiles,
+			State: gameInstance.State(),
+			Tiles: changedTiles(gameInstance, tileIndexes),
+		}, true
+	case configs.EventGameWon, configs.EventGameLost:
+		return event{
+			Type:  eventEnd,
+			State: gameInstance.State(),
+			Stats: gameInstance.Stats(),
+		}, true
+	}
+
+	// The tiles of the lives lost were sent with the reveal, the time elapsed is
+	// sent by the ticks and a game is not resumed without an undo
+	return event{}, false
+}
+
+/*
+tick returns the event with the time elapsed since the game started.
+Returns false if the game has not started or has ended.
+*/
+func tick(gameInstance game.IGame, now time.Time) (event, bool) {
+	startTime := gameInstance.StartTime()
+	if startTime.IsZero() || gameInstance.State() != configs.StateOnGoing {
+		return event{}, false
+	}
+
+	return event{
+		Type:           eventTick,
+		State:          gameInstance.State(),
+		ElapsedSeconds: int(now.Sub(startTime) / time.Second),
+	}, true
+}
+
+/*
+hasSockets returns true if any socket is streaming the game's events.
+*/
+func (gameEntry *entry) hasSockets() bool {
+	gameEntry.mutex.Lock()
+	defer gameEntry.mutex.Unlock()
+
+	return gameEntry.numSockets > 0
+}
+
+/*
+close marks the game as removed and closes its sockets.
+Closing the entry again does nothing.
+*/
+func (gameEntry *entry) close() {
+	gameEntry.mutex.Lock()
+	defer gameEntry.mutex.Unlock()
+
+	if gameEntry.closed {
+		return
+	}
+
+	gameEntry.closed = true
+	close(gameEntry.removed)
+}
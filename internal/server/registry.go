@@ -0,0 +1,250 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/pedrohenriques/go-minesweeper/internal/match"
+)
+
+// The number of random bytes in a game's ID
+const gameIdBytes int = 16
+
+// MatchEntry holds a match of the registry
+type matchEntry struct {
+	// Shared with the entries of the players' games
+	mutex    *sync.Mutex
+	match    match.IMatch
+	lastUsed time.Time
+}
+
+// Registry holds the games being played, keyed by ID
+type registry struct {
+	mutex sync.Mutex
+	games map[string]*entry
+	// The IDs of the games, keyed by the ID given to their spectators
+	spectatedGames map[string]string
+	matches        map[string]*matchEntry
+	// The time after which a game that was not used is removed
+	idleTimeout time.Duration
+	now         func() time.Time
+}
+
+/*
+newRegistry creates an empty registry that removes games idle for longer than
+the provided timeout.
+*/
+func newRegistry(idleTimeout time.Duration) *registry {
+	return &registry{
+		games:          map[string]*entry{},
+		spectatedGames: map[string]string{},
+		matches:        map[string]*matchEntry{},
+		idleTimeout:    idleTimeout,
+		now:            time.Now,
+	}
+}
+
+/*
+add stores the game and returns its ID and the ID given to its spectators.
+*/
+func (registry *registry) add(game game.IGame) (string, string, error) {
+	id, error := newGameId()
+	if error != nil {
+		return "", "", error
+	}
+	spectatorId, error := newGameId()
+	if error != nil {
+		return "", "", error
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	registry.games[id] = newEntry(game, spectatorId, registry.now(), &sync.Mutex{})
+	registry.spectatedGames[spectatorId] = id
+
+	return id, spectatorId, nil
+}
+
+/*
+addMatch stores the match and its players' games, and returns the ID of the
+match and the IDs of the games, ordered by player.
+*/
+func (registry *registry) addMatch(raceMatch match.IMatch) (string, []string, error) {
+	matchId, error := newGameId()
+	if error != nil {
+		return "", nil, error
+	}
+
+	mutex := &sync.Mutex{}
+	gameEntries := map[string]*entry{}
+	gameIds := make([]string, raceMatch.NumPlayers())
+	for player := range gameIds {
+		gameIds[player], error = newGameId()
+		if error != nil {
+			return "", nil, error
+		}
+		spectatorId, error := newGameId()
+		if error != nil {
+			return "", nil, error
+		}
+
+		gameInstance, _ := raceMatch.Game(player)
+		gameEntries[gameIds[player]] = newEntry(gameInstance, spectatorId, registry.now(), mutex)
+		gameEntries[gameIds[player]].matchId = matchId
+		gameEntries[gameIds[player]].player = player
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	for id, gameEntry := range gameEntries {
+		registry.games[id] = gameEntry
+		registry.spectatedGames[gameEntry.spectatorId] = id
+	}
+	registry.matches[matchId] = &matchEntry{
+		mutex:    mutex,
+		match:    raceMatch,
+		lastUsed: registry.now(),
+	}
+
+	return matchId, gameIds, nil
+}
+
+/*
+getMatch returns the match with the provided ID and marks it as used.
+Returns false if there is no such match or it has expired.
+*/
+func (registry *registry) getMatch(id string) (*matchEntry, bool) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	raceMatch, ok := registry.matches[id]
+	if !ok {
+		return nil, false
+	}
+
+	now := registry.now()
+	if now.Sub(raceMatch.lastUsed) > registry.idleTimeout {
+		delete(registry.matches, id)
+		return nil, false
+	}
+
+	raceMatch.lastUsed = now
+	return raceMatch, true
+}
+
+/*
+get returns the game with the provided ID and marks it as used.
+Returns false if there is no such game or it has expired.
+*/
+func (registry *registry) get(id string) (*entry, bool) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	gameEntry, ok := registry.games[id]
+	if !ok {
+		return nil, false
+	}
+
+	now := registry.now()
+	if registry.expired(gameEntry, now) {
+		registry.delete(id)
+		return nil, false
+	}
+
+	gameEntry.lastUsed = now
+	return gameEntry, true
+}
+
+/*
+getSpectated returns the game with the provided spectator ID and marks it as
+used.
+Returns false if there is no such game or it has expired.
+*/
+func (registry *registry) getSpectated(spectatorId string) (*entry, bool) {
+	registry.mutex.Lock()
+	id, ok := registry.spectatedGames[spectatorId]
+	registry.mutex.Unlock()
+	if !ok {
+		return nil, false
+	}
+
+	return registry.get(id)
+}
+
+/*
+remove deletes the game with the provided ID.
+Returns false if there is no such game.
+*/
+func (registry *registry) remove(id string) bool {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	_, ok := registry.games[id]
+	if ok {
+		registry.delete(id)
+	}
+
+	return ok
+}
+
+/*
+removeExpired deletes every game that has been idle for longer than the
+registry's timeout.
+*/
+func (registry *registry) removeExpired() {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	now := registry.now()
+	for id, gameEntry := range registry.games {
+		if registry.expired(gameEntry, now) {
+			registry.delete(id)
+		}
+	}
+	for id, raceMatch := range registry.matches {
+		if now.Sub(raceMatch.lastUsed) > registry.idleTimeout {
+			delete(registry.matches, id)
+		}
+	}
+}
+
+/*
+delete removes the game with the provided ID, which must exist, and closes its
+sockets.
+The registry's mutex must be held.
+*/
+func (registry *registry) delete(id string) {
+	gameEntry := registry.games[id]
+
+	delete(registry.games, id)
+	delete(registry.spectatedGames, gameEntry.spectatorId)
+	gameEntry.close()
+}
+
+/*
+newGameId returns a random ID for a game.
+*/
+func newGameId() (string, error) {
+	idBytes := make([]byte, gameIdBytes)
+	_, error := rand.Read(idBytes)
+	if error != nil {
+		return "", error
+	}
+
+	return hex.EncodeToString(idBytes), nil
+}
+
+/*
+expired returns true if the game has been idle for longer than the registry's
+timeout, at the provided time.
+Games with connected sockets do not expire.
+The registry's mutex must be held.
+*/
+func (registry *registry) expired(gameEntry *entry, now time.Time) bool {
+	return now.Sub(gameEntry.lastUsed) > registry.idleTimeout && !gameEntry.hasSockets()
+}
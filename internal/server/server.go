@@ -0,0 +1,437 @@
+/*
+Package server exposes games over an HTTP API with JSON bodies, and WebSockets
+that stream their events, so they can be played by bots and web frontends
+*/
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/pedrohenriques/go-minesweeper/internal/match"
+	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
+)
+
+// CreateGameResponse is the body returned when a game is created
+type createGameResponse struct {
+	ID string
+	// The ID that gives read only access to the game's events
+	SpectatorID string
+	Config      interface{}
+}
+
+// CreateMatchRequest is the body of a request to create a match
+type createMatchRequest struct {
+	Config     game.GameConfig
+	NumPlayers int
+}
+
+// CreateMatchResponse is the body returned when a match is created
+type createMatchResponse struct {
+	ID string
+	// The IDs of the players' games, ordered by player
+	GameIDs []string
+	Config  interface{}
+}
+
+// JoinMatchResponse is the body with the match a game belongs to.
+// The configuration does not have the seed nor the mine tiles, so the board is
+// only known to the server
+type joinMatchResponse struct {
+	MatchID string
+	Player  int
+	Config  game.GameConfig
+}
+
+// BoardResponse is the body with the visible state of a game's tiles
+type boardResponse struct {
+	// The rows of every layer, one layer after the other
+	NumRows   int
+	NumCols   int
+	NumLayers int
+	NumMines  int
+	State     int
+	// The tiles ordered by row and then column
+	Tiles []tileResponse
+}
+
+// TileResponse is the visible state of a tile.
+// Only revealed tiles have their mine and number of adjacent mines set
+type tileResponse struct {
+	Revealed      bool
+	HasFlag       bool
+	HasMine       bool
+	AdjacentMines int
+	// True for the cells that are not tiles of the board, removed by a mask
+	Masked bool
+}
+
+// ActionRequest is the body of a request to act on a tile.
+// Action is only used by the commands sent over a game's socket
+type actionRequest struct {
+	// One of "reveal", "flag" or "chord"
+	Action   string
+	RowIndex int
+	ColIndex int
+}
+
+// ActionResponse is the body returned after acting on a tile
+type actionResponse struct {
+	// The indexes of the tiles that changed
+	TileIndexes []int
+	// The visible state of the tiles that changed
+	Tiles []changedTile
+	State int
+}
+
+// ErrorResponse is the body returned when a request fails
+type errorResponse struct {
+	Error string
+}
+
+// Server handles the requests of the API
+type server struct {
+	registry *registry
+}
+
+/*
+NewHandler creates the handler of the API.
+Games that are not used for longer than the idle timeout are removed.
+
+The routes are:
+POST /games creates a game from a game.GameConfig
+GET /games/{id}/board returns the visible state of the tiles
+GET /games/{id}/stats returns the game's stats
+POST /games/{id}/reveal, /flag and /chord act on the tile in the body
+DELETE /games/{id} removes the game
+GET /games/{id}/socket opens a WebSocket that streams the game's events and
+accepts actions
+GET /spectate/{spectatorId}/socket opens a read only WebSocket that streams the
+game's events
+POST /matches creates a match from a createMatchRequest, with a game per player
+GET /matches/{id} returns the progress of the players and the outcome
+GET /games/{id}/match returns the match the game belongs to
+*/
+func NewHandler(idleTimeout time.Duration) http.Handler {
+	return &server{
+		registry: newRegistry(idleTimeout),
+	}
+}
+
+/*
+Run serves the API on the provided address until it fails.
+*/
+func Run(address string, idleTimeout time.Duration) error {
+	handler := &server{
+		registry: newRegistry(idleTimeout),
+	}
+
+	go func() {
+		ticker := time.NewTicker(idleTimeout)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			handler.registry.removeExpired()
+		}
+	}()
+
+	return http.ListenAndServe(address, handler)
+}
+
+/*
+ServeHTTP routes the request to its handler.
+*/
+func (server *server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	pathParts := strings.Split(strings.Trim(request.URL.Path, "/"), "/")
+	if pathParts[0] == "spectate" && len(pathParts) == 3 && pathParts[2] == "socket" {
+		gameEntry, ok := server.registry.getSpectated(pathParts[1])
+		if !ok {
+			writeError(writer, http.StatusNotFound, "The game does not exist")
+			return
+		}
+		server.serveSocket(writer, request, gameEntry, "")
+		return
+	}
+	if pathParts[0] == "matches" && len(pathParts) <= 2 {
+		server.serveMatch(writer, request, pathParts)
+		return
+	}
+	if pathParts[0] != "games" || len(pathParts) > 3 {
+		writeError(writer, http.StatusNotFound, "The route does not exist")
+		return
+	}
+
+	if len(pathParts) == 1 {
+		if request.Method != http.MethodPost {
+			writeError(writer, http.StatusMethodNotAllowed, "The method is not allowed")
+			return
+		}
+		server.createGame(writer, request)
+		return
+	}
+
+	id := pathParts[1]
+	if len(pathParts) == 2 {
+		if request.Method != http.MethodDelete {
+			writeError(writer, http.StatusMethodNotAllowed, "The method is not allowed")
+			return
+		}
+		if !server.registry.remove(id) {
+			writeError(writer, http.StatusNotFound, "The game does not exist")
+			return
+		}
+		writer.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	gameEntry, ok := server.registry.get(id)
+	if !ok {
+		writeError(writer, http.StatusNotFound, "The game does not exist")
+		return
+	}
+
+	if request.Method == http.MethodGet && pathParts[2] == "socket" {
+		server.serveSocket(writer, request, gameEntry, id)
+		return
+	}
+
+	gameEntry.mutex.Lock()
+	defer gameEntry.mutex.Unlock()
+
+	route := request.Method + " " + pathParts[2]
+	switch route {
+	case "GET board":
+		writeJSON(writer, http.StatusOK, board(gameEntry.game))
+	case "GET stats":
+		writeJSON(writer, http.StatusOK, gameEntry.game.Stats())
+	case "GET match":
+		if gameEntry.matchId == "" {
+			writeError(writer, http.StatusNotFound, "The game is not in a match")
+			return
+		}
+		gameConfig := gameEntry.game.GameConfig()
+		gameConfig.Seed = ""
+		gameConfig.MineTiles = nil
+		writeJSON(writer, http.StatusOK, joinMatchResponse{
+			MatchID: gameEntry.matchId,
+			Player:  gameEntry.player,
+			Config:  gameConfig,
+		})
+	case "POST reveal":
+		processAction(writer, request, gameEntry, configs.PrimaryClick)
+	case "POST flag":
+		processAction(writer, request, gameEntry, configs.SecondaryClick)
+	case "POST chord":
+		processAction(writer, request, gameEntry, configs.BothClick)
+	default:
+		writeError(writer, http.StatusNotFound, "The route does not exist")
+	}
+}
+
+/*
+createGame generates a game with the configuration in the request's body and
+adds it to the registry.
+*/
+func (server *server) createGame(writer http.ResponseWriter, request *http.Request) {
+	gameConfig := game.GameConfig{}
+	error := json.NewDecoder(request.Body).Decode(&gameConfig)
+	if error != nil {
+		writeError(writer, http.StatusBadRequest, error.Error())
+		return
+	}
+
+	error = game.Validate(gameConfig)
+	if error != nil {
+		writeError(writer, http.StatusBadRequest, error.Error())
+		return
+	}
+
+	gameInstance, error := game.Generate(gameConfig)
+	if error != nil {
+		writeError(writer, http.StatusBadRequest, error.Error())
+		return
+	}
+
+	id, spectatorId, error := server.registry.add(gameInstance)
+	if error != nil {
+		writeError(writer, http.StatusInternalServerError, error.Error())
+		return
+	}
+
+	// The seed is not returned, since the board could be generated from it
+	writeJSON(writer, http.StatusCreated, createGameResponse{
+		ID:          id,
+		SpectatorID: spectatorId,
+		Config:      gameInstance.Config(),
+	})
+}
+
+/*
+serveMatch creates a match or returns the status of a match.
+*/
+func (server *server) serveMatch(writer http.ResponseWriter, request *http.Request, pathParts []string) {
+	if len(pathParts) == 1 {
+		if request.Method != http.MethodPost {
+			writeError(writer, http.StatusMethodNotAllowed, "The method is not allowed")
+			return
+		}
+		server.createMatch(writer, request)
+		return
+	}
+
+	if request.Method != http.MethodGet {
+		writeError(writer, http.StatusMethodNotAllowed, "The method is not allowed")
+		return
+	}
+
+	matchEntry, ok := server.registry.getMatch(pathParts[1])
+	if !ok {
+		writeError(writer, http.StatusNotFound, "The match does not exist")
+		return
+	}
+
+	matchEntry.mutex.Lock()
+	defer matchEntry.mutex.Unlock()
+
+	writeJSON(writer, http.StatusOK, matchEntry.match.Status())
+}
+
+/*
+createMatch creates a match with the configuration and number of players in
+the request's body and adds it, and the players' games, to the registry.
+*/
+func (server *server) createMatch(writer http.ResponseWriter, request *http.Request) {
+	matchRequest := createMatchRequest{}
+	error := json.NewDecoder(request.Body).Decode(&matchRequest)
+	if error != nil {
+		writeError(writer, http.StatusBadRequest, error.Error())
+		return
+	}
+
+	error = game.Validate(matchRequest.Config)
+	if error != nil {
+		writeError(writer, http.StatusBadRequest, error.Error())
+		return
+	}
+
+	raceMatch, error := match.New(matchRequest.Config, matchRequest.NumPlayers)
+	if error != nil {
+		writeError(writer, http.StatusBadRequest, error.Error())
+		return
+	}
+
+	id, gameIds, error := server.registry.addMatch(raceMatch)
+	if error != nil {
+		writeError(writer, http.StatusInternalServerError, error.Error())
+		return
+	}
+
+	gameInstance, _ := raceMatch.Game(0)
+	writeJSON(writer, http.StatusCreated, createMatchResponse{
+		ID:      id,
+		GameIDs: gameIds,
+		Config:  gameInstance.Config(),
+	})
+}
+
+/*
+processAction applies a click of the provided type on the tile in the request's
+body.
+The entry's mutex must be held.
+
+clickType: 0 = primary | 1 = secondary | 2 = both
+*/
+func processAction(writer http.ResponseWriter, request *http.Request, gameEntry *entry, clickType int) {
+	action := actionRequest{}
+	error := json.NewDecoder(request.Body).Decode(&action)
+	if error != nil {
+		writeError(writer, http.StatusBadRequest, error.Error())
+		return
+	}
+
+	response, error := gameEntry.act(clickType, action.RowIndex, action.ColIndex)
+	if _, ok := error.(gameEndedError); ok {
+		writeError(writer, http.StatusConflict, error.Error())
+		return
+	}
+	if error != nil {
+		writeError(writer, http.StatusBadRequest, error.Error())
+		return
+	}
+
+	writeJSON(writer, http.StatusOK, response)
+}
+
+/*
+board returns the visible state of the game's tiles.
+The mines and numbers of the tiles that are not revealed are left out.
+*/
+func board(gameInstance game.IGame) boardResponse {
+	gameConfig := gameInstance.Config()
+
+	response := boardResponse{
+		NumRows:   gameConfig.NumRows,
+		NumCols:   gameConfig.NumCols,
+		NumLayers: gameConfig.NumLayers,
+		NumMines:  gameConfig.NumMines,
+		State:     gameInstance.State(),
+		Tiles:     make([]tileResponse, 0, gameConfig.NumRows*gameConfig.NumCols),
+	}
+
+	for rowIndex := 0; rowIndex < gameConfig.NumRows; rowIndex++ {
+		for colIndex := 0; colIndex < gameConfig.NumCols; colIndex++ {
+			tile, error := gameInstance.Tile(rowIndex, colIndex)
+			if error != nil {
+				response.Tiles = append(response.Tiles, tileResponse{Masked: true})
+				continue
+			}
+			response.Tiles = append(response.Tiles, visibleTile(tile))
+		}
+	}
+
+	return response
+}
+
+/*
+visibleTile returns the visible state of the tile.
+The mine and number of a tile that is not revealed are left out.
+*/
+func visibleTile(tile minefield.ITile) tileResponse {
+	response := tileResponse{
+		Revealed: tile.Revealed(),
+		HasFlag:  tile.HasFlag(),
+	}
+	if tile.Revealed() {
+		response.HasMine = tile.HasMine()
+		response.AdjacentMines = tile.AdjacentMines()
+	}
+
+	return response
+}
+
+/*
+writeJSON writes the body as JSON with the provided status code.
+*/
+func writeJSON(writer http.ResponseWriter, statusCode int, body interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+
+	error := json.NewEncoder(writer).Encode(body)
+	if error != nil {
+		log.Println(error)
+	}
+}
+
+/*
+writeError writes an errorResponse with the provided status code.
+*/
+func writeError(writer http.ResponseWriter, statusCode int, message string) {
+	writeJSON(writer, statusCode, errorResponse{
+		Error: message,
+	})
+}
@@ -0,0 +1,345 @@
+package server_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/server"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type serverTestSuite struct {
+	suite.Suite
+	sut http.Handler
+}
+
+func (suite *serverTestSuite) SetupTest() {
+	suite.sut = server.NewHandler(time.Minute)
+}
+
+/*
+request sends a request to the handler and decodes the JSON body of the
+response into the provided value, if it is not nil.
+*/
+func (suite *serverTestSuite) request(method string, path string, body string, response interface{}) int {
+	recorder := httptest.NewRecorder()
+	suite.sut.ServeHTTP(recorder, httptest.NewRequest(method, path, strings.NewReader(body)))
+
+	if response != nil {
+		err := json.Unmarshal(recorder.Body.Bytes(), response)
+		require.Nil(suite.T(), err)
+	}
+
+	return recorder.Code
+}
+
+/*
+createGame creates a 3x3 game with a mine on the top left tile and returns its
+ID.
+*/
+func (suite *serverTestSuite) createGame() string {
+	response := map[string]interface{}{}
+	code := suite.request(http.MethodPost, "/games", `{"NumRows": 3, "NumCols": 3, "NumMines": 1, "Lives": 1, "FlagsEnabled": true, "MineTiles": [0]}`, &response)
+	require.Equal(suite.T(), http.StatusCreated, code)
+
+	return response["ID"].(string)
+}
+
+func (suite *serverTestSuite) TestCreateGameReturnsTheIDAndTheConfigWithoutTheSeed() {
+	response := map[string]interface{}{}
+	code := suite.request(http.MethodPost, "/games", `{"NumRows": 9, "NumCols": 9, "NumMines": 10, "Lives": 1, "Seed": "hello"}`, &response)
+
+	require.Equal(suite.T(), http.StatusCreated, code)
+	require.NotEmpty(suite.T(), response["ID"])
+	require.Equal(suite.T(), map[string]interface{}{"NumMines": 10.0, "NumRows": 9.0, "NumCols": 9.0, "NumLayers": 1.0}, response["Config"])
+}
+
+func (suite *serverTestSuite) TestCreateGameReturnsBadRequestIfTheConfigIsNotValid() {
+	response := map[string]interface{}{}
+	code := suite.request(http.MethodPost, "/games", `{"NumRows": 3, "NumCols": 3, "NumMines": 9}`, &response)
+
+	require.Equal(suite.T(), http.StatusBadRequest, code)
+	require.NotEmpty(suite.T(), response["Error"])
+}
+
+func (suite *serverTestSuite) TestCreateGameReturnsBadRequestIfTheGameHasNoLives() {
+	response := map[string]interface{}{}
+	code := suite.request(http.MethodPost, "/games", `{"NumRows": 9, "NumCols": 9, "NumMines": 10}`, &response)
+
+	require.Equal(suite.T(), http.StatusBadRequest, code)
+	require.Equal(suite.T(), "The number of lives '0' is not valid, it must be at least '1'", response["Error"])
+}
+
+func (suite *serverTestSuite) TestCreateGameReturnsBadRequestIfTheBodyIsNotValidJSON() {
+	code := suite.request(http.MethodPost, "/games", `not json`, nil)
+
+	require.Equal(suite.T(), http.StatusBadRequest, code)
+}
+
+func (suite *serverTestSuite) TestBoardHidesTheMinesOfTilesThatAreNotRevealed() {
+	id := suite.createGame()
+
+	response := map[string]interface{}{}
+	code := suite.request(http.MethodGet, "/games/"+id+"/board", "", &response)
+
+	require.Equal(suite.T(), http.StatusOK, code)
+	tiles := response["Tiles"].([]interface{})
+	require.Equal(suite.T(), 9, len(tiles))
+	for _, tile := range tiles {
+		require.Equal(suite.T(), false, tile.(map[string]interface{})["Revealed"])
+		require.Equal(suite.T(), false, tile.(map[string]interface{})["HasMine"])
+		require.Equal(suite.T(), 0.0, tile.(map[string]interface{})["AdjacentMines"])
+	}
+}
+
+func (suite *serverTestSuite) TestRevealReturnsTheChangedTilesAndTheState() {
+	id := suite.createGame()
+
+	response := struct {
+		TileIndexes []int
+		State       int
+	}{}
+	code := suite.request(http.MethodPost, "/games/"+id+"/reveal", `{"RowIndex": 2, "ColIndex": 2}`, &response)
+
+	require.Equal(suite.T(), http.StatusOK, code)
+	require.ElementsMatch(suite.T(), []int{1, 2, 3, 4, 5, 6, 7, 8}, response.TileIndexes)
+	require.Equal(suite.T(), configs.StateWin, response.State)
+}
+
+func (suite *serverTestSuite) TestRevealReturnsTheVisibleStateOfTheChangedTiles() {
+	id := suite.createGame()
+
+	response := struct {
+		Tiles []struct {
+			TileIndex     int
+			Revealed      bool
+			AdjacentMines int
+		}
+	}{}
+	suite.request(http.MethodPost, "/games/"+id+"/reveal", `{"RowIndex": 1, "ColIndex": 1}`, &response)
+
+	require.Equal(suite.T(), 1, len(response.Tiles))
+	require.Equal(suite.T(), 4, response.Tiles[0].TileIndex)
+	require.Equal(suite.T(), true, response.Tiles[0].Revealed)
+	require.Equal(suite.T(), 1, response.Tiles[0].AdjacentMines)
+}
+
+func (suite *serverTestSuite) TestBoardShowsTheNumbersOfRevealedTiles() {
+	id := suite.createGame()
+	suite.request(http.MethodPost, "/games/"+id+"/reveal", `{"RowIndex": 1, "ColIndex": 1}`, nil)
+
+	response := struct {
+		Tiles []struct {
+			Revealed      bool
+			AdjacentMines int
+		}
+	}{}
+	suite.request(http.MethodGet, "/games/"+id+"/board", "", &response)
+
+	require.Equal(suite.T(), true, response.Tiles[4].Revealed)
+	require.Equal(suite.T(), 1, response.Tiles[4].AdjacentMines)
+}
+
+func (suite *serverTestSuite) TestFlagTogglesTheFlagOfTheTile() {
+	id := suite.createGame()
+
+	response := struct {
+		TileIndexes []int
+	}{}
+	code := suite.request(http.MethodPost, "/games/"+id+"/flag", `{"RowIndex": 0, "ColIndex": 0}`, &response)
+
+	require.Equal(suite.T(), http.StatusOK, code)
+	require.Equal(suite.T(), []int{0}, response.TileIndexes)
+
+	stats := map[string]interface{}{}
+	suite.request(http.MethodGet, "/games/"+id+"/stats", "", &stats)
+	require.Equal(suite.T(), 0.0, stats["RemainingMines"])
+}
+
+func (suite *serverTestSuite) TestChordRevealsTheAdjacentTilesOfAFlaggedMine() {
+	id := suite.createGame()
+	suite.request(http.MethodPost, "/games/"+id+"/reveal", `{"RowIndex": 1, "ColIndex": 1}`, nil)
+	suite.request(http.MethodPost, "/games/"+id+"/flag", `{"RowIndex": 0, "ColIndex": 0}`, nil)
+
+	response := struct {
+		TileIndexes []int
+		State       int
+	}{}
+	code := suite.request(http.MethodPost, "/games/"+id+"/chord", `{"RowIndex": 1, "ColIndex": 1}`, &response)
+
+	require.Equal(suite.T(), http.StatusOK, code)
+	require.Equal(suite.T(), configs.StateWin, response.State)
+}
+
+func (suite *serverTestSuite) TestActionsReturnConflictIfTheGameHasEnded() {
+	id := suite.createGame()
+	suite.request(http.MethodPost, "/games/"+id+"/reveal", `{"RowIndex": 0, "ColIndex": 0}`, nil)
+
+	code := suite.request(http.MethodPost, "/games/"+id+"/reveal", `{"RowIndex": 2, "ColIndex": 2}`, nil)
+
+	require.Equal(suite.T(), http.StatusConflict, code)
+}
+
+func (suite *serverTestSuite) TestActionsReturnBadRequestIfTheTileDoesNotExist() {
+	id := suite.createGame()
+
+	code := suite.request(http.MethodPost, "/games/"+id+"/reveal", `{"RowIndex": 5, "ColIndex": 5}`, nil)
+
+	require.Equal(suite.T(), http.StatusBadRequest, code)
+}
+
+func (suite *serverTestSuite) TestRequestsReturnNotFoundIfTheGameDoesNotExist() {
+	code := suite.request(http.MethodGet, "/games/unknown/board", "", nil)
+
+	require.Equal(suite.T(), http.StatusNotFound, code)
+}
+
+func (suite *serverTestSuite) TestRequestsReturnMethodNotAllowedIfTheMethodDoesNotMatch() {
+	code := suite.request(http.MethodGet, "/games", "", nil)
+
+	require.Equal(suite.T(), http.StatusMethodNotAllowed, code)
+}
+
+func (suite *serverTestSuite) TestDeleteRemovesTheGame() {
+	id := suite.createGame()
+
+	code := suite.request(http.MethodDelete, "/games/"+id, "", nil)
+	require.Equal(suite.T(), http.StatusNoContent, code)
+
+	code = suite.request(http.MethodGet, "/games/"+id+"/board", "", nil)
+	require.Equal(suite.T(), http.StatusNotFound, code)
+}
+
+func (suite *serverTestSuite) TestGamesAreRemovedAfterBeingIdleForLongerThanTheTimeout() {
+	suite.sut = server.NewHandler(20 * time.Millisecond)
+	id := suite.createGame()
+
+	time.Sleep(50 * time.Millisecond)
+	code := suite.request(http.MethodGet, "/games/"+id+"/board", "", nil)
+
+	require.Equal(suite.T(), http.StatusNotFound, code)
+}
+
+func (suite *serverTestSuite) TestConcurrentRequestsOnTheSameGameAreApplied() {
+	id := suite.createGame()
+
+	waitGroup := sync.WaitGroup{}
+	for tileIndex := 1; tileIndex < 9; tileIndex++ {
+		waitGroup.Add(1)
+		go func(tileIndex int) {
+			defer waitGroup.Done()
+			body := fmt.Sprintf(`{"RowIndex": %v, "ColIndex": %v}`, tileIndex/3, tileIndex%3)
+			recorder := httptest.NewRecorder()
+			suite.sut.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/games/"+id+"/flag", strings.NewReader(body)))
+		}(tileIndex)
+	}
+	waitGroup.Wait()
+
+	stats := map[string]interface{}{}
+	suite.request(http.MethodGet, "/games/"+id+"/stats", "", &stats)
+	require.Equal(suite.T(), -7.0, stats["RemainingMines"])
+}
+
+/*
+createMatch creates a match of two players on the board of createGame and
+returns its ID and the IDs of the players' games.
+*/
+func (suite *serverTestSuite) createMatch() (string, []string) {
+	response := struct {
+		ID      string
+		GameIDs []string
+	}{}
+	code := suite.request(http.MethodPost, "/matches", `{"NumPlayers": 2, "Config": {"NumRows": 3, "NumCols": 3, "NumMines": 1, "Lives": 1, "MineTiles": [0]}}`, &response)
+	require.Equal(suite.T(), http.StatusCreated, code)
+
+	return response.ID, response.GameIDs
+}
+
+func (suite *serverTestSuite) TestCreateMatchReturnsAGameForEachPlayer() {
+	_, gameIds := suite.createMatch()
+
+	require.Equal(suite.T(), 2, len(gameIds))
+	code := suite.request(http.MethodGet, "/games/"+gameIds[1]+"/board", "", nil)
+	require.Equal(suite.T(), http.StatusOK, code)
+}
+
+func (suite *serverTestSuite) TestCreateMatchReturnsBadRequestIfThereAreNotEnoughPlayers() {
+	code := suite.request(http.MethodPost, "/matches", `{"NumPlayers": 1, "Config": {"NumRows": 3, "NumCols": 3, "NumMines": 1, "Lives": 1}}`, nil)
+
+	require.Equal(suite.T(), http.StatusBadRequest, code)
+}
+
+func (suite *serverTestSuite) TestMatchReturnsTheProgressOfThePlayersAndTheWinner() {
+	id, gameIds := suite.createMatch()
+	suite.request(http.MethodPost, "/games/"+gameIds[1]+"/reveal", `{"RowIndex": 2, "ColIndex": 2}`, nil)
+
+	response := struct {
+		Progress []struct {
+			Percentage float64
+		}
+		Result struct {
+			Done   bool
+			Winner int
+		}
+	}{}
+	code := suite.request(http.MethodGet, "/matches/"+id, "", &response)
+
+	require.Equal(suite.T(), http.StatusOK, code)
+	require.Equal(suite.T(), 0.0, response.Progress[0].Percentage)
+	require.Equal(suite.T(), 100.0, response.Progress[1].Percentage)
+	require.Equal(suite.T(), true, response.Result.Done)
+	require.Equal(suite.T(), 1, response.Result.Winner)
+}
+
+func (suite *serverTestSuite) TestGameMatchReturnsTheMatchAndThePlayerOfTheGame() {
+	id, gameIds := suite.createMatch()
+
+	response := struct {
+		MatchID string
+		Player  int
+		Config  struct {
+			NumRows int
+		}
+	}{}
+	code := suite.request(http.MethodGet, "/games/"+gameIds[1]+"/match", "", &response)
+
+	require.Equal(suite.T(), http.StatusOK, code)
+	require.Equal(suite.T(), id, response.MatchID)
+	require.Equal(suite.T(), 1, response.Player)
+	require.Equal(suite.T(), 3, response.Config.NumRows)
+}
+
+func (suite *serverTestSuite) TestGameMatchDoesNotReturnTheSeedNorTheMinesOfTheBoard() {
+	_, gameIds := suite.createMatch()
+
+	response := struct {
+		Config struct {
+			Seed      string
+			MineTiles []int
+		}
+	}{}
+	suite.request(http.MethodGet, "/games/"+gameIds[1]+"/match", "", &response)
+
+	require.Empty(suite.T(), response.Config.Seed)
+	require.Nil(suite.T(), response.Config.MineTiles)
+}
+
+func (suite *serverTestSuite) TestGameMatchReturnsNotFoundIfTheGameIsNotInAMatch() {
+	id := suite.createGame()
+
+	code := suite.request(http.MethodGet, "/games/"+id+"/match", "", nil)
+
+	require.Equal(suite.T(), http.StatusNotFound, code)
+}
+
+func TestServerSuite(t *testing.T) {
+	suite.Run(t, new(serverTestSuite))
+}
@@ -0,0 +1,196 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"golang.org/x/net/websocket"
+)
+
+// Error: A command was sent over a read only socket
+type readOnlySocketError struct{}
+
+/*
+Error prints the message for this error.
+*/
+func (e readOnlySocketError) Error() string {
+	return "The socket is read only"
+}
+
+// Error: A command sent over a socket has an unknown action
+type unknownActionError struct {
+	Action string
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e unknownActionError) Error() string {
+	return fmt.Sprintf("The action '%v' is not known", e.Action)
+}
+
+// Error: The socket was opened by a page of another site
+type crossOriginError struct {
+	Origin string
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e crossOriginError) Error() string {
+	return fmt.Sprintf("The origin '%v' can not open a socket on this server", e.Origin)
+}
+
+// The number of error events a socket can have waiting to be sent
+const socketErrorBufferSize int = 16
+
+// The click types of the actions that can be sent over a socket
+var socketActions = map[string]int{
+	"reveal": configs.PrimaryClick,
+	"flag":   configs.SecondaryClick,
+	"chord":  configs.BothClick,
+}
+
+/*
+serveSocket upgrades the request to a WebSocket that streams the game's events,
+starting with the state of every tile.
+The actions sent by the client are applied to the game with the provided ID or,
+if the ID is empty, the socket is read only.
+*/
+func (server *server) serveSocket(writer http.ResponseWriter, request *http.Request, gameEntry *entry, id string) {
+	websocket.Server{
+		Handshake: checkOrigin,
+		Handler: func(conn *websocket.Conn) {
+			defer conn.Close()
+
+			// The board is read while subscribed and before any other action, so
+			// the events start from it
+			gameEntry.mutex.Lock()
+			if gameEntry.closed {
+				gameEntry.mutex.Unlock()
+				return
+			}
+			gameEvents, unsubscribe := gameEntry.game.Subscribe()
+			gameBoard := board(gameEntry.game)
+			gameEntry.numSockets++
+			gameEntry.mutex.Unlock()
+
+			commandErrors := make(chan event, socketErrorBufferSize)
+			writerDone := make(chan struct{})
+			go func() {
+				defer close(writerDone)
+				// Closing the connection stops the reads of the commands
+				defer conn.Close()
+
+				ticker := time.NewTicker(time.Second)
+				defer ticker.Stop()
+
+				nextEvent, ok := event{
+					Type:  eventBoard,
+					State: gameBoard.State,
+					Board: &gameBoard,
+				}, true
+				for {
+					if ok && websocket.JSON.Send(conn, nextEvent) != nil {
+						return
+					}
+
+					select {
+					case newEvent, open := <-gameEvents:
+						if !open {
+							return
+						}
+						nextEvent, ok = gameEvent(gameEntry.game, newEvent.Kind, newEvent.TileIndexes)
+					case nextEvent = <-commandErrors:
+						ok = true
+					case now := <-ticker.C:
+						nextEvent, ok = tick(gameEntry.game, now)
+					case <-gameEntry.removed:
+						return
+					}
+				}
+			}()
+
+			for {
+				command := actionRequest{}
+				error := websocket.JSON.Receive(conn, &command)
+				if error != nil {
+					break
+				}
+
+				server.processCommand(gameEntry, commandErrors, id, command)
+			}
+
+			unsubscribe()
+			gameEntry.mutex.Lock()
+			gameEntry.numSockets--
+			gameEntry.mutex.Unlock()
+			<-writerDone
+		},
+	}.ServeHTTP(writer, request)
+}
+
+/*
+checkOrigin accepts the sockets opened by clients that are not browsers, which
+do not send an Origin header, and by the pages of the server's own host, so
+other sites can not play the games of the browsers that visit them.
+*/
+func checkOrigin(config *websocket.Config, request *http.Request) error {
+	origin := request.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+
+	originURL, error := url.Parse(origin)
+	if error != nil || originURL.Host != request.Host {
+		return crossOriginError{
+			Origin: origin,
+		}
+	}
+
+	return nil
+}
+
+/*
+processCommand applies an action sent over a socket, giving an error event to
+the socket's writer if it fails.
+The action is only applied if the ID of the game is not empty.
+*/
+func (server *server) processCommand(gameEntry *entry, commandErrors chan<- event, id string, command actionRequest) {
+	var error error
+
+	clickType, ok := socketActions[command.Action]
+	if id == "" {
+		error = readOnlySocketError{}
+	} else if !ok {
+		error = unknownActionError{
+			Action: command.Action,
+		}
+	} else {
+		// Marks the game as used
+		server.registry.get(id)
+	}
+
+	gameEntry.mutex.Lock()
+	defer gameEntry.mutex.Unlock()
+
+	if error == nil {
+		_, error = gameEntry.act(clickType, command.RowIndex, command.ColIndex)
+	}
+	if error == nil {
+		return
+	}
+
+	// The errors of a client that does not read them are dropped
+	select {
+	case commandErrors <- event{
+		Type:  eventError,
+		State: gameEntry.game.State(),
+		Error: error.Error(),
+	}:
+	default:
+	}
+}
@@ -0,0 +1,173 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/server"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/net/websocket"
+)
+
+type socketTestSuite struct {
+	suite.Suite
+	httpServer  *httptest.Server
+	id          string
+	spectatorId string
+}
+
+// SocketEvent holds the fields of the events used by the tests
+type socketEvent struct {
+	Type  string
+	State int
+	Board *struct {
+		Tiles []map[string]interface{}
+	}
+	Tiles []struct {
+		TileIndex     int
+		Revealed      bool
+		HasMine       bool
+		AdjacentMines int
+	}
+	Error string
+}
+
+func (suite *socketTestSuite) SetupTest() {
+	suite.httpServer = httptest.NewServer(server.NewHandler(time.Minute))
+
+	response, err := http.Post(suite.httpServer.URL+"/games", "application/json",
+		strings.NewReader(`{"NumRows": 3, "NumCols": 3, "NumMines": 1, "Lives": 1, "FlagsEnabled": true, "MineTiles": [0]}`))
+	require.Nil(suite.T(), err)
+	defer response.Body.Close()
+
+	body := map[string]string{}
+	json.NewDecoder(response.Body).Decode(&body)
+	suite.id = body["ID"]
+	suite.spectatorId = body["SpectatorID"]
+}
+
+func (suite *socketTestSuite) TearDownTest() {
+	suite.httpServer.Close()
+}
+
+/*
+dial opens a socket on the provided path and reads the initial board event.
+*/
+func (suite *socketTestSuite) dial(path string) *websocket.Conn {
+	conn, err := websocket.Dial(strings.Replace(suite.httpServer.URL, "http", "ws", 1)+path, "", suite.httpServer.URL)
+	require.Nil(suite.T(), err)
+
+	boardEvent := suite.receive(conn)
+	require.Equal(suite.T(), "board", boardEvent.Type)
+
+	return conn
+}
+
+/*
+receive reads the next event sent over the socket.
+*/
+func (suite *socketTestSuite) receive(conn *websocket.Conn) socketEvent {
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	receivedEvent := socketEvent{}
+	err := websocket.JSON.Receive(conn, &receivedEvent)
+	require.Nil(suite.T(), err)
+
+	return receivedEvent
+}
+
+func (suite *socketTestSuite) TestSocketStartsWithTheVisibleStateOfEveryTile() {
+	conn, err := websocket.Dial(strings.Replace(suite.httpServer.URL, "http", "ws", 1)+"/games/"+suite.id+"/socket", "", suite.httpServer.URL)
+	require.Nil(suite.T(), err)
+	defer conn.Close()
+
+	actual := suite.receive(conn)
+
+	require.Equal(suite.T(), "board", actual.Type)
+	require.Equal(suite.T(), 9, len(actual.Board.Tiles))
+	require.Equal(suite.T(), false, actual.Board.Tiles[0]["HasMine"])
+}
+
+func (suite *socketTestSuite) TestSocketIsRefusedToThePagesOfAnotherSite() {
+	_, err := websocket.Dial(strings.Replace(suite.httpServer.URL, "http", "ws", 1)+"/games/"+suite.id+"/socket", "", "http://example.com")
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *socketTestSuite) TestSocketAppliesTheActionsAndStreamsTheChangedTilesAndTheEnd() {
+	conn := suite.dial("/games/" + suite.id + "/socket")
+	defer conn.Close()
+
+	err := websocket.JSON.Send(conn, map[string]interface{}{"Action": "reveal", "RowIndex": 2, "ColIndex": 2})
+	require.Nil(suite.T(), err)
+
+	tilesEvent := suite.receive(conn)
+	require.Equal(suite.T(), "tiles", tilesEvent.Type)
+	require.Equal(suite.T(), 8, len(tilesEvent.Tiles))
+
+	endEvent := suite.receive(conn)
+	require.Equal(suite.T(), "end", endEvent.Type)
+	require.Equal(suite.T(), configs.StateWin, endEvent.State)
+}
+
+func (suite *socketTestSuite) TestSpectatorsReceiveTheActionsMadeThroughTheAPI() {
+	conn := suite.dial("/spectate/" + suite.spectatorId + "/socket")
+	defer conn.Close()
+
+	response, err := http.Post(suite.httpServer.URL+"/games/"+suite.id+"/reveal", "application/json",
+		strings.NewReader(`{"RowIndex": 1, "ColIndex": 1}`))
+	require.Nil(suite.T(), err)
+	response.Body.Close()
+
+	actual := suite.receive(conn)
+	require.Equal(suite.T(), "tiles", actual.Type)
+	require.Equal(suite.T(), 4, actual.Tiles[0].TileIndex)
+	require.Equal(suite.T(), true, actual.Tiles[0].Revealed)
+	require.Equal(suite.T(), 1, actual.Tiles[0].AdjacentMines)
+}
+
+func (suite *socketTestSuite) TestSpectatorsCanNotSendActions() {
+	conn := suite.dial("/spectate/" + suite.spectatorId + "/socket")
+	defer conn.Close()
+
+	websocket.JSON.Send(conn, map[string]interface{}{"Action": "reveal", "RowIndex": 2, "ColIndex": 2})
+
+	actual := suite.receive(conn)
+	require.Equal(suite.T(), "error", actual.Type)
+	require.Equal(suite.T(), configs.StateOnGoing, actual.State)
+}
+
+func (suite *socketTestSuite) TestSocketSendsAnErrorIfTheActionIsNotKnown() {
+	conn := suite.dial("/games/" + suite.id + "/socket")
+	defer conn.Close()
+
+	websocket.JSON.Send(conn, map[string]interface{}{"Action": "jump", "RowIndex": 2, "ColIndex": 2})
+
+	actual := suite.receive(conn)
+	require.Equal(suite.T(), "error", actual.Type)
+	require.NotEmpty(suite.T(), actual.Error)
+}
+
+func (suite *socketTestSuite) TestSocketIsClosedWhenTheGameIsRemoved() {
+	conn := suite.dial("/games/" + suite.id + "/socket")
+	defer conn.Close()
+
+	request, _ := http.NewRequest(http.MethodDelete, suite.httpServer.URL+"/games/"+suite.id, nil)
+	response, err := http.DefaultClient.Do(request)
+	require.Nil(suite.T(), err)
+	response.Body.Close()
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	err = websocket.JSON.Receive(conn, &socketEvent{})
+	require.NotNil(suite.T(), err)
+}
+
+func TestSocketSuite(t *testing.T) {
+	suite.Run(t, new(socketTestSuite))
+}
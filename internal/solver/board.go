@@ -0,0 +1,59 @@
+package solver
+
+import (
+	"github.com/pedrohenriques/go-minesweeper/internal/deduction"
+	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
+)
+
+/*
+readBoard collects the visible information of the provided minefield.
+Only the revealed tiles and, if trusted, the flags are used, the mines of the
+hidden tiles are never looked at.
+*/
+func readBoard(minefield minefield.IMinefield, trustFlags bool) (deduction.IBoard, error) {
+	rows := minefield.Rows()
+	cols := minefield.Cols()
+	adjacent := make([][]int, rows*cols)
+	masked := make([]bool, rows*cols)
+
+	for rowIndex := 0; rowIndex < rows; rowIndex++ {
+		for colIndex := 0; colIndex < cols; colIndex++ {
+			tileIndex := rowIndex*cols + colIndex
+			if !minefield.HasTile(rowIndex, colIndex) {
+				masked[tileIndex] = true
+				continue
+			}
+
+			adjacentIndexes, error := minefield.AdjacentTiles(rowIndex, colIndex)
+			if error != nil {
+				return nil, error
+			}
+			adjacent[tileIndex] = adjacentIndexes
+		}
+	}
+
+	board := deduction.NewBoard(cols, minefield.Mines(), adjacent)
+	for tileIndex, isMasked := range masked {
+		if isMasked {
+			board.Mask(tileIndex)
+			continue
+		}
+
+		tile, error := minefield.Tile(tileIndex/cols, tileIndex%cols)
+		if error != nil {
+			return nil, error
+		}
+
+		if tile.Revealed() {
+			if tile.HasMine() {
+				board.MarkMine(tileIndex)
+			} else {
+				board.Reveal(tileIndex, tile.AdjacentMines())
+			}
+		} else if trustFlags && tile.HasFlag() {
+			board.MarkMine(tileIndex)
+		}
+	}
+
+	return board, nil
+}
@@ -0,0 +1,24 @@
+package solver
+
+import (
+	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
+)
+
+/*
+Hint returns the index of a hidden tile of the minefield that is certainly safe
+or, if there are none, of the hidden tile with the lowest probability of having
+a mine, with that probability.
+The flags are not trusted, since the player may have placed them wrongly, so
+only the revealed tiles and the total number of mines are used.
+If the frontier has too many mine layouts to enumerate, the mines left are
+taken to be spread evenly among the unknown tiles.
+The returned tile index is -1 if every hidden tile is certainly a mine.
+*/
+func Hint(minefield minefield.IMinefield) (int, float64, error) {
+	board, error := readBoard(minefield, false)
+	if error != nil {
+		return -1, 0, error
+	}
+
+	return board.Hint()
+}
@@ -0,0 +1,37 @@
+package solver
+
+import (
+	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
+)
+
+/*
+Probabilities calculates, for each tile of the minefield, the probability of it
+having a mine given the visible numbers, the flags and the total number of
+mines.
+The result is indexed by row index and then by col index.
+Revealed tiles have a probability of 0, while flagged tiles and revealed mines
+have a probability of 1.
+Every mine layout of the frontier is enumerated, one independent component at a
+time, and weighted by the number of ways the remaining mines can be placed in
+the tiles outside the frontier.
+Returns an error if the visible board can not be satisfied by any mine layout.
+*/
+func Probabilities(minefield minefield.IMinefield) ([][]float64, error) {
+	board, error := readBoard(minefield, true)
+	if error != nil {
+		return nil, error
+	}
+
+	tileProbabilities, error := board.Probabilities()
+	if error != nil {
+		return nil, error
+	}
+
+	cols := minefield.Cols()
+	grid := make([][]float64, minefield.Rows())
+	for rowIndex := range grid {
+		grid[rowIndex] = tileProbabilities[rowIndex*cols : (rowIndex+1)*cols]
+	}
+
+	return grid, nil
+}
@@ -0,0 +1,105 @@
+package solver_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
+	"github.com/pedrohenriques/go-minesweeper/internal/solver"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type probabilityTestSuite struct {
+	suite.Suite
+}
+
+func (suite *probabilityTestSuite) TestProbabilitiesSplitsTheMineEvenlyOnAFiftyFiftyGuess() {
+	sut := newFakeMinefield(suite.T(), 1, "?1?")
+
+	actual, err := solver.Probabilities(sut)
+
+	require.Nil(suite.T(), err)
+	require.InDeltaSlice(suite.T(), []float64{0.5, 0, 0.5}, actual[0], 1e-9)
+}
+
+func (suite *probabilityTestSuite) TestProbabilitiesReturnsOneForFlagsAndRevealedMines() {
+	for _, row := range []string{"F1?", "*1?"} {
+		sut := newFakeMinefield(suite.T(), 1, row)
+
+		actual, err := solver.Probabilities(sut)
+
+		require.Nil(suite.T(), err)
+		require.Equal(suite.T(), []float64{1, 0, 0}, actual[0])
+	}
+}
+
+func (suite *probabilityTestSuite) TestProbabilitiesWeightsTheFrontierLayoutsByTheLayoutsOfTheTilesOutsideTheFrontier() {
+	sut := newFakeMinefield(suite.T(), 2, "?1?1???")
+
+	actual, err := solver.Probabilities(sut)
+
+	require.Nil(suite.T(), err)
+	require.InDeltaSlice(suite.T(), []float64{1.0 / 3, 0, 2.0 / 3, 0, 1.0 / 3, 1.0 / 3, 1.0 / 3}, actual[0], 1e-9)
+}
+
+func (suite *probabilityTestSuite) TestProbabilitiesReturnsTheSameProbabilityForAllTilesIfNothingIsRevealed() {
+	sut := newFakeMinefield(suite.T(), 10, "?????", "?????", "?????", "?????")
+
+	actual, err := solver.Probabilities(sut)
+
+	require.Nil(suite.T(), err)
+	for _, row := range actual {
+		for _, probability := range row {
+			require.InDelta(suite.T(), 0.5, probability, 1e-9)
+		}
+	}
+}
+
+func (suite *probabilityTestSuite) TestProbabilitiesReturnsAnErrorIfTheBoardCanNotBeSatisfied() {
+	sut := newFakeMinefield(suite.T(), 1, "?2?", "???")
+
+	_, err := solver.Probabilities(sut)
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *probabilityTestSuite) TestProbabilitiesAddUpToTheNumberOfMinesAndMatchTheCertainTilesOnExpertBoards() {
+	for seedIndex := 0; seedIndex < 20; seedIndex++ {
+		sut, err := minefield.Generate(minefield.MinefieldConfig{
+			NumCols:   30,
+			NumRows:   16,
+			NumMines:  99,
+			Seed:      fmt.Sprintf("seed %v", seedIndex),
+			SafeStart: configs.SafeStartArea,
+		})
+		require.Nil(suite.T(), err)
+		sut.RevealTile(8, 15)
+
+		actual, err := solver.Probabilities(sut)
+
+		require.Nil(suite.T(), err)
+
+		total := 0.0
+		for _, row := range actual {
+			for _, probability := range row {
+				total += probability
+			}
+		}
+		require.InDeltaf(suite.T(), 99.0, total, 1e-6, "seed index: %v", seedIndex)
+
+		result, _ := solver.Solve(sut)
+		for _, tileIndex := range result.SafeTiles {
+			require.InDeltaf(suite.T(), 0.0, actual[tileIndex/30][tileIndex%30], 1e-9, "seed index: %v | tile index: %v", seedIndex, tileIndex)
+		}
+		for _, tileIndex := range result.MineTiles {
+			require.InDeltaf(suite.T(), 1.0, actual[tileIndex/30][tileIndex%30], 1e-9, "seed index: %v | tile index: %v", seedIndex, tileIndex)
+		}
+	}
+}
+
+func TestProbabilitySuite(t *testing.T) {
+	suite.Run(t, new(probabilityTestSuite))
+}
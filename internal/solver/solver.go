@@ -0,0 +1,42 @@
+/*
+Package solver finds which tiles of a minefield are provably safe or provably
+mines, and the probability of each tile having a mine, using only the
+information visible to a player
+*/
+package solver
+
+import (
+	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
+)
+
+/*
+Solve analyses the visible state of the minefield and returns the hidden tiles,
+without a flag, that are certainly safe and certainly mines.
+Flags are trusted to be on mines.
+The simple rules are applied first, checking each number on its own and each
+pair of overlapping numbers, and only when they can not deduce anything else are
+all the mine layouts of the frontier enumerated.
+Returns an error if the visible board can not be satisfied by any mine layout.
+*/
+func Solve(minefield minefield.IMinefield) (result, error) {
+	board, error := readBoard(minefield, true)
+	if error != nil {
+		return result{}, error
+	}
+
+	deduced, error := board.Solve()
+	if error != nil {
+		return result{}, error
+	}
+
+	return result{
+		SafeTiles: deduced.SafeTiles,
+		MineTiles: deduced.MineTiles,
+	}, nil
+}
+
+// Result contains the tile indexes that are certainly safe or mines
+type result struct {
+	SafeTiles []int
+	MineTiles []int
+}
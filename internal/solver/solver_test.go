@@ -0,0 +1,297 @@
+package solver_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
+	"github.com/pedrohenriques/go-minesweeper/internal/solver"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type tile struct {
+	revealed      bool
+	hasMine       bool
+	hasFlag       bool
+	adjacentMines int
+	test          *testing.T
+}
+
+func (tile *tile) Revealed() bool {
+	return tile.revealed
+}
+
+func (tile *tile) HasMine() bool {
+	if !tile.revealed {
+		tile.test.Fatal("HasMine was called on a hidden tile")
+	}
+	return tile.hasMine
+}
+
+func (tile *tile) HasFlag() bool {
+	return tile.hasFlag
+}
+
+func (tile *tile) AdjacentMines() int {
+	return tile.adjacentMines
+}
+
+/*
+fakeMinefield is a visible board built from a text layout.
+Only the methods used by the solver are implemented.
+*/
+type fakeMinefield struct {
+	minefield.IMinefield
+	rows   int
+	cols   int
+	mines  int
+	tiles  []*tile
+	masked []bool
+}
+
+func (minefield *fakeMinefield) Rows() int {
+	return minefield.rows
+}
+
+func (minefield *fakeMinefield) Cols() int {
+	return minefield.cols
+}
+
+func (minefield *fakeMinefield) Mines() int {
+	return minefield.mines
+}
+
+func (minefield *fakeMinefield) Tile(rowIndex int, colIndex int) (minefield.ITile, error) {
+	if rowIndex < 0 || rowIndex >= minefield.rows || colIndex < 0 || colIndex >= minefield.cols {
+		return nil, fmt.Errorf("tile not found")
+	}
+	return minefield.tiles[rowIndex*minefield.cols+colIndex], nil
+}
+
+func (minefield *fakeMinefield) HasTile(rowIndex int, colIndex int) bool {
+	return !minefield.masked[rowIndex*minefield.cols+colIndex]
+}
+
+func (minefield *fakeMinefield) AdjacentTiles(rowIndex int, colIndex int) ([]int, error) {
+	tileIndexes := []int{}
+	for rIndex := rowIndex - 1; rIndex <= rowIndex+1; rIndex++ {
+		for cIndex := colIndex - 1; cIndex <= colIndex+1; cIndex++ {
+			if rIndex < 0 || rIndex >= minefield.rows || cIndex < 0 || cIndex >= minefield.cols {
+				continue
+			}
+			if rIndex == rowIndex && cIndex == colIndex || minefield.masked[rIndex*minefield.cols+cIndex] {
+				continue
+			}
+			tileIndexes = append(tileIndexes, rIndex*minefield.cols+cIndex)
+		}
+	}
+	return tileIndexes, nil
+}
+
+/*
+newFakeMinefield builds a visible board where each row is a string with one
+character per tile.
+0-8 = revealed number | ? = hidden | F = flag | * = revealed mine | . = masked
+*/
+func newFakeMinefield(test *testing.T, numMines int, rows ...string) *fakeMinefield {
+	minefield := &fakeMinefield{
+		rows:  len(rows),
+		cols:  len(rows[0]),
+		mines: numMines,
+	}
+
+	for _, row := range rows {
+		for _, char := range row {
+			tile := &tile{test: test}
+			minefield.masked = append(minefield.masked, char == '.')
+			switch {
+			case char == '?':
+			case char == 'F':
+				tile.hasFlag = true
+			case char == '*':
+				tile.revealed = true
+				tile.hasMine = true
+			case strings.ContainsRune("012345678", char):
+				tile.revealed = true
+				tile.adjacentMines = int(char - '0')
+			}
+			minefield.tiles = append(minefield.tiles, tile)
+		}
+	}
+
+	return minefield
+}
+
+type solverTestSuite struct {
+	suite.Suite
+}
+
+func (suite *solverTestSuite) TestSolveFindsTheMineNextToANumberWithASingleHiddenTile() {
+	sut := newFakeMinefield(suite.T(), 1, "1?")
+
+	result, err := solver.Solve(sut)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{}, result.SafeTiles)
+	require.Equal(suite.T(), []int{1}, result.MineTiles)
+}
+
+func (suite *solverTestSuite) TestSolveTrustsTheFlagsAndTheRevealedMines() {
+	for _, row := range []string{"F1?", "*1?"} {
+		sut := newFakeMinefield(suite.T(), 1, row)
+
+		result, err := solver.Solve(sut)
+
+		require.Nil(suite.T(), err)
+		require.Equal(suite.T(), []int{2}, result.SafeTiles)
+		require.Equal(suite.T(), []int{}, result.MineTiles)
+	}
+}
+
+func (suite *solverTestSuite) TestSolveAppliesTheSubsetRule() {
+	sut := newFakeMinefield(suite.T(), 2, "???", "121")
+
+	result, err := solver.Solve(sut)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{1}, result.SafeTiles)
+	require.Equal(suite.T(), []int{0, 2}, result.MineTiles)
+}
+
+func (suite *solverTestSuite) TestSolveChainsTheDeductionsOfOverlappingNumbers() {
+	sut := newFakeMinefield(suite.T(), 2, "????", "1121", "0000")
+
+	result, err := solver.Solve(sut)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{0, 2}, result.SafeTiles)
+	require.Equal(suite.T(), []int{1, 3}, result.MineTiles)
+}
+
+func (suite *solverTestSuite) TestSolveUsesTheMineCountToClearTheTilesOutsideTheFrontier() {
+	sut := newFakeMinefield(suite.T(), 1, "?1???")
+
+	result, err := solver.Solve(sut)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{3, 4}, result.SafeTiles)
+	require.Equal(suite.T(), []int{}, result.MineTiles)
+}
+
+func (suite *solverTestSuite) TestSolveUsesTheMineCountToFindTheMinesOutsideTheFrontier() {
+	sut := newFakeMinefield(suite.T(), 3, "?1???")
+
+	result, err := solver.Solve(sut)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{}, result.SafeTiles)
+	require.Equal(suite.T(), []int{3, 4}, result.MineTiles)
+}
+
+func (suite *solverTestSuite) TestSolveDoesNotDeduceAnythingOnAFiftyFiftyGuess() {
+	sut := newFakeMinefield(suite.T(), 1, "?1?")
+
+	result, err := solver.Solve(sut)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{}, result.SafeTiles)
+	require.Equal(suite.T(), []int{}, result.MineTiles)
+}
+
+func (suite *solverTestSuite) TestSolveDoesNotCountTheMaskedCellsAsUnknownTiles() {
+	sut := newFakeMinefield(suite.T(), 1, "?1.?")
+
+	result, err := solver.Solve(sut)
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []int{3}, result.SafeTiles)
+	require.Equal(suite.T(), []int{0}, result.MineTiles)
+}
+
+func (suite *solverTestSuite) TestSolveReturnsAnErrorIfANumberCanNotBeSatisfied() {
+	sut := newFakeMinefield(suite.T(), 1, "10?")
+
+	_, err := solver.Solve(sut)
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *solverTestSuite) TestSolveReturnsAnErrorIfTheMineCountCanNotBeSatisfied() {
+	sut := newFakeMinefield(suite.T(), 1, "?2?", "???")
+
+	_, err := solver.Solve(sut)
+
+	require.NotNil(suite.T(), err)
+}
+
+func (suite *solverTestSuite) TestSolveOnlyReturnsTilesThatMatchTheActualMinefield() {
+	for seedIndex := 0; seedIndex < 20; seedIndex++ {
+		sut, err := minefield.Generate(minefield.MinefieldConfig{
+			NumCols:   30,
+			NumRows:   16,
+			NumMines:  99,
+			Seed:      fmt.Sprintf("seed %v", seedIndex),
+			SafeStart: configs.SafeStartArea,
+		})
+		require.Nil(suite.T(), err)
+		sut.RevealTile(8, 15)
+
+		for {
+			result, err := solver.Solve(sut)
+			require.Nil(suite.T(), err)
+
+			for _, tileIndex := range result.MineTiles {
+				tile, _ := sut.Tile(tileIndex/sut.Cols(), tileIndex%sut.Cols())
+				require.Equalf(suite.T(), true, tile.HasMine(), "seed index: %v | tile index: %v", seedIndex, tileIndex)
+			}
+			for _, tileIndex := range result.SafeTiles {
+				tile, _ := sut.Tile(tileIndex/sut.Cols(), tileIndex%sut.Cols())
+				require.Equalf(suite.T(), false, tile.HasMine(), "seed index: %v | tile index: %v", seedIndex, tileIndex)
+			}
+
+			if len(result.SafeTiles) == 0 {
+				break
+			}
+			for _, tileIndex := range result.SafeTiles {
+				sut.RevealTile(tileIndex/sut.Cols(), tileIndex%sut.Cols())
+			}
+		}
+	}
+}
+
+func (suite *solverTestSuite) TestSolveClearsANoGuessMinefield() {
+	for seedIndex := 0; seedIndex < 5; seedIndex++ {
+		sut, err := minefield.Generate(minefield.MinefieldConfig{
+			NumCols:   30,
+			NumRows:   16,
+			NumMines:  99,
+			Seed:      fmt.Sprintf("seed %v", seedIndex),
+			SafeStart: configs.SafeStartArea,
+			NoGuess:   true,
+		})
+		require.Nil(suite.T(), err)
+		sut.RevealTile(8, 15)
+
+		for {
+			result, err := solver.Solve(sut)
+			require.Nil(suite.T(), err)
+
+			if len(result.SafeTiles) == 0 {
+				break
+			}
+			for _, tileIndex := range result.SafeTiles {
+				sut.RevealTile(tileIndex/sut.Cols(), tileIndex%sut.Cols())
+			}
+		}
+
+		require.Equalf(suite.T(), 30*16-99, sut.Stats().NumTilesRevealed, "seed index: %v", seedIndex)
+	}
+}
+
+func TestSolverSuite(t *testing.T) {
+	suite.Run(t, new(solverTestSuite))
+}
@@ -0,0 +1,21 @@
+package statistics
+
+import (
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+)
+
+type IStatistics interface {
+	/*
+		Record adds the result of the finished game, played on the difficulty, and
+		writes the statistics to the user's configuration directory.
+	*/
+	Record(difficulty string, gameInstance game.IGame) error
+	/*
+		Summary returns the statistics of the games played on the difficulty.
+	*/
+	Summary(difficulty string) summary
+	/*
+		Overall returns the statistics of every game played, on any difficulty.
+	*/
+	Overall() summary
+}
@@ -0,0 +1,291 @@
+/*
+Package statistics aggregates the results of every finished game, per
+difficulty, in a file of the user's configuration directory
+*/
+package statistics
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/pedrohenriques/go-minesweeper/internal/storage"
+)
+
+// The name of the file where the results are kept
+const fileName string = "statistics.json"
+
+// The version of the statistics file format
+const statisticsVersion int = 1
+
+// The number of buckets in the histogram of the completion times
+const numHistogramBuckets int = 10
+
+// Error: The statistics file was written by an incompatible version
+type unsupportedStatisticsVersionError struct {
+	Version int
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e unsupportedStatisticsVersionError) Error() string {
+	return fmt.Sprintf("The statistics version '%v' is not supported", e.Version)
+}
+
+// Error: Only finished games can be recorded
+type gameNotEndedError struct{}
+
+/*
+Error prints the message for this error.
+*/
+func (e gameNotEndedError) Error() string {
+	return "A game that is on going can not be recorded"
+}
+
+// Result describes a finished game
+type result struct {
+	Difficulty string
+	Won        bool
+	// The time between the first reveal and the end of the game
+	Time time.Duration
+	// The number of tiles revealed when the game ended, including mines
+	TilesRevealed int
+	EndTime       time.Time
+}
+
+// Bucket counts the wins with a completion time in a range
+type bucket struct {
+	// The shortest time in the bucket, inclusive
+	From time.Duration
+	// The longest time in the bucket, exclusive except for the last bucket
+	To    time.Duration
+	Count int
+}
+
+// Summary contains the statistics aggregated from a set of results
+type summary struct {
+	GamesPlayed int
+	GamesWon    int
+	// The percentage of the games played that were won, from 0 to 100
+	WinRate float64
+	// The number of consecutive wins up to the last game
+	CurrentStreak int
+	LongestStreak int
+	// The average and median of the completion times of the wins
+	AverageTime   time.Duration
+	MedianTime    time.Duration
+	TilesRevealed int
+	// The completion times of the wins, shortest first
+	Histogram []bucket
+}
+
+// StatisticsDocument contains everything written to the statistics file
+type statisticsDocument struct {
+	Version int
+	Results []result
+}
+
+// Statistics holds the results of every finished game, oldest first
+type statistics struct {
+	mutex   sync.Mutex
+	results []result
+}
+
+/*
+Load reads the statistics from the user's configuration directory.
+If no statistics were written yet empty ones are returned.
+*/
+func Load() (IStatistics, error) {
+	output := &statistics{
+		results: []result{},
+	}
+
+	data, error := storage.Read(fileName)
+	if errors.Is(error, fs.ErrNotExist) {
+		return output, nil
+	}
+	if error != nil {
+		return nil, error
+	}
+
+	document := statisticsDocument{}
+	error = json.Unmarshal(data, &document)
+	if error != nil {
+		return nil, error
+	}
+	if document.Version != statisticsVersion {
+		return nil, unsupportedStatisticsVersionError{
+			Version: document.Version,
+		}
+	}
+
+	output.results = append(output.results, document.Results...)
+
+	return output, nil
+}
+
+/*
+Record adds the result of the finished game, played on the difficulty, and
+writes the statistics to the user's configuration directory.
+*/
+func (statistics *statistics) Record(difficulty string, gameInstance game.IGame) error {
+	state := gameInstance.State()
+	if state == configs.StateOnGoing {
+		return gameNotEndedError{}
+	}
+
+	gameConfig := gameInstance.Config()
+	tilesRevealed := 0
+	for rowIndex := 0; rowIndex < gameConfig.NumRows; rowIndex++ {
+		for colIndex := 0; colIndex < gameConfig.NumCols; colIndex++ {
+			tile, error := gameInstance.Tile(rowIndex, colIndex)
+			if error == nil && tile.Revealed() {
+				tilesRevealed++
+			}
+		}
+	}
+
+	gameStats := gameInstance.Stats()
+	newResult := result{
+		Difficulty:    difficulty,
+		Won:           state == configs.StateWin,
+		Time:          gameStats.EndTime.Sub(gameStats.StartTime),
+		TilesRevealed: tilesRevealed,
+		EndTime:       gameStats.EndTime,
+	}
+
+	statistics.mutex.Lock()
+	defer statistics.mutex.Unlock()
+
+	statistics.results = append(statistics.results, newResult)
+
+	data, error := json.Marshal(statisticsDocument{
+		Version: statisticsVersion,
+		Results: statistics.results,
+	})
+	if error != nil {
+		return error
+	}
+
+	return storage.Write(fileName, data)
+}
+
+/*
+Summary returns the statistics of the games played on the difficulty.
+*/
+func (statistics *statistics) Summary(difficulty string) summary {
+	statistics.mutex.Lock()
+	defer statistics.mutex.Unlock()
+
+	results := []result{}
+	for _, result := range statistics.results {
+		if result.Difficulty == difficulty {
+			results = append(results, result)
+		}
+	}
+
+	return summarize(results)
+}
+
+/*
+Overall returns the statistics of every game played, on any difficulty.
+*/
+func (statistics *statistics) Overall() summary {
+	statistics.mutex.Lock()
+	defer statistics.mutex.Unlock()
+
+	return summarize(statistics.results)
+}
+
+/*
+summarize aggregates the results, which must be ordered oldest first.
+*/
+func summarize(results []result) summary {
+	output := summary{
+		GamesPlayed: len(results),
+		Histogram:   []bucket{},
+	}
+
+	winTimes := []time.Duration{}
+	streak := 0
+	for _, result := range results {
+		output.TilesRevealed += result.TilesRevealed
+
+		if !result.Won {
+			streak = 0
+			continue
+		}
+
+		output.GamesWon++
+		winTimes = append(winTimes, result.Time)
+		streak++
+		if streak > output.LongestStreak {
+			output.LongestStreak = streak
+		}
+	}
+	output.CurrentStreak = streak
+
+	if output.GamesPlayed > 0 {
+		output.WinRate = float64(output.GamesWon) * 100 / float64(output.GamesPlayed)
+	}
+	if len(winTimes) == 0 {
+		return output
+	}
+
+	sort.Slice(winTimes, func(i, j int) bool {
+		return winTimes[i] < winTimes[j]
+	})
+
+	var totalTime time.Duration
+	for _, winTime := range winTimes {
+		totalTime += winTime
+	}
+	output.AverageTime = totalTime / time.Duration(len(winTimes))
+
+	middle := len(winTimes) / 2
+	if len(winTimes)%2 == 0 {
+		output.MedianTime = (winTimes[middle-1] + winTimes[middle]) / 2
+	} else {
+		output.MedianTime = winTimes[middle]
+	}
+
+	output.Histogram = histogram(winTimes)
+
+	return output
+}
+
+/*
+histogram counts the times, which must be sorted, in buckets of the same width
+between the shortest and the longest time.
+*/
+func histogram(times []time.Duration) []bucket {
+	shortest := times[0]
+	longest := times[len(times)-1]
+	if shortest == longest {
+		return []bucket{{From: shortest, To: longest, Count: len(times)}}
+	}
+
+	width := (longest - shortest + time.Duration(numHistogramBuckets) - 1) / time.Duration(numHistogramBuckets)
+	buckets := make([]bucket, numHistogramBuckets)
+	for index := range buckets {
+		buckets[index].From = shortest + width*time.Duration(index)
+		buckets[index].To = buckets[index].From + width
+	}
+
+	for _, value := range times {
+		index := int((value - shortest) / width)
+		if index >= numHistogramBuckets {
+			index = numHistogramBuckets - 1
+		}
+		buckets[index].Count++
+	}
+
+	return buckets
+}
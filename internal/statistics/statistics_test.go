@@ -0,0 +1,207 @@
+package statistics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/pedrohenriques/go-minesweeper/internal/statistics"
+	"github.com/pedrohenriques/go-minesweeper/internal/storage"
+	"github.com/pedrohenriques/go-minesweeper/internal/storage/storagetest"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type statisticsTestSuite struct {
+	suite.Suite
+	// The time read by the games, moved forward by the tests
+	now time.Time
+}
+
+func (suite *statisticsTestSuite) SetupTest() {
+	storagetest.UseTempDir(suite.T())
+	suite.now = time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+}
+
+/*
+newGame generates a game where the only mine is on the center tile, so every
+other tile must be revealed to win.
+*/
+func (suite *statisticsTestSuite) newGame() game.IGame {
+	gameInstance, err := game.GenerateWithClock(game.GameConfig{
+		NumRows:   3,
+		NumCols:   3,
+		NumMines:  1,
+		Lives:     1,
+		MineTiles: []int{4},
+	}, func() time.Time {
+		return suite.now
+	})
+	require.Nil(suite.T(), err)
+
+	return gameInstance
+}
+
+/*
+wonGame plays a game to a win, taking the provided time after the first reveal.
+*/
+func (suite *statisticsTestSuite) wonGame(delay time.Duration) game.IGame {
+	gameInstance := suite.newGame()
+	for _, tileIndex := range []int{0, 1, 2, 3, 5, 6, 7, 8} {
+		gameInstance.RevealTile(tileIndex/3, tileIndex%3)
+		if tileIndex == 0 {
+			suite.now = suite.now.Add(delay)
+		}
+	}
+	require.Equal(suite.T(), configs.StateWin, gameInstance.State())
+
+	return gameInstance
+}
+
+/*
+lostGame plays a game to a loss, by revealing the mine after another tile.
+*/
+func (suite *statisticsTestSuite) lostGame() game.IGame {
+	gameInstance := suite.newGame()
+	gameInstance.RevealTile(0, 0)
+	gameInstance.RevealTile(1, 1)
+	require.Equal(suite.T(), configs.StateLoss, gameInstance.State())
+
+	return gameInstance
+}
+
+/*
+gameTime returns the time between the start and the end of the game.
+*/
+func gameTime(gameInstance game.IGame) time.Duration {
+	return gameInstance.Stats().EndTime.Sub(gameInstance.StartTime())
+}
+
+func (suite *statisticsTestSuite) TestLoadReturnsEmptyStatisticsIfNoneWereWritten() {
+	sut, err := statistics.Load()
+
+	require.Nil(suite.T(), err)
+	actual := sut.Overall()
+	require.Equal(suite.T(), 0, actual.GamesPlayed)
+	require.Equal(suite.T(), 0.0, actual.WinRate)
+	require.Empty(suite.T(), actual.Histogram)
+}
+
+func (suite *statisticsTestSuite) TestRecordReturnsAnErrorIfTheGameIsOnGoing() {
+	sut, _ := statistics.Load()
+
+	err := sut.Record("Easy", suite.newGame())
+
+	require.NotNil(suite.T(), err)
+	require.Equal(suite.T(), 0, sut.Overall().GamesPlayed)
+}
+
+func (suite *statisticsTestSuite) TestSummaryCountsTheGamesPlayedAndWonOfTheDifficulty() {
+	sut, _ := statistics.Load()
+	sut.Record("Easy", suite.wonGame(0))
+	sut.Record("Easy", suite.lostGame())
+	sut.Record("Easy", suite.wonGame(0))
+	sut.Record("Easy", suite.wonGame(0))
+	sut.Record("Hard", suite.lostGame())
+
+	actual := sut.Summary("Easy")
+
+	require.Equal(suite.T(), 4, actual.GamesPlayed)
+	require.Equal(suite.T(), 3, actual.GamesWon)
+	require.Equal(suite.T(), 75.0, actual.WinRate)
+	require.Equal(suite.T(), 5, sut.Overall().GamesPlayed)
+}
+
+func (suite *statisticsTestSuite) TestSummaryHasTheCurrentAndLongestStreaks() {
+	sut, _ := statistics.Load()
+	sut.Record("Easy", suite.wonGame(0))
+	sut.Record("Easy", suite.wonGame(0))
+	sut.Record("Easy", suite.wonGame(0))
+	sut.Record("Easy", suite.lostGame())
+	sut.Record("Easy", suite.wonGame(0))
+
+	actual := sut.Summary("Easy")
+
+	require.Equal(suite.T(), 1, actual.CurrentStreak)
+	require.Equal(suite.T(), 3, actual.LongestStreak)
+
+	sut.Record("Easy", suite.lostGame())
+	require.Equal(suite.T(), 0, sut.Summary("Easy").CurrentStreak)
+}
+
+func (suite *statisticsTestSuite) TestSummaryHasTheAverageAndMedianTimesOfTheWins() {
+	sut, _ := statistics.Load()
+	wins := []game.IGame{
+		suite.wonGame(0),
+		suite.wonGame(10 * time.Millisecond),
+		suite.wonGame(30 * time.Millisecond),
+	}
+	for _, gameInstance := range wins {
+		sut.Record("Easy", gameInstance)
+	}
+	sut.Record("Easy", suite.lostGame())
+
+	actual := sut.Summary("Easy")
+
+	require.Equal(suite.T(), (gameTime(wins[0])+gameTime(wins[1])+gameTime(wins[2]))/3, actual.AverageTime)
+	require.Equal(suite.T(), gameTime(wins[1]), actual.MedianTime)
+}
+
+func (suite *statisticsTestSuite) TestSummaryCountsTheTilesRevealed() {
+	sut, _ := statistics.Load()
+	sut.Record("Easy", suite.wonGame(0))
+	sut.Record("Easy", suite.lostGame())
+
+	actual := sut.Summary("Easy")
+
+	require.Equal(suite.T(), 8+2, actual.TilesRevealed)
+}
+
+func (suite *statisticsTestSuite) TestTheHistogramCountsEveryWinFromTheShortestToTheLongestTime() {
+	sut, _ := statistics.Load()
+	wins := []game.IGame{
+		suite.wonGame(0),
+		suite.wonGame(10 * time.Millisecond),
+		suite.wonGame(20 * time.Millisecond),
+	}
+	for _, gameInstance := range wins {
+		sut.Record("Easy", gameInstance)
+	}
+
+	actual := sut.Summary("Easy").Histogram
+
+	require.Equal(suite.T(), 10, len(actual))
+	require.Equal(suite.T(), gameTime(wins[0]), actual[0].From)
+	require.GreaterOrEqual(suite.T(), actual[len(actual)-1].To, gameTime(wins[2]))
+	require.Equal(suite.T(), 1, actual[0].Count)
+	require.Equal(suite.T(), 1, actual[len(actual)-1].Count)
+	total := 0
+	for _, bucket := range actual {
+		total += bucket.Count
+	}
+	require.Equal(suite.T(), 3, total)
+}
+
+func (suite *statisticsTestSuite) TestLoadReadsTheResultsThatWereRecorded() {
+	first, _ := statistics.Load()
+	first.Record("Easy", suite.wonGame(0))
+	first.Record("Easy", suite.lostGame())
+
+	sut, err := statistics.Load()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), first.Summary("Easy"), sut.Summary("Easy"))
+}
+
+func (suite *statisticsTestSuite) TestLoadReturnsAnErrorForAnUnsupportedVersion() {
+	storage.Write("statistics.json", []byte(`{"Version": 99}`))
+
+	_, err := statistics.Load()
+
+	require.NotNil(suite.T(), err)
+}
+
+func TestStatisticsSuite(t *testing.T) {
+	suite.Run(t, new(statisticsTestSuite))
+}
@@ -0,0 +1,97 @@
+/*
+Package storage reads and writes the files the application keeps between runs,
+in the user's configuration directory
+*/
+package storage
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// The name of the directory, inside the user's configuration directory, where the files are kept
+const appDirName string = "go-minesweeper"
+
+/*
+Dir returns the path to the directory where the files are kept.
+*/
+func Dir() (string, error) {
+	configDir, error := os.UserConfigDir()
+	if error != nil {
+		return "", error
+	}
+
+	return filepath.Join(configDir, appDirName), nil
+}
+
+/*
+Read returns the content of the file with the provided name.
+Returns an error matching fs.ErrNotExist if the file does not exist.
+*/
+func Read(name string) ([]byte, error) {
+	dir, error := Dir()
+	if error != nil {
+		return nil, error
+	}
+
+	return os.ReadFile(filepath.Join(dir, name))
+}
+
+/*
+Write replaces the content of the file with the provided name, creating the
+directory if needed.
+The content is written to a temporary file first, so an interrupted write does
+not corrupt the existing file.
+*/
+func Write(name string, data []byte) error {
+	dir, error := Dir()
+	if error != nil {
+		return error
+	}
+
+	error = os.MkdirAll(dir, 0o755)
+	if error != nil {
+		return error
+	}
+
+	tmpPath := filepath.Join(dir, name+".tmp")
+	error = os.WriteFile(tmpPath, data, 0o644)
+	if error != nil {
+		return error
+	}
+
+	return os.Rename(tmpPath, filepath.Join(dir, name))
+}
+
+/*
+Delete removes the file with the provided name.
+Deleting a file that does not exist is not an error.
+*/
+func Delete(name string) error {
+	dir, error := Dir()
+	if error != nil {
+		return error
+	}
+
+	error = os.Remove(filepath.Join(dir, name))
+	if errors.Is(error, fs.ErrNotExist) {
+		return nil
+	}
+
+	return error
+}
+
+/*
+Exists returns true if a file with the provided name exists.
+*/
+func Exists(name string) bool {
+	dir, error := Dir()
+	if error != nil {
+		return false
+	}
+
+	_, error = os.Stat(filepath.Join(dir, name))
+	return error == nil
+}
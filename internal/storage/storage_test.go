@@ -0,0 +1,82 @@
+package storage_test
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/storage"
+	"github.com/pedrohenriques/go-minesweeper/internal/storage/storagetest"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type storageTestSuite struct {
+	suite.Suite
+	configDir string
+}
+
+func (suite *storageTestSuite) SetupTest() {
+	suite.configDir = storagetest.UseTempDir(suite.T())
+}
+
+func (suite *storageTestSuite) TestDirReturnsADirectoryInsideTheUserConfigDirectory() {
+	actual, err := storage.Dir()
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), "go-minesweeper", filepath.Base(actual))
+}
+
+func (suite *storageTestSuite) TestReadReturnsTheDataThatWasWritten() {
+	err := storage.Write("game.json", []byte("hello"))
+	require.Nil(suite.T(), err)
+
+	actual, err := storage.Read("game.json")
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []byte("hello"), actual)
+}
+
+func (suite *storageTestSuite) TestWriteReplacesTheExistingData() {
+	storage.Write("game.json", []byte("hello"))
+	storage.Write("game.json", []byte("bye"))
+
+	actual, err := storage.Read("game.json")
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), []byte("bye"), actual)
+}
+
+func (suite *storageTestSuite) TestReadReturnsANotExistErrorIfTheFileDoesNotExist() {
+	_, err := storage.Read("game.json")
+
+	require.Equal(suite.T(), true, errors.Is(err, fs.ErrNotExist))
+}
+
+func (suite *storageTestSuite) TestExistsReturnsTrueOnlyForWrittenFiles() {
+	require.Equal(suite.T(), false, storage.Exists("game.json"))
+
+	storage.Write("game.json", []byte("hello"))
+
+	require.Equal(suite.T(), true, storage.Exists("game.json"))
+}
+
+func (suite *storageTestSuite) TestDeleteRemovesTheFile() {
+	storage.Write("game.json", []byte("hello"))
+
+	err := storage.Delete("game.json")
+
+	require.Nil(suite.T(), err)
+	require.Equal(suite.T(), false, storage.Exists("game.json"))
+}
+
+func (suite *storageTestSuite) TestDeleteDoesNotReturnAnErrorIfTheFileDoesNotExist() {
+	err := storage.Delete("game.json")
+
+	require.Nil(suite.T(), err)
+}
+
+func TestStorageSuite(t *testing.T) {
+	suite.Run(t, new(storageTestSuite))
+}
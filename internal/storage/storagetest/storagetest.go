@@ -0,0 +1,21 @@
+/*
+Package storagetest helps the tests of the packages that keep their files with
+the storage package
+*/
+package storagetest
+
+import "testing"
+
+/*
+UseTempDir makes the storage package keep its files in a temporary directory,
+removed when the test ends, and returns the directory.
+The directory is set as the user's config directory on every platform.
+*/
+func UseTempDir(t testing.TB) string {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	t.Setenv("AppData", configDir)
+
+	return configDir
+}
@@ -0,0 +1,233 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/minefield"
+)
+
+// The colours of the numbers of adjacent mines, indexed by the number, with
+// the last colour used for the higher numbers
+var adjacentMinesColours = []string{
+	"",
+	"\x1b[94m",
+	"\x1b[32m",
+	"\x1b[91m",
+	"\x1b[34m",
+	"\x1b[31m",
+	"\x1b[36m",
+	"\x1b[35m",
+	"\x1b[90m",
+}
+
+// The escape sequences used to draw the tiles that are not numbers
+const (
+	escHiddenTile = "\x1b[90m"
+	escFlag       = "\x1b[1;91m"
+	escMine       = "\x1b[1;97;41m"
+	escHint       = "\x1b[42m"
+)
+
+/*
+handleGameKey moves the cursor or applies the key's action to the game.
+*/
+func handleGameKey(state *tuiState, key keyPress) {
+	gameConfig := state.gameInstance.Config()
+
+	switch key.Code {
+	case keyUp:
+		if state.cursorRow > 0 {
+			state.cursorRow--
+		}
+		return
+	case keyDown:
+		if state.cursorRow < gameConfig.NumRows-1 {
+			state.cursorRow++
+		}
+		return
+	case keyLeft:
+		if state.cursorCol > 0 {
+			state.cursorCol--
+		}
+		return
+	case keyRight:
+		if state.cursorCol < gameConfig.NumCols-1 {
+			state.cursorCol++
+		}
+		return
+	case keyEnter:
+		key = keyPress{Code: keyRune, Char: ' '}
+	case keyRune:
+	default:
+		return
+	}
+
+	var error error
+
+	switch key.Char {
+	case ' ':
+		error = processClick(state, configs.PrimaryClick)
+	case 'f':
+		error = processClick(state, configs.SecondaryClick)
+	case 'c':
+		error = processClick(state, configs.BothClick)
+	case 'u':
+		_, error = state.gameInstance.Undo()
+	case 'r':
+		_, error = state.gameInstance.Redo()
+	case 'h':
+		hint, hintError := state.gameInstance.Hint()
+		error = hintError
+		if error == nil && hint.TileIndex >= 0 {
+			state.hintTileIndex = hint.TileIndex
+			state.cursorRow = hint.TileIndex / gameConfig.NumCols
+			state.cursorCol = hint.TileIndex % gameConfig.NumCols
+		}
+	case 's':
+		startGame(state, state.gameConfig)
+		return
+	case 'n':
+		state.screen = screenSetup
+		state.message = ""
+		return
+	default:
+		return
+	}
+
+	if key.Char != 'h' {
+		state.hintTileIndex = -1
+	}
+
+	state.message = ""
+	if error != nil {
+		state.message = error.Error()
+	}
+	switch state.gameInstance.State() {
+	case configs.StateWin:
+		state.message = "You have won!"
+	case configs.StateLoss:
+		state.message = "You have lost!"
+	}
+}
+
+/*
+processClick applies a click of the provided type on the tile under the cursor,
+if the game is on going.
+
+clickType: 0 = primary | 1 = secondary | 2 = both
+*/
+func processClick(state *tuiState, clickType int) error {
+	if state.gameInstance.State() != configs.StateOnGoing {
+		return nil
+	}
+
+	var error error
+
+	switch clickType {
+	case configs.PrimaryClick:
+		_, error = state.gameInstance.RevealTile(state.cursorRow, state.cursorCol)
+	case configs.SecondaryClick:
+		error = state.gameInstance.ToggleFlag(state.cursorRow, state.cursorCol)
+	case configs.BothClick:
+		_, error = state.gameInstance.ProcessAdjacentTiles(state.cursorRow, state.cursorCol)
+	}
+
+	return error
+}
+
+/*
+renderGame draws the lines of the game screen, with the time elapsed measured
+at the provided time.
+*/
+func renderGame(state *tuiState, now time.Time) []string {
+	gameInstance := state.gameInstance
+	gameConfig := gameInstance.Config()
+	gameStats := gameInstance.Stats()
+	gameEnded := gameInstance.State() != configs.StateOnGoing
+
+	elapsed := time.Duration(0)
+	if !gameStats.StartTime.IsZero() {
+		if gameEnded {
+			now = gameStats.EndTime
+		}
+		elapsed = now.Sub(gameStats.StartTime).Truncate(time.Second)
+	}
+
+	lines := []string{
+		escBold + "go-minesweeper",
+		fmt.Sprintf("Mines: %v | Lives: %v | Time: %v", gameStats.RemainingMines, gameStats.RemainingLives, elapsed),
+		"",
+	}
+
+	for rowIndex := 0; rowIndex < gameConfig.NumRows; rowIndex++ {
+		line := ""
+		for colIndex := 0; colIndex < gameConfig.NumCols; colIndex++ {
+			tile, error := gameInstance.Tile(rowIndex, colIndex)
+
+			// The cells removed by a mask are left blank
+			style, text := "", " "
+			if error == nil {
+				style, text = tileStyle(tile, gameEnded)
+			}
+			if rowIndex*gameConfig.NumCols+colIndex == state.hintTileIndex {
+				style += escHint
+			}
+			if rowIndex == state.cursorRow && colIndex == state.cursorCol {
+				style += escReverse
+			}
+
+			line += " " + style + text + escReset
+		}
+		lines = append(lines, line)
+	}
+
+	return append(lines,
+		"",
+		state.message,
+		"",
+		"Arrows: move | Space/Enter: reveal | f: flag | c: reveal adjacent | h: hint",
+		"u: undo | r: redo | s: restart | n: new game | q: quit",
+	)
+}
+
+/*
+tileStyle returns the escape sequence and the character that represent the
+tile.
+If forceReveal is true the tile is drawn as if it was revealed.
+*/
+func tileStyle(tile minefield.ITile, forceReveal bool) (string, string) {
+	if tile.Revealed() || forceReveal {
+		if tile.HasFlag() {
+			if !tile.HasMine() {
+				return escFlag, "X"
+			}
+			return escFlag, "F"
+		} else if tile.HasMine() {
+			return escMine, "*"
+		} else if tile.AdjacentMines() > 0 {
+			return adjacentMinesColour(tile.AdjacentMines()), fmt.Sprint(tile.AdjacentMines())
+		}
+		return "", "."
+	}
+
+	if tile.HasFlag() {
+		return escFlag, "F"
+	}
+
+	return escHiddenTile, "#"
+}
+
+/*
+adjacentMinesColour returns the colour of the number of adjacent mines.
+The topologies with more than 8 adjacent tiles, such as boards with layers, use
+the colour of 8 for the higher numbers.
+*/
+func adjacentMinesColour(adjacentMines int) string {
+	if adjacentMines > len(adjacentMinesColours)-1 {
+		return adjacentMinesColours[len(adjacentMinesColours)-1]
+	}
+
+	return adjacentMinesColours[adjacentMines]
+}
@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// testTile is a tile with a fixed state, to draw tiles the test boards can not
+// have
+type testTile struct {
+	revealed      bool
+	hasMine       bool
+	hasFlag       bool
+	adjacentMines int
+}
+
+func (t testTile) Revealed() bool     { return t.revealed }
+func (t testTile) HasMine() bool      { return t.hasMine }
+func (t testTile) HasFlag() bool      { return t.hasFlag }
+func (t testTile) AdjacentMines() int { return t.adjacentMines }
+
+type gameTestSuite struct {
+	suite.Suite
+	state *tuiState
+}
+
+func (suite *gameTestSuite) SetupTest() {
+	suite.state = newTuiState(&configs.Configs{
+		SizeOptions: map[string]configs.SizeOption{
+			"Test": {NumMines: 1, NumRows: 3, NumCols: 3},
+		},
+	})
+	// A 3x3 board with a mine on the last tile
+	startGame(suite.state, game.GameConfig{
+		NumRows:      3,
+		NumCols:      3,
+		NumMines:     1,
+		FlagsEnabled: true,
+		Lives:        1,
+		MineTiles:    []int{8},
+	})
+}
+
+func (suite *gameTestSuite) pressKeys(keys ...keyPress) {
+	for _, key := range keys {
+		handleGameKey(suite.state, key)
+	}
+}
+
+func (suite *gameTestSuite) TestTheCursorKeysMoveTheCursorUntilTheEdgesOfTheBoard() {
+	suite.pressKeys(keyPress{Code: keyUp}, keyPress{Code: keyLeft})
+	require.Equal(suite.T(), []int{0, 0}, []int{suite.state.cursorRow, suite.state.cursorCol})
+
+	suite.pressKeys(keyPress{Code: keyDown}, keyPress{Code: keyDown}, keyPress{Code: keyDown}, keyPress{Code: keyRight})
+	require.Equal(suite.T(), []int{2, 1}, []int{suite.state.cursorRow, suite.state.cursorCol})
+}
+
+func (suite *gameTestSuite) TestTheFlagKeyTogglesTheFlagOfTheTileUnderTheCursor() {
+	suite.pressKeys(keyPress{Code: keyRight}, keyPress{Code: keyRune, Char: 'f'})
+
+	tile, _ := suite.state.gameInstance.Tile(0, 1)
+	require.Equal(suite.T(), true, tile.HasFlag())
+	require.Equal(suite.T(), 0, suite.state.gameInstance.Stats().RemainingMines)
+}
+
+func (suite *gameTestSuite) TestRevealingAnEmptyTileWinsTheGame() {
+	suite.pressKeys(keyPress{Code: keyRune, Char: ' '})
+
+	require.Equal(suite.T(), configs.StateWin, suite.state.gameInstance.State())
+	require.Equal(suite.T(), "You have won!", suite.state.message)
+}
+
+func (suite *gameTestSuite) TestEnterRevealsTheTileUnderTheCursor() {
+	suite.pressKeys(keyPress{Code: keyDown}, keyPress{Code: keyDown}, keyPress{Code: keyRight}, keyPress{Code: keyRight}, keyPress{Code: keyEnter})
+
+	require.Equal(suite.T(), configs.StateLoss, suite.state.gameInstance.State())
+	require.Equal(suite.T(), "You have lost!", suite.state.message)
+}
+
+func (suite *gameTestSuite) TestTheTilesCanNotBeChangedOnceTheGameEnded() {
+	suite.pressKeys(keyPress{Code: keyRune, Char: ' '}, keyPress{Code: keyDown}, keyPress{Code: keyRune, Char: 'f'})
+
+	tile, _ := suite.state.gameInstance.Tile(1, 0)
+	require.Equal(suite.T(), false, tile.HasFlag())
+}
+
+func (suite *gameTestSuite) TestTheNewGameKeyShowsTheSetupScreen() {
+	suite.pressKeys(keyPress{Code: keyRune, Char: 'n'})
+
+	require.Equal(suite.T(), screenSetup, suite.state.screen)
+}
+
+func (suite *gameTestSuite) TestTheRestartKeyStartsAGameWithTheSameConfiguration() {
+	previousGame := suite.state.gameInstance
+	suite.pressKeys(keyPress{Code: keyDown}, keyPress{Code: keyRune, Char: 'r'}, keyPress{Code: keyRune, Char: 's'})
+
+	require.NotSame(suite.T(), previousGame, suite.state.gameInstance)
+	require.Equal(suite.T(), []int{0, 0}, []int{suite.state.cursorRow, suite.state.cursorCol})
+	require.Equal(suite.T(), previousGame.Config(), suite.state.gameInstance.Config())
+}
+
+func (suite *gameTestSuite) TestTileStyleDrawsEachStateOfATile() {
+	cases := []struct {
+		tile        testTile
+		forceReveal bool
+		style       string
+		text        string
+	}{
+		{testTile{}, false, escHiddenTile, "#"},
+		{testTile{hasFlag: true}, false, escFlag, "F"},
+		{testTile{hasFlag: true}, true, escFlag, "X"},
+		{testTile{hasFlag: true, hasMine: true}, true, escFlag, "F"},
+		{testTile{hasMine: true}, true, escMine, "*"},
+		{testTile{revealed: true}, false, "", "."},
+		{testTile{revealed: true, adjacentMines: 3}, false, adjacentMinesColours[3], "3"},
+	}
+
+	for _, testCase := range cases {
+		style, text := tileStyle(testCase.tile, testCase.forceReveal)
+
+		require.Equalf(suite.T(), []string{testCase.style, testCase.text}, []string{style, text}, "tile: %+v", testCase.tile)
+	}
+}
+
+func (suite *gameTestSuite) TestTileStyleUsesTheLastColourForMoreThanEightAdjacentMines() {
+	style, text := tileStyle(testTile{revealed: true, adjacentMines: 26}, false)
+
+	require.Equal(suite.T(), adjacentMinesColours[8], style)
+	require.Equal(suite.T(), "26", text)
+}
+
+func (suite *gameTestSuite) TestRenderDrawsTheBoardWithTheCursorAndTheStats() {
+	suite.pressKeys(keyPress{Code: keyRight}, keyPress{Code: keyRune, Char: 'f'})
+
+	output := render(suite.state, time.Now())
+
+	lines := strings.Split(output, "\r\n")
+	require.Equal(suite.T(), true, strings.HasPrefix(lines[0], escCursorHome))
+	require.Equal(suite.T(), "Mines: 0 | Lives: 1 | Time: 0s"+escReset+escClearLine, lines[1])
+	require.Equal(suite.T(),
+		" "+escHiddenTile+"#"+escReset+" "+escFlag+escReverse+"F"+escReset+" "+escHiddenTile+"#"+escReset+escReset+escClearLine,
+		lines[3])
+}
+
+func TestGameSuite(t *testing.T) {
+	suite.Run(t, new(gameTestSuite))
+}
@@ -0,0 +1,60 @@
+package tui
+
+// The keys the TUI reacts to, besides printable characters
+const (
+	keyRune = iota
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyEnter
+	keyEscape
+	keyInterrupt
+)
+
+// KeyPress describes a key read from the terminal
+type keyPress struct {
+	// One of the key* constants
+	Code int
+	// The character typed, if the code is keyRune
+	Char rune
+}
+
+/*
+parseKeys converts the bytes read from the terminal into key presses.
+The escape sequences of the cursor keys are recognized, other escape sequences
+are ignored.
+*/
+func parseKeys(data []byte) []keyPress {
+	keys := []keyPress{}
+
+	for index := 0; index < len(data); index++ {
+		switch data[index] {
+		case 0x03:
+			keys = append(keys, keyPress{Code: keyInterrupt})
+		case '\r', '\n':
+			keys = append(keys, keyPress{Code: keyEnter})
+		case 0x1b:
+			if index+2 >= len(data) || (data[index+1] != '[' && data[index+1] != 'O') {
+				keys = append(keys, keyPress{Code: keyEscape})
+				continue
+			}
+
+			switch data[index+2] {
+			case 'A':
+				keys = append(keys, keyPress{Code: keyUp})
+			case 'B':
+				keys = append(keys, keyPress{Code: keyDown})
+			case 'C':
+				keys = append(keys, keyPress{Code: keyRight})
+			case 'D':
+				keys = append(keys, keyPress{Code: keyLeft})
+			}
+			index += 2
+		default:
+			keys = append(keys, keyPress{Code: keyRune, Char: rune(data[index])})
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type keysTestSuite struct {
+	suite.Suite
+}
+
+func (suite *keysTestSuite) TestParseKeysRecognizesTheCursorKeysOfBothEscapeSequences() {
+	keys := parseKeys([]byte("\x1b[A\x1b[B\x1bOC\x1bOD"))
+
+	require.Equal(suite.T(), []keyPress{{Code: keyUp}, {Code: keyDown}, {Code: keyRight}, {Code: keyLeft}}, keys)
+}
+
+func (suite *keysTestSuite) TestParseKeysReturnsTheCharactersEnterAndInterrupt() {
+	keys := parseKeys([]byte("f\r\n\x03"))
+
+	require.Equal(suite.T(), []keyPress{{Code: keyRune, Char: 'f'}, {Code: keyEnter}, {Code: keyEnter}, {Code: keyInterrupt}}, keys)
+}
+
+func (suite *keysTestSuite) TestParseKeysReturnsAnEscapeThatDoesNotStartASequence() {
+	require.Equal(suite.T(), []keyPress{{Code: keyEscape}}, parseKeys([]byte("\x1b")))
+	require.Equal(suite.T(), []keyPress{{Code: keyEscape}, {Code: keyRune, Char: 'x'}, {Code: keyRune, Char: 'y'}}, parseKeys([]byte("\x1bxy")))
+}
+
+func (suite *keysTestSuite) TestParseKeysIgnoresTheOtherEscapeSequences() {
+	keys := parseKeys([]byte("\x1b[Zq"))
+
+	require.Equal(suite.T(), []keyPress{{Code: keyRune, Char: 'q'}}, keys)
+}
+
+func TestKeysSuite(t *testing.T) {
+	suite.Run(t, new(keysTestSuite))
+}
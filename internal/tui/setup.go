@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+)
+
+// The rows of the setup screen
+const (
+	setupRowDifficulty = iota
+	setupRowSafeStart
+	setupRowNoGuess
+	setupRowFlags
+	setupRowLives
+	numSetupRows
+)
+
+// The labels of the configs.SafeStart* options, in order
+var safeStartLabels = []string{"Random opening", "Safe first click", "Safe first click area"}
+
+// SetupState holds the options chosen on the setup screen
+type setupState struct {
+	selectedRow  int
+	difficulty   int
+	safeStart    int
+	noGuess      bool
+	flagsEnabled bool
+	lives        int
+}
+
+/*
+newSetupState creates the setup options with the same defaults as the GUI.
+*/
+func newSetupState() setupState {
+	return setupState{
+		safeStart:    configs.SafeStartNone,
+		flagsEnabled: true,
+		lives:        1,
+	}
+}
+
+/*
+handleSetupKey moves between the options, changes the selected option or
+starts a game.
+*/
+func handleSetupKey(state *tuiState, key keyPress) {
+	setup := &state.setup
+
+	switch key.Code {
+	case keyUp:
+		setup.selectedRow = (setup.selectedRow + numSetupRows - 1) % numSetupRows
+	case keyDown:
+		setup.selectedRow = (setup.selectedRow + 1) % numSetupRows
+	case keyLeft:
+		changeSetupOption(state, -1)
+	case keyRight:
+		changeSetupOption(state, 1)
+	case keyEnter:
+		if len(state.difficulties) == 0 {
+			return
+		}
+
+		option := state.config.SizeOptions[state.difficulties[setup.difficulty]]
+		startGame(state, game.GameConfig{
+			NumMines:     option.NumMines,
+			NumRows:      option.NumRows,
+			NumCols:      option.NumCols,
+			FlagsEnabled: setup.flagsEnabled,
+			Lives:        setup.lives,
+			SafeStart:    setup.safeStart,
+			NoGuess:      setup.noGuess,
+		})
+	}
+}
+
+/*
+changeSetupOption moves the selected option to the previous, if direction is
+negative, or next value.
+*/
+func changeSetupOption(state *tuiState, direction int) {
+	setup := &state.setup
+
+	switch setup.selectedRow {
+	case setupRowDifficulty:
+		if len(state.difficulties) > 0 {
+			setup.difficulty = (setup.difficulty + len(state.difficulties) + direction) % len(state.difficulties)
+		}
+	case setupRowSafeStart:
+		setup.safeStart = (setup.safeStart + len(safeStartLabels) + direction) % len(safeStartLabels)
+	case setupRowNoGuess:
+		setup.noGuess = !setup.noGuess
+	case setupRowFlags:
+		setup.flagsEnabled = !setup.flagsEnabled
+	case setupRowLives:
+		if setup.lives+direction >= 1 {
+			setup.lives += direction
+		}
+	}
+}
+
+/*
+renderSetup draws the lines of the setup screen.
+*/
+func renderSetup(state *tuiState) []string {
+	setup := state.setup
+
+	difficulty := ""
+	if len(state.difficulties) > 0 {
+		difficulty = state.difficulties[setup.difficulty]
+	}
+
+	values := make([]string, numSetupRows)
+	values[setupRowDifficulty] = fmt.Sprintf("Difficulty: < %v >", difficulty)
+	values[setupRowSafeStart] = fmt.Sprintf("First click: < %v >", safeStartLabels[setup.safeStart])
+	values[setupRowNoGuess] = fmt.Sprintf("No guessing required: < %v >", onOffLabel(setup.noGuess))
+	values[setupRowFlags] = fmt.Sprintf("Enable flags: < %v >", onOffLabel(setup.flagsEnabled))
+	values[setupRowLives] = fmt.Sprintf("Number of lives: < %v >", setup.lives)
+
+	lines := []string{escBold + "go-minesweeper", ""}
+	for rowIndex, value := range values {
+		if rowIndex == setup.selectedRow {
+			lines = append(lines, "> "+escReverse+value)
+		} else {
+			lines = append(lines, "  "+value)
+		}
+	}
+
+	return append(lines,
+		"",
+		state.message,
+		"",
+		"Up/Down: select option | Left/Right: change option | Enter: start game | q: quit",
+	)
+}
+
+/*
+onOffLabel returns the label shown for a boolean option.
+*/
+func onOffLabel(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+
+	return "off"
+}
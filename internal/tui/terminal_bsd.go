@@ -0,0 +1,11 @@
+//go:build darwin || freebsd || netbsd || openbsd
+
+package tui
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// The requests used to read and change the terminal settings
+const ioctlGetTermios = unix.TIOCGETA
+const ioctlSetTermios = unix.TIOCSETA
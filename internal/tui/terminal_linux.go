@@ -0,0 +1,9 @@
+package tui
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// The requests used to read and change the terminal settings
+const ioctlGetTermios = unix.TCGETS
+const ioctlSetTermios = unix.TCSETS
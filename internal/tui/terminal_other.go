@@ -0,0 +1,40 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+
+package tui
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Error: The terminal can not be put in raw mode on this operating system
+type unsupportedTerminalError struct {
+	OS string
+}
+
+/*
+Error prints the message for this error.
+*/
+func (e unsupportedTerminalError) Error() string {
+	return fmt.Sprintf("The terminal UI is not supported on '%v'", e.OS)
+}
+
+// TerminalState holds the terminal settings to restore when the TUI exits
+type terminalState struct{}
+
+/*
+makeRaw returns an error, since raw mode is not supported on this operating
+system.
+*/
+func makeRaw(fd int) (*terminalState, error) {
+	return nil, unsupportedTerminalError{
+		OS: runtime.GOOS,
+	}
+}
+
+/*
+restore does nothing, since raw mode is not supported on this operating system.
+*/
+func restore(fd int, state *terminalState) error {
+	return nil
+}
@@ -0,0 +1,47 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package tui
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// TerminalState holds the terminal settings to restore when the TUI exits
+type terminalState struct {
+	termios unix.Termios
+}
+
+/*
+makeRaw puts the terminal in raw mode, so keys are read as they are pressed and
+not echoed, and returns the previous settings.
+*/
+func makeRaw(fd int) (*terminalState, error) {
+	termios, error := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	if error != nil {
+		return nil, error
+	}
+
+	state := &terminalState{termios: *termios}
+
+	termios.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	termios.Oflag &^= unix.OPOST
+	termios.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	termios.Cflag &^= unix.CSIZE | unix.PARENB
+	termios.Cflag |= unix.CS8
+	termios.Cc[unix.VMIN] = 1
+	termios.Cc[unix.VTIME] = 0
+
+	error = unix.IoctlSetTermios(fd, ioctlSetTermios, termios)
+	if error != nil {
+		return nil, error
+	}
+
+	return state, nil
+}
+
+/*
+restore sets the terminal settings back to the provided state.
+*/
+func restore(fd int, state *terminalState) error {
+	return unix.IoctlSetTermios(fd, ioctlSetTermios, &state.termios)
+}
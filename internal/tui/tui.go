@@ -0,0 +1,198 @@
+/*
+Package tui is a full-screen terminal frontend for the game, played with the
+keyboard.
+*/
+package tui
+
+import (
+	"bufio"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pedrohenriques/go-minesweeper/internal/configs"
+	"github.com/pedrohenriques/go-minesweeper/internal/game"
+)
+
+// The screens of the TUI
+const (
+	screenSetup = iota
+	screenGame
+)
+
+// The escape sequences used to draw on the terminal
+const (
+	escEnterAltScreen = "\x1b[?1049h"
+	escExitAltScreen  = "\x1b[?1049l"
+	escHideCursor     = "\x1b[?25l"
+	escShowCursor     = "\x1b[?25h"
+	escCursorHome     = "\x1b[H"
+	escClearLine      = "\x1b[K"
+	escClearBelow     = "\x1b[J"
+	escReset          = "\x1b[0m"
+	escReverse        = "\x1b[7m"
+	escBold           = "\x1b[1m"
+)
+
+// TuiState holds the screen being shown and the game being played
+type tuiState struct {
+	config *configs.Configs
+	// The labels of the size options, sorted so the order is always the same
+	difficulties []string
+	screen       int
+	setup        setupState
+	gameConfig   game.GameConfig
+	gameInstance game.IGame
+	cursorRow    int
+	cursorCol    int
+	// The index of the tile suggested by the last hint, -1 if there is none
+	hintTileIndex int
+	// A message shown below the board, such as an error or the game's result
+	message string
+	quit    bool
+}
+
+/*
+Run starts the TUI on the process' terminal and returns when the player quits.
+*/
+func Run(config *configs.Configs) error {
+	fd := int(os.Stdin.Fd())
+	terminalState, error := makeRaw(fd)
+	if error != nil {
+		return error
+	}
+	defer restore(fd, terminalState)
+
+	output := bufio.NewWriter(os.Stdout)
+	output.WriteString(escEnterAltScreen + escHideCursor)
+	defer func() {
+		output.WriteString(escReset + escShowCursor + escExitAltScreen)
+		output.Flush()
+	}()
+
+	keysChannel := make(chan []keyPress)
+	go readKeys(os.Stdin, keysChannel)
+
+	state := newTuiState(config)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for !state.quit {
+		output.WriteString(render(state, time.Now()))
+		output.Flush()
+
+		select {
+		case keys, ok := <-keysChannel:
+			if !ok {
+				return nil
+			}
+			for _, key := range keys {
+				handleKey(state, key)
+			}
+		case <-ticker.C:
+		}
+	}
+
+	return nil
+}
+
+/*
+readKeys reads the terminal's input and sends the key presses to the channel,
+closing it when the input ends.
+*/
+func readKeys(input *os.File, keysChannel chan []keyPress) {
+	defer close(keysChannel)
+
+	buffer := make([]byte, 64)
+	for {
+		count, error := input.Read(buffer)
+		if error != nil {
+			return
+		}
+
+		keysChannel <- parseKeys(buffer[:count])
+	}
+}
+
+/*
+newTuiState creates the state of the TUI, starting on the setup screen.
+*/
+func newTuiState(config *configs.Configs) *tuiState {
+	difficulties := make([]string, 0, len(config.SizeOptions))
+	for key := range config.SizeOptions {
+		difficulties = append(difficulties, key)
+	}
+	sort.Slice(difficulties, func(i, j int) bool {
+		return config.SizeOptions[difficulties[i]].NumMines < config.SizeOptions[difficulties[j]].NumMines
+	})
+
+	return &tuiState{
+		config:        config,
+		difficulties:  difficulties,
+		screen:        screenSetup,
+		setup:         newSetupState(),
+		hintTileIndex: -1,
+	}
+}
+
+/*
+handleKey applies a key press to the screen being shown.
+*/
+func handleKey(state *tuiState, key keyPress) {
+	if key.Code == keyInterrupt || (key.Code == keyRune && key.Char == 'q') {
+		state.quit = true
+		return
+	}
+
+	switch state.screen {
+	case screenSetup:
+		handleSetupKey(state, key)
+	case screenGame:
+		handleGameKey(state, key)
+	}
+}
+
+/*
+render draws the screen being shown.
+Each line clears the rest of the previous frame, since raw mode needs explicit
+carriage returns.
+*/
+func render(state *tuiState, now time.Time) string {
+	var lines []string
+
+	switch state.screen {
+	case screenSetup:
+		lines = renderSetup(state)
+	case screenGame:
+		lines = renderGame(state, now)
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(escCursorHome)
+	for _, line := range lines {
+		builder.WriteString(line + escReset + escClearLine + "\r\n")
+	}
+	builder.WriteString(escClearBelow)
+
+	return builder.String()
+}
+
+/*
+startGame generates a game with the provided configuration and shows it.
+*/
+func startGame(state *tuiState, gameConfig game.GameConfig) {
+	gameInstance, error := game.Generate(gameConfig)
+	if error != nil {
+		state.message = error.Error()
+		return
+	}
+
+	state.gameConfig = gameConfig
+	state.gameInstance = gameInstance
+	state.screen = screenGame
+	state.cursorRow = 0
+	state.cursorCol = 0
+	state.hintTileIndex = -1
+	state.message = ""
+}
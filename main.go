@@ -3,10 +3,14 @@ package main
 import (
 	_ "embed"
 	"encoding/json"
+	"flag"
 	"log"
+	"time"
 
 	"github.com/pedrohenriques/go-minesweeper/internal/configs"
 	"github.com/pedrohenriques/go-minesweeper/internal/gui"
+	"github.com/pedrohenriques/go-minesweeper/internal/server"
+	"github.com/pedrohenriques/go-minesweeper/internal/tui"
 )
 
 //go:embed configs/main.json
@@ -16,11 +20,33 @@ var configFileData []byte
 main is the entry point into the application.
 */
 func main() {
+	frontend := flag.String("frontend", "gui", "the frontend to play with: gui or tui")
+	serveAddress := flag.String("serve", "", "if set, serves the HTTP API on this address, such as :8080, instead of starting a frontend")
+	idleTimeout := flag.Duration("idle-timeout", 30*time.Minute, "the time after which a game of the HTTP API that is not used is removed")
+	flag.Parse()
+
+	if *serveAddress != "" {
+		if *idleTimeout <= 0 {
+			log.Fatalf("The idle timeout '%v' must be positive", *idleTimeout)
+		}
+		log.Fatal(server.Run(*serveAddress, *idleTimeout))
+	}
+
 	config := &configs.Configs{}
 	err := json.Unmarshal(configFileData, config)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	gui.Run(config)
+	switch *frontend {
+	case "gui":
+		gui.Run(config)
+	case "tui":
+		err = tui.Run(config)
+		if err != nil {
+			log.Fatal(err)
+		}
+	default:
+		log.Fatalf("The frontend '%v' is not supported", *frontend)
+	}
 }